@@ -2,25 +2,62 @@ package main
 
 import (
 	"flag"
-	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/chrispyles/slow"
+	"github.com/chrispyles/slow/internal/diagnostic"
 	"github.com/chrispyles/slow/internal/interpreter"
 )
 
+// searchPathEnvVar is the environment variable containing the import search path.
+const searchPathEnvVar = "SLOWPATH"
+
 var (
+	debugFlag       = flag.Bool("debug", false, "print asts and values")
+	checkedFlag     = flag.Bool("checked", false, "raise an OverflowError when int or uint arithmetic overflows")
+	precisionFlag   = flag.Int("decimal-precision", slow.DefaultDecimalPrecision, "number of significant digits in inexact decimal results")
 	interpreterFlag = flag.Bool("i", false, "start the interpreter after running the file")
+	includeFlag     stringsFlag
+	errorFormatFlag diagnostic.Format
+	roundingFlag    slow.RoundingMode
 )
 
+func init() {
+	flag.Var(&includeFlag, "I", "add a directory to the import search path (may be repeated)")
+	flag.Var(&errorFormatFlag, "error-format", `format of error messages: "text" or "json"`)
+	flag.Var(&roundingFlag, "decimal-rounding", `rounding mode of inexact decimal results: "half_even", "half_up", "half_down", "up", "down", "ceiling", or "floor"`)
+}
+
+// useColor returns whether diagnostics written to f should be coloured: f must be a terminal and
+// the NO_COLOR environment variable must not be set.
+func useColor(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// stringsFlag is a flag that can be passed multiple times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, string(filepath.ListSeparator))
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
 func main() {
 	flag.Parse()
-	if flag.NArg() > 1 {
-		panic(fmt.Errorf("slow accepts 0 or 1 arguments, not %d", flag.NArg()))
-	}
 
 	var code []byte
-	if flag.NArg() == 1 {
+	if flag.NArg() > 0 {
 		var err error
 		code, err = os.ReadFile(flag.Arg(0))
 		if err != nil {
@@ -34,5 +71,27 @@ func main() {
 		rdr = os.Stdin
 	}
 
-	interpreter.Run(string(code), rdr)
+	// Directories passed with -I are searched before those in $SLOWPATH.
+	opts := []slow.Option{
+		slow.WithDebug(*debugFlag),
+		slow.WithCheckedArithmetic(*checkedFlag),
+		slow.WithDecimalContext(*precisionFlag, roundingFlag),
+		slow.WithSearchPath(includeFlag...),
+		slow.WithSearchPath(filepath.SplitList(os.Getenv(searchPathEnvVar))...),
+	}
+	if flag.NArg() > 0 {
+		// Arguments after the script are passed to it rather than parsed as flags.
+		opts = append(opts, slow.WithScript(flag.Arg(0)), slow.WithArgs(flag.Args()[1:]...))
+	}
+	interp := slow.New(opts...)
+	diagOpts := diagnostic.Options{Format: errorFormatFlag, Color: useColor(os.Stderr)}
+	if err := interpreter.Run(interp, string(code), rdr, diagOpts); err != nil {
+		if ee, ok := err.(*slow.ExitError); ok {
+			os.Exit(ee.Code)
+		}
+		if err == interpreter.ErrUncaught {
+			os.Exit(1)
+		}
+		panic(err)
+	}
 }
@@ -5,19 +5,15 @@ import (
 	"strings"
 	"syscall/js"
 
-	"github.com/chrispyles/slow/internal/eval"
+	"github.com/chrispyles/slow"
+	"github.com/chrispyles/slow/internal/diagnostic"
 	"github.com/chrispyles/slow/internal/interpreter"
-	"github.com/chrispyles/slow/internal/printer"
 	"github.com/chrispyles/slow/internal/reader"
 )
 
 func main() {
-	env := interpreter.Run(string(""), nil)
-
-	var out string
-	printer.Set(func(s string) {
-		out += s
-	})
+	var out strings.Builder
+	interp := slow.New(slow.WithStdout(&out), slow.WithStderr(&out))
 
 	js.Global().Set("evalSlow", js.FuncOf(func(_ js.Value, args []js.Value) any {
 		in := args[0].String()
@@ -28,10 +24,12 @@ func main() {
 		if _, err := reader.IsCompleteStatement(in); err != nil {
 			return fmt.Sprintf("%+v", err)
 		}
-		eval.Eval(in, env, true)
 		// Reset out after its value is retrieved.
-		defer func() { out = "" }()
-		return out
+		defer out.Reset()
+		// The playground can't be exited, so an error from calling exit is ignored; exit has already
+		// printed its message to out.
+		interpreter.EvalAndPrint(interp, in, true, diagnostic.Options{})
+		return out.String()
 	}))
 
 	// Open a channel and block on reading from it to keep the binary running, otherwise it exits
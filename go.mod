@@ -4,7 +4,6 @@ go 1.23
 
 require (
 	github.com/google/go-cmp v0.5.9
-	github.com/pkg/errors v0.9.1
 	github.com/sanity-io/litter v1.5.5
 )
 
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 )
 
@@ -11,17 +12,26 @@ type AssignmentTarget struct {
 }
 
 type AssignmentNode struct {
+	Node
 	Left  AssignmentTarget
 	Right execute.Expression
 }
 
 func (n *AssignmentNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *AssignmentNode) execute(e *execute.Environment) (execute.Value, error) {
 	expr, err := n.Right.Execute(e)
 	if err != nil {
 		return nil, err
 	}
 	if n := n.Left.Variable; n != "" {
-		return e.Set(n, expr)
+		v, err := e.Set(n, expr)
+		if err != nil {
+			return nil, errors.SuggestName(err, n, e.VisibleNames())
+		}
+		return v, nil
 	}
 	if an := n.Left.Attribute; an != nil {
 		val, err := an.Left.Execute(e)
@@ -1,11 +1,41 @@
 package ast
 
 import (
-	"github.com/chrispyles/slow/internal/config"
+	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/source"
+	"github.com/chrispyles/slow/internal/types"
 	"github.com/sanity-io/litter"
 )
 
+// Node contains the fields common to every node in the AST and is embedded in each node type.
+type Node struct {
+	// Span is the location of the node in the source code.
+	Span source.Span
+}
+
+// Location returns the location of the node in the source code.
+func (n *Node) Location() source.Span {
+	return n.Span
+}
+
+// SetLocation sets the location of the node in the source code.
+func (n *Node) SetLocation(s source.Span) {
+	n.Span = s
+}
+
+// locate records the node's location on an error returned while executing it. Nodes that can return
+// errors wrap their implementation of Execute in it.
+func (n *Node) locate(v execute.Value, err error) (execute.Value, error) {
+	return v, errors.Locate(err, n.Span)
+}
+
+// Located is implemented by every node in the AST.
+type Located interface {
+	Location() source.Span
+	SetLocation(source.Span)
+}
+
 type AST struct {
 	Nodes execute.Block
 }
@@ -15,16 +45,17 @@ func New(nodes execute.Block) *AST {
 }
 
 func (a *AST) Execute(e *execute.Environment) (execute.Value, error) {
-	var val execute.Value
+	rt := e.Runtime()
+	// An empty program evaluates to null.
+	var val execute.Value = types.Null
 	var err error
 	for _, n := range a.Nodes {
 		val, err = n.Execute(e)
 		if err != nil {
 			return nil, err
 		}
-		if *config.Debug {
-			print("<AST EXECUTE LITTER> ")
-			litter.Dump(val)
+		if rt.IsDebug() {
+			rt.Print("<AST EXECUTE LITTER> " + litter.Sdump(val))
 		}
 	}
 	return val, err
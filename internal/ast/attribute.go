@@ -5,11 +5,16 @@ import (
 )
 
 type AttributeNode struct {
+	Node
 	Left  execute.Expression
 	Right string
 }
 
 func (n *AttributeNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *AttributeNode) execute(e *execute.Environment) (execute.Value, error) {
 	expr, err := n.Left.Execute(e)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,16 @@
+package ast
+
+import (
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+)
+
+// BadNode is a placeholder for a statement that could not be parsed. It only appears in partial ASTs,
+// which are produced for tools that need to work with code that contains syntax errors.
+type BadNode struct {
+	Node
+}
+
+func (n *BadNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return nil, errors.SyntaxErrorAt(n.Span, "cannot execute a statement that could not be parsed", "")
+}
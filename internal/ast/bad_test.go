@@ -0,0 +1,21 @@
+package ast
+
+import (
+	"testing"
+
+	asttesting "github.com/chrispyles/slow/internal/ast/internal/testing"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/source"
+)
+
+func TestBadNode(t *testing.T) {
+	span := source.Span{
+		Start: source.Pos{Offset: 4, Line: 2, Column: 1},
+		End:   source.Pos{Offset: 9, Line: 2, Column: 6},
+	}
+	asttesting.RunTestCase(t, asttesting.TestCase{
+		Node:        &BadNode{Node: Node{Span: span}},
+		WantErr:     errors.SyntaxErrorAt(span, "cannot execute a statement that could not be parsed", ""),
+		WantSameEnv: true,
+	})
+}
@@ -6,12 +6,17 @@ import (
 )
 
 type BinaryOpNode struct {
+	Node
 	Op    *operators.BinaryOperator
 	Left  execute.Expression
 	Right execute.Expression
 }
 
 func (n *BinaryOpNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *BinaryOpNode) execute(e *execute.Environment) (execute.Value, error) {
 	le, err := n.Left.Execute(e)
 	if err != nil {
 		return nil, err
@@ -20,7 +25,7 @@ func (n *BinaryOpNode) Execute(e *execute.Environment) (execute.Value, error) {
 	if err != nil {
 		return nil, err
 	}
-	val, err := n.Op.Value(le, re)
+	val, err := n.Op.ValueWith(le, re, operators.RuntimeOptions(e.Runtime()))
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +40,7 @@ func TestBinaryOpNode(t *testing.T) {
 				Op: operators.BinOp_RPLUS,
 				Left: &IndexNode{
 					Container: &VariableNode{Name: "foo"},
-					Index:     &ConstantNode{types.NewInt(0)},
+					Index:     &ConstantNode{Value: types.NewInt(0)},
 				},
 				Right: &ConstantNode{Value: types.NewInt(3)},
 			},
@@ -8,7 +8,9 @@ type breakError struct{}
 
 func (*breakError) Error() string { return "" }
 
-type BreakNode struct{}
+type BreakNode struct {
+	Node
+}
 
 func (*BreakNode) Execute(e *execute.Environment) (execute.Value, error) {
 	return nil, &breakError{}
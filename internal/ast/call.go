@@ -5,11 +5,16 @@ import (
 )
 
 type CallNode struct {
+	Node
 	Func execute.Expression
 	Args []execute.Expression
 }
 
 func (n *CallNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *CallNode) execute(e *execute.Environment) (execute.Value, error) {
 	expr, err := n.Func.Execute(e)
 	if err != nil {
 		return nil, err
@@ -17,11 +17,16 @@ var castingUnsupportedTypes = map[execute.Type]bool{
 }
 
 type CastNode struct {
+	Node
 	Expr execute.Expression
 	Type execute.Type
 }
 
 func (n *CastNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *CastNode) execute(e *execute.Environment) (execute.Value, error) {
 	if castingUnsupportedTypes[n.Type] {
 		return nil, errors.InvalidTypeCastTarget(n.Type)
 	}
@@ -29,5 +34,8 @@ func (n *CastNode) Execute(e *execute.Environment) (execute.Value, error) {
 	if err != nil {
 		return nil, err
 	}
+	if e.Runtime().IsCheckedArithmetic() {
+		return types.CheckedNew(n.Type, val)
+	}
 	return n.Type.New(val)
 }
@@ -5,6 +5,7 @@ import (
 )
 
 type ConstantNode struct {
+	Node
 	Value execute.Value
 }
 
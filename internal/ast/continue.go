@@ -8,7 +8,9 @@ type continueError struct{}
 
 func (*continueError) Error() string { return "" }
 
-type ContinueNode struct{}
+type ContinueNode struct {
+	Node
+}
 
 func (*ContinueNode) Execute(e *execute.Environment) (execute.Value, error) {
 	return nil, &continueError{}
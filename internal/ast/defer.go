@@ -6,6 +6,7 @@ import (
 )
 
 type DeferNode struct {
+	Node
 	Expr execute.Expression
 }
 
@@ -0,0 +1,54 @@
+package ast
+
+import (
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// ExportNode marks variables as exported from the module that they are declared in. It either
+// wraps a declaration, whose variable is exported once declared, or lists the names of variables
+// that have already been declared.
+type ExportNode struct {
+	Node
+	// Decl is a VarNode or FuncNode; may be nil
+	Decl  execute.Expression
+	Names []string
+}
+
+// ExportedNames returns the names of the variables exported by this node.
+func (n *ExportNode) ExportedNames() []string {
+	if n.Decl != nil {
+		return []string{declName(n.Decl)}
+	}
+	return n.Names
+}
+
+func (n *ExportNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *ExportNode) execute(e *execute.Environment) (execute.Value, error) {
+	if n.Decl != nil {
+		v, err := n.Decl.Execute(e)
+		if err != nil {
+			return nil, err
+		}
+		return v, e.Export(declName(n.Decl))
+	}
+	for _, name := range n.Names {
+		if err := e.Export(name); err != nil {
+			return nil, err
+		}
+	}
+	return types.Null, nil
+}
+
+func declName(decl execute.Expression) string {
+	switch d := decl.(type) {
+	case *VarNode:
+		return d.Name
+	case *FuncNode:
+		return d.Name
+	}
+	panic("ExportNode.Decl is not a declaration")
+}
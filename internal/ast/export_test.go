@@ -0,0 +1,69 @@
+package ast
+
+import (
+	"testing"
+
+	asttesting "github.com/chrispyles/slow/internal/ast/internal/testing"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowtesting "github.com/chrispyles/slow/internal/testing"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func exportedEnv(t *testing.T, vars map[string]execute.Value, exports ...string) *execute.Environment {
+	e := slowtesting.MustMakeEnv(t, vars)
+	for _, n := range exports {
+		if err := e.Export(n); err != nil {
+			t.Fatalf("failed to export variable %q: %v", n, err)
+		}
+	}
+	return e
+}
+
+func TestExportNode(t *testing.T) {
+	funcEnv := execute.NewEnvironment()
+	for _, tc := range []asttesting.TestCase{
+		{
+			Name: "var",
+			Node: &ExportNode{Decl: &VarNode{Name: "x", Value: &ConstantNode{Value: types.NewInt(1)}}},
+			Env:  execute.NewEnvironment(),
+			Want: types.NewInt(1),
+			WantEnv: exportedEnv(t, map[string]execute.Value{
+				"x": types.NewInt(1),
+			}, "x"),
+		},
+		{
+			Name: "func",
+			Node: &ExportNode{Decl: &FuncNode{Name: "f"}},
+			Env:  funcEnv,
+			Want: types.NewFunc("f", nil, nil, funcEnv, ""),
+			WantEnv: exportedEnv(t, map[string]execute.Value{
+				"f": types.NewFunc("f", nil, nil, funcEnv, ""),
+			}, "f"),
+		},
+		{
+			Name: "names",
+			Node: &ExportNode{Names: []string{"x", "y"}},
+			Env: slowtesting.MustMakeEnv(t, map[string]execute.Value{
+				"x": types.NewInt(1),
+				"y": types.NewInt(2),
+				"z": types.NewInt(3),
+			}),
+			Want: types.Null,
+			WantEnv: exportedEnv(t, map[string]execute.Value{
+				"x": types.NewInt(1),
+				"y": types.NewInt(2),
+				"z": types.NewInt(3),
+			}, "x", "y"),
+		},
+		{
+			Name:        "undeclared",
+			Node:        &ExportNode{Names: []string{"x"}},
+			Env:         execute.NewEnvironment(),
+			WantErr:     errors.NewNameError("x"),
+			WantSameEnv: true,
+		},
+	} {
+		asttesting.RunTestCase(t, tc)
+	}
+}
@@ -8,7 +8,9 @@ type fallthroughError struct{}
 
 func (*fallthroughError) Error() string { return "" }
 
-type FallthroughNode struct{}
+type FallthroughNode struct {
+	Node
+}
 
 func (*FallthroughNode) Execute(e *execute.Environment) (execute.Value, error) {
 	return nil, &fallthroughError{}
@@ -5,12 +5,17 @@ import (
 )
 
 type ForNode struct {
+	Node
 	IterName string
 	Iter     execute.Expression
 	Body     execute.Block
 }
 
 func (n *ForNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *ForNode) execute(e *execute.Environment) (execute.Value, error) {
 	var val execute.Value
 	expr, err := n.Iter.Execute(e)
 	if err != nil {
@@ -1,20 +1,35 @@
 package ast
 
 import (
+	"path/filepath"
+
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/types"
 )
 
 type FuncNode struct {
+	Node
 	Name     string
 	ArgNames []string
 	Body     execute.Block
 }
 
 func (n *FuncNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *FuncNode) execute(e *execute.Environment) (execute.Value, error) {
 	if err := e.Declare(n.Name); err != nil {
 		return nil, err
 	}
-	ft := types.NewFunc(n.Name, n.ArgNames, n.Body)
+	// The main script's path may be relative, so it is made absolute in case the working directory
+	// changes before the function is called.
+	file := e.Runtime().CurrentFile()
+	if file != "" {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+	}
+	ft := types.NewFunc(n.Name, n.ArgNames, n.Body, e, file)
 	return e.Set(n.Name, ft)
 }
@@ -5,12 +5,17 @@ import (
 )
 
 type IfNode struct {
+	Node
 	Cond     execute.Expression
 	Body     execute.Block
 	ElseBody execute.Block
 }
 
 func (n *IfNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *IfNode) execute(e *execute.Environment) (execute.Value, error) {
 	expr, err := n.Cond.Execute(e)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,49 @@
+package ast
+
+import (
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// ImportNode imports a module using the import builtin and binds either the module or some of its
+// exports to constants.
+type ImportNode struct {
+	Node
+	Path string
+	// Alias is the name the module is bound to; ignored if Names is non-empty.
+	Alias string
+	// Names are the exports of the module to bind.
+	Names []string
+}
+
+func (n *ImportNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *ImportNode) execute(e *execute.Environment) (execute.Value, error) {
+	importFn, err := e.Get("import")
+	if err != nil {
+		return nil, err
+	}
+	c, err := importFn.ToCallable()
+	if err != nil {
+		return nil, err
+	}
+	mod, err := c.Call(e.NewFrame(), types.NewStr(n.Path))
+	if err != nil {
+		return nil, err
+	}
+	if len(n.Names) == 0 {
+		return e.DeclareConst(n.Alias, mod)
+	}
+	for _, name := range n.Names {
+		v, err := mod.GetAttribute(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := e.DeclareConst(name, v); err != nil {
+			return nil, err
+		}
+	}
+	return mod, nil
+}
@@ -0,0 +1,69 @@
+package ast
+
+import (
+	"testing"
+
+	asttesting "github.com/chrispyles/slow/internal/ast/internal/testing"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowtesting "github.com/chrispyles/slow/internal/testing"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func TestImportNode(t *testing.T) {
+	mod := types.NewModuleWithExports("m", slowtesting.MustMakeEnv(t, map[string]execute.Value{
+		"a": types.NewInt(1),
+		"b": types.NewInt(2),
+		"c": types.NewInt(3),
+	}), []string{"a", "b"})
+	importFn := types.NewGoFunc("import", func(vs ...execute.Value) (execute.Value, error) {
+		if s, _ := vs[0].ToStr(); s != "m" {
+			return nil, errors.NewImportError(s)
+		}
+		return mod, nil
+	})
+	withConsts := func(consts map[string]execute.Value) *execute.Environment {
+		e := slowtesting.MustMakeEnv(t, map[string]execute.Value{"import": importFn})
+		for n, v := range consts {
+			if _, err := e.DeclareConst(n, v); err != nil {
+				t.Fatalf("failed to declare constant %q: %v", n, err)
+			}
+		}
+		return e
+	}
+	for _, tc := range []asttesting.TestCase{
+		{
+			Name:    "alias",
+			Node:    &ImportNode{Path: "m", Alias: "n"},
+			Env:     withConsts(nil),
+			Want:    mod,
+			WantEnv: withConsts(map[string]execute.Value{"n": mod}),
+		},
+		{
+			Name: "names",
+			Node: &ImportNode{Path: "m", Names: []string{"a", "b"}},
+			Env:  withConsts(nil),
+			Want: mod,
+			WantEnv: withConsts(map[string]execute.Value{
+				"a": types.NewInt(1),
+				"b": types.NewInt(2),
+			}),
+		},
+		{
+			Name:        "unexported_name",
+			Node:        &ImportNode{Path: "m", Names: []string{"c"}},
+			Env:         withConsts(nil),
+			WantErr:     errors.NewAttributeError(types.ModuleType, "c"),
+			WantSameEnv: true,
+		},
+		{
+			Name:        "no_such_module",
+			Node:        &ImportNode{Path: "x", Alias: "x"},
+			Env:         withConsts(nil),
+			WantErr:     errors.NewImportError("x"),
+			WantSameEnv: true,
+		},
+	} {
+		asttesting.RunTestCase(t, tc)
+	}
+}
@@ -5,11 +5,16 @@ import (
 )
 
 type IndexNode struct {
+	Node
 	Container execute.Expression
 	Index     execute.Expression
 }
 
 func (n *IndexNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *IndexNode) execute(e *execute.Environment) (execute.Value, error) {
 	container, err := n.Container.Execute(e)
 	if err != nil {
 		return nil, err
@@ -6,10 +6,15 @@ import (
 )
 
 type ListNode struct {
+	Node
 	Values []execute.Expression
 }
 
 func (n *ListNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *ListNode) execute(e *execute.Environment) (execute.Value, error) {
 	vs := make([]execute.Value, len(n.Values))
 	for i, expr := range n.Values {
 		v, err := expr.Execute(e)
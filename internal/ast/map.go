@@ -6,10 +6,15 @@ import (
 )
 
 type MapNode struct {
+	Node
 	Values [][]execute.Expression
 }
 
 func (n *MapNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *MapNode) execute(e *execute.Environment) (execute.Value, error) {
 	m := types.NewMap()
 	for _, kv := range n.Values {
 		k, err := kv[0].Execute(e)
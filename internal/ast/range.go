@@ -6,12 +6,17 @@ import (
 )
 
 type RangeNode struct {
+	Node
 	Start execute.Expression
 	Stop  execute.Expression
 	Step  execute.Expression
 }
 
 func (n *RangeNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *RangeNode) execute(e *execute.Environment) (execute.Value, error) {
 	var start, stop, step execute.Value
 	var err error
 	if n.Start != nil {
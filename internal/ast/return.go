@@ -6,10 +6,15 @@ import (
 )
 
 type ReturnNode struct {
+	Node
 	Value execute.Expression
 }
 
 func (n *ReturnNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *ReturnNode) execute(e *execute.Environment) (execute.Value, error) {
 	value, err := n.Value.Execute(e)
 	if err != nil {
 		return nil, err
@@ -10,12 +10,17 @@ type SwitchCase struct {
 }
 
 type SwitchNode struct {
+	Node
 	Value       execute.Expression
 	Cases       []SwitchCase
 	DefaultCase execute.Block
 }
 
 func (n *SwitchNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *SwitchNode) execute(e *execute.Environment) (execute.Value, error) {
 	valueExpr, err := n.Value.Execute(e)
 	if err != nil {
 		return nil, err
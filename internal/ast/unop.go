@@ -6,16 +6,21 @@ import (
 )
 
 type UnaryOpNode struct {
+	Node
 	Op   *operators.UnaryOperator
 	Expr execute.Expression
 }
 
 func (n *UnaryOpNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *UnaryOpNode) execute(e *execute.Environment) (execute.Value, error) {
 	operand, err := n.Expr.Execute(e)
 	if err != nil {
 		return nil, err
 	}
-	val, err := n.Op.Value(operand)
+	val, err := n.Op.ValueWith(operand, operators.RuntimeOptions(e.Runtime()))
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ import (
 )
 
 type VarNode struct {
+	Node
 	Name    string
 	IsConst bool
 	// Value of the expression if it is assigned; may be nil
@@ -13,6 +14,10 @@ type VarNode struct {
 }
 
 func (n *VarNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *VarNode) execute(e *execute.Environment) (execute.Value, error) {
 	var val execute.Value
 	if n.Value != nil {
 		var err error
@@ -1,14 +1,24 @@
 package ast
 
 import (
+	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 )
 
 // VariableNode represents a variable access, not a declaration.
 type VariableNode struct {
+	Node
 	Name string
 }
 
 func (n *VariableNode) Execute(e *execute.Environment) (execute.Value, error) {
-	return e.Get(n.Name)
+	return n.locate(n.execute(e))
+}
+
+func (n *VariableNode) execute(e *execute.Environment) (execute.Value, error) {
+	v, err := e.Get(n.Name)
+	if err != nil {
+		return nil, errors.SuggestName(err, n.Name, e.VisibleNames())
+	}
+	return v, nil
 }
@@ -2,8 +2,45 @@ package ast
 
 import (
 	"testing"
+
+	asttesting "github.com/chrispyles/slow/internal/ast/internal/testing"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/source"
+	"github.com/chrispyles/slow/internal/types"
 )
 
 func TestVariableNode(t *testing.T) {
-
+	span := source.Span{
+		File:  "a.slo",
+		Start: source.Pos{Offset: 0, Line: 1, Column: 1},
+		End:   source.Pos{Offset: 6, Line: 1, Column: 7},
+	}
+	tests := []asttesting.TestCase{
+		{
+			Name:        "success",
+			Node:        &VariableNode{Name: "length"},
+			Env:         execute.FromMap(map[string]execute.Value{"length": types.NewInt(1)}),
+			Want:        types.NewInt(1),
+			WantSameEnv: true,
+		},
+		{
+			Name:        "undeclared",
+			Node:        &VariableNode{Name: "x"},
+			Env:         execute.FromMap(map[string]execute.Value{"length": types.NewInt(1)}),
+			WantErr:     errors.NewNameError("x"),
+			WantSameEnv: true,
+		},
+		{
+			Name: "misspelled",
+			Node: &VariableNode{Node: Node{Span: span}, Name: "lenght"},
+			Env:  execute.FromMap(map[string]execute.Value{"length": types.NewInt(1)}).NewFrame(),
+			WantErr: errors.Locate(
+				errors.WithHint(errors.NewNameError("lenght"), `did you mean "length"?`), span),
+			WantSameEnv: true,
+		},
+	}
+	for _, tc := range tests {
+		asttesting.RunTestCase(t, tc)
+	}
 }
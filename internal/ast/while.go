@@ -5,11 +5,16 @@ import (
 )
 
 type WhileNode struct {
+	Node
 	Cond execute.Expression
 	Body execute.Block
 }
 
 func (n *WhileNode) Execute(e *execute.Environment) (execute.Value, error) {
+	return n.locate(n.execute(e))
+}
+
+func (n *WhileNode) execute(e *execute.Environment) (execute.Value, error) {
 	frame := e.NewFrame()
 	var val execute.Value
 	for {
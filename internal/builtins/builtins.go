@@ -1,50 +1,73 @@
 package builtins
 
 import (
+	"github.com/chrispyles/slow/internal/builtins/modules"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/types"
 )
 
-// RootEnvironment is a frozen environment containing all builtins. All execution environments
-// should be child frames of this environment.
-var RootEnvironment *execute.Environment
+// state is the interpreter-specific state that builtins are bound to when a root environment is
+// created.
+type state struct {
+	runtime *execute.Runtime
+	modules *modules.Registry
+	root    *execute.Environment
+	// files is the cache of imported files, keyed by canonical path.
+	files map[string]*types.Module
+}
+
+// static wraps a builtin that does not depend on any interpreter state.
+func static(f types.FuncImpl) func(*state) types.FuncImpl {
+	return func(*state) types.FuncImpl { return f }
+}
 
 var builtins = []struct {
 	name string
-	f    types.FuncImpl
+	f    func(*state) types.FuncImpl
 }{
+	{
+		name: "dir",
+		f:    static(dirImpl),
+	},
 	{
 		name: "exit",
-		f:    exitImpl,
+		f:    func(s *state) types.FuncImpl { return s.exitImpl },
 	},
 	{
 		name: "import",
-		f:    importImpl,
+		f:    func(s *state) types.FuncImpl { return s.importImpl },
 	},
 	{
 		name: "len",
-		f:    lenImpl,
+		f:    static(lenImpl),
+	},
+	{
+		name: "modules",
+		f:    func(s *state) types.FuncImpl { return s.modulesImpl },
 	},
 	{
 		name: "print",
-		f:    printImpl,
+		f:    func(s *state) types.FuncImpl { return s.printImpl },
 	},
 	{
 		name: "range",
-		f:    rangeImpl,
+		f:    static(rangeImpl),
 	},
 	{
 		name: "type",
-		f:    typeImpl,
+		f:    static(typeImpl),
 	},
 }
 
-// newRootEnvironment creates a new root environment populated with every builtin. The returned
-// environment is frozen, so a child frame must be created from it before declaring any variables.
-func newRootEnvironment() *execute.Environment {
-	e := execute.NewEnvironment()
+// NewRootEnvironment creates a new root environment populated with every builtin, each of which is
+// bound to the provided runtime and module registry. The returned environment is frozen, so a child
+// frame must be created from it before declaring any variables. All execution environments of an
+// interpreter should be child frames of its root environment.
+func NewRootEnvironment(rt *execute.Runtime, reg *modules.Registry) *execute.Environment {
+	e := execute.NewRootEnvironment(rt)
+	s := &state{runtime: rt, modules: reg, root: e, files: make(map[string]*types.Module)}
 	for _, b := range builtins {
-		f := types.NewGoFunc(b.name, b.f)
+		f := types.NewGoFunc(b.name, b.f(s))
 		e.Declare(b.name)
 		e.Set(b.name, f)
 	}
@@ -52,7 +75,3 @@ func newRootEnvironment() *execute.Environment {
 	e.Freeze()
 	return e
 }
-
-func init() {
-	RootEnvironment = newRootEnvironment()
-}
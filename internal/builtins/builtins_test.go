@@ -1,10 +1,11 @@
 package builtins
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/chrispyles/slow/internal/builtins/modules"
 	"github.com/chrispyles/slow/internal/execute"
-	"github.com/chrispyles/slow/internal/printer"
 	slowtesting "github.com/chrispyles/slow/internal/testing"
 	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
 	"github.com/google/go-cmp/cmp"
@@ -17,24 +18,22 @@ type builtinTest struct {
 	makeMock    func() []any
 	cleanupMock func()
 	want        execute.Value
-	wantPrints  []string
+	wantPrints  string
 	wantCalls   []any
 	wantErr     error
+	cmpOpts     []cmp.Option
 }
 
 func doBuiltinTest(t *testing.T, tests []builtinTest) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			var printed []string
-			printer.Set(func(s string) {
-				printed = append(printed, s)
-			})
+			var stdout strings.Builder
 			var gotCalls []any
 			if tc.makeMock != nil {
 				gotCalls = tc.makeMock()
 				t.Cleanup(tc.cleanupMock)
 			}
-			env := RootEnvironment.NewFrame()
+			env := newTestRootEnvironment(&stdout).NewFrame()
 			fn, err := env.Get(tc.fn)
 			if err != nil {
 				t.Fatalf("Get() returned unexpected error: %v", err)
@@ -47,10 +46,11 @@ func doBuiltinTest(t *testing.T, tests []builtinTest) {
 			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
 				t.Errorf("c.Call() returned incorrect error (-want +got):\n%s", diff)
 			}
-			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported(), slowcmpopts.EquateFuncs()); diff != "" {
+			opts := append([]cmp.Option{slowcmpopts.AllowUnexported(), slowcmpopts.EquateFuncs()}, tc.cmpOpts...)
+			if diff := cmp.Diff(tc.want, got, opts...); diff != "" {
 				t.Errorf("c.Call() returned incorrect value (-want +got):\n%s", diff)
 			}
-			if diff := cmp.Diff(tc.wantPrints, printed); diff != "" {
+			if diff := cmp.Diff(tc.wantPrints, stdout.String()); diff != "" {
 				t.Errorf("println called incorrectly (-want +got):\n%s", diff)
 			}
 			if diff := cmp.Diff(tc.wantCalls, gotCalls, slowcmpopts.AllowUnexported()); diff != "" {
@@ -60,9 +60,14 @@ func doBuiltinTest(t *testing.T, tests []builtinTest) {
 	}
 }
 
+func newTestRootEnvironment(stdout *strings.Builder) *execute.Environment {
+	rt := &execute.Runtime{Stdout: stdout}
+	return NewRootEnvironment(rt, modules.NewRegistry(rt))
+}
+
 func TestNewRootEnvironmentIsFrozen(t *testing.T) {
 	// Attempt to reassign a variable that is bound to a built-in, so we know it's already declared.
-	_, err := RootEnvironment.Set("import", &slowtesting.MockValue{})
+	_, err := newTestRootEnvironment(&strings.Builder{}).Set("import", &slowtesting.MockValue{})
 	if err == nil {
 		t.Errorf("env.Set did not error")
 	}
@@ -0,0 +1,22 @@
+package builtins
+
+import (
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func dirImpl(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("dir", len(args), 1)
+	}
+	m, ok := args[0].(*types.Module)
+	if !ok {
+		return nil, errors.NewTypeError(args[0].Type(), types.ModuleType)
+	}
+	var names []execute.Value
+	for _, n := range m.Exports() {
+		names = append(names, types.NewStr(n))
+	}
+	return types.NewList(names), nil
+}
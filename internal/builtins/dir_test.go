@@ -0,0 +1,43 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func TestBuiltins_dir(t *testing.T) {
+	env := execute.NewEnvironment()
+	for _, n := range []string{"foo", "_bar", "baz"} {
+		env.Declare(n)
+		env.Set(n, types.NewInt(1))
+	}
+	doBuiltinTest(t, []builtinTest{
+		{
+			name: "module",
+			fn:   "dir",
+			args: []execute.Value{types.NewModuleWithExports("m", env, []string{"baz", "foo"})},
+			want: types.NewList([]execute.Value{types.NewStr("baz"), types.NewStr("foo")}),
+		},
+		{
+			name: "no_exports",
+			fn:   "dir",
+			args: []execute.Value{types.NewModuleWithExports("m", env, nil)},
+			want: types.NewList(nil),
+		},
+		{
+			name:    "not_a_module",
+			fn:      "dir",
+			args:    []execute.Value{types.NewInt(1)},
+			wantErr: errors.NewTypeError(types.IntType, types.ModuleType),
+		},
+		{
+			name:    "no_args",
+			fn:      "dir",
+			args:    []execute.Value{},
+			wantErr: errors.CallError("dir", 0, 1),
+		},
+	})
+}
@@ -1,18 +1,23 @@
 package builtins
 
 import (
-	"os"
+	"fmt"
 
 	"github.com/chrispyles/slow/internal/execute"
-	"github.com/chrispyles/slow/internal/printer"
 	"github.com/chrispyles/slow/internal/types"
 )
 
-var (
-	osExit = os.Exit
-)
+// ExitError is an error that indicates that the exit builtin has been called. It is propagated up
+// to the caller of the interpreter, which decides how to end the program.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit code %d", e.Code)
+}
 
-func exitImpl(args ...execute.Value) (execute.Value, error) {
+func (s *state) exitImpl(args ...execute.Value) (execute.Value, error) {
 	var code int64
 	if len(args) > 0 {
 		var err error
@@ -21,7 +26,6 @@ func exitImpl(args ...execute.Value) (execute.Value, error) {
 			code, _ = types.NewBool(args[0].ToBool()).ToInt()
 		}
 	}
-	printer.Printlnf("Exiting with code %d", code)
-	osExit(int(code))
-	return types.Null, nil
+	s.runtime.Printlnf("Exiting with code %d", code)
+	return nil, &ExitError{int(code)}
 }
@@ -1,7 +1,6 @@
 package builtins
 
 import (
-	"os"
 	"testing"
 
 	"github.com/chrispyles/slow/internal/execute"
@@ -9,78 +8,54 @@ import (
 )
 
 func TestBuiltins_exit(t *testing.T) {
-	makeExitMock := func() []any {
-		calls := make([]any, 1)
-		var i int
-		osExit = func(c int) {
-			calls[i] = c
-			i++
-		}
-		return calls
-	}
-	cleanupExitMock := func() {
-		osExit = os.Exit
-	}
 	doBuiltinTest(t, []builtinTest{
 		{
-			name:        "no_args",
-			fn:          "exit",
-			args:        []execute.Value{},
-			makeMock:    makeExitMock,
-			cleanupMock: cleanupExitMock,
-			want:        types.Null,
-			wantPrints:  []string{"Exiting with code 0\n"},
-			wantCalls:   []any{0},
+			name:       "no_args",
+			fn:         "exit",
+			args:       []execute.Value{},
+			wantPrints: "Exiting with code 0\n",
+			wantErr:    &ExitError{0},
 		},
 		{
-			name:        "one_arg",
-			fn:          "exit",
-			args:        []execute.Value{types.NewInt(1)},
-			makeMock:    makeExitMock,
-			cleanupMock: cleanupExitMock,
-			want:        types.Null,
-			wantPrints:  []string{"Exiting with code 1\n"},
-			wantCalls:   []any{1},
+			name:       "one_arg",
+			fn:         "exit",
+			args:       []execute.Value{types.NewInt(1)},
+			wantPrints: "Exiting with code 1\n",
+			wantErr:    &ExitError{1},
 		},
 		{
-			name:        "one_float",
-			fn:          "exit",
-			args:        []execute.Value{types.NewFloat(1.2)},
-			makeMock:    makeExitMock,
-			cleanupMock: cleanupExitMock,
-			want:        types.Null,
-			wantPrints:  []string{"Exiting with code 1\n"},
-			wantCalls:   []any{1},
+			name:       "one_float",
+			fn:         "exit",
+			args:       []execute.Value{types.NewFloat(1.2)},
+			wantPrints: "Exiting with code 1\n",
+			wantErr:    &ExitError{1},
 		},
 		{
-			name:        "arg_cant_be_converted_to_int",
-			fn:          "exit",
-			args:        []execute.Value{types.NewList(nil)},
-			makeMock:    makeExitMock,
-			cleanupMock: cleanupExitMock,
-			want:        types.Null,
-			wantPrints:  []string{"Exiting with code 1\n"},
-			wantCalls:   []any{1},
+			name:       "arg_cant_be_converted_to_int",
+			fn:         "exit",
+			args:       []execute.Value{types.NewList(nil)},
+			wantPrints: "Exiting with code 1\n",
+			wantErr:    &ExitError{1},
 		},
 		{
-			name:        "falsey_non_numeric_arg",
-			fn:          "exit",
-			args:        []execute.Value{types.NewBytes(nil)},
-			makeMock:    makeExitMock,
-			cleanupMock: cleanupExitMock,
-			want:        types.Null,
-			wantPrints:  []string{"Exiting with code 0\n"},
-			wantCalls:   []any{0},
+			name:       "falsey_non_numeric_arg",
+			fn:         "exit",
+			args:       []execute.Value{types.NewBytes(nil)},
+			wantPrints: "Exiting with code 0\n",
+			wantErr:    &ExitError{0},
 		},
 		{
-			name:        "multiple_args",
-			fn:          "exit",
-			args:        []execute.Value{types.NewInt(2), types.NewInt(3)},
-			makeMock:    makeExitMock,
-			cleanupMock: cleanupExitMock,
-			want:        types.Null,
-			wantPrints:  []string{"Exiting with code 2\n"},
-			wantCalls:   []any{2},
+			name:       "multiple_args",
+			fn:         "exit",
+			args:       []execute.Value{types.NewInt(2), types.NewInt(3)},
+			wantPrints: "Exiting with code 2\n",
+			wantErr:    &ExitError{2},
 		},
 	})
 }
+
+func TestExitError(t *testing.T) {
+	if got, want := (&ExitError{3}).Error(), "exit code 3"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
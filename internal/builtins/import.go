@@ -2,21 +2,25 @@ package builtins
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
-	"github.com/chrispyles/slow/internal/builtins/modules"
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/eval"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/types"
 )
 
+// indexFile is the file that is imported when a directory is imported.
+const indexFile = "index.slo"
+
 var (
 	evalEval   = eval.Eval
 	osReadFile = os.ReadFile
 )
 
-func importImpl(args ...execute.Value) (execute.Value, error) {
+func (s *state) importImpl(args ...execute.Value) (execute.Value, error) {
 	if len(args) != 1 {
 		return nil, errors.CallError("import", len(args), 1)
 	}
@@ -25,26 +29,120 @@ func importImpl(args ...execute.Value) (execute.Value, error) {
 		return nil, errors.NewTypeError(args[0].Type(), types.StrType)
 	}
 	name := argstr.Value()
-	if strings.HasSuffix(name, ".slo") {
-		return importFile(name)
+	if !isPath(name) {
+		if _, ok := s.modules.Get(name); ok {
+			m, err := s.modules.Import(name)
+			if err != nil {
+				return nil, err
+			}
+			return m, nil
+		}
 	}
-	m, ok := modules.Get(name)
+	path, ok := s.resolveImport(name)
 	if !ok {
 		return nil, errors.NewImportError(name)
 	}
-	env, err := m.Import()
+	return s.importFile(path)
+}
+
+// isPath returns whether an import name can only refer to a file or directory.
+func isPath(name string) bool {
+	return strings.HasSuffix(name, ".slo") ||
+		filepath.IsAbs(name) ||
+		strings.HasPrefix(name, "./") ||
+		strings.HasPrefix(name, "../")
+}
+
+// resolveImport returns the canonical path of the file that is imported by the provided name.
+// Absolute paths are used as-is, paths that start with "./" or "../" are resolved relative to the
+// directory containing the importing file, and all other names are resolved relative to that
+// directory and then to each directory in the search path. If the name refers to a directory, the
+// directory's index file is imported.
+func (s *state) resolveImport(name string) (string, bool) {
+	var bases []string
+	if filepath.IsAbs(name) {
+		bases = []string{""}
+	} else {
+		bases = []string{s.runtime.CurrentDir()}
+		if !strings.HasPrefix(name, "./") && !strings.HasPrefix(name, "../") {
+			bases = append(bases, s.runtime.SearchPath...)
+		}
+	}
+	for _, b := range bases {
+		p := filepath.Join(b, name)
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			p = filepath.Join(p, indexFile)
+			if info, err := os.Stat(p); err != nil || info.IsDir() {
+				continue
+			}
+		} else if !strings.HasSuffix(p, ".slo") {
+			continue
+		}
+		if cp, err := canonicalPath(p); err == nil {
+			return cp, true
+		}
+	}
+	return "", false
+}
+
+// canonicalPath returns the absolute path of the provided file with all symlinks resolved.
+func canonicalPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	return types.NewModule(name, env), nil
+	return filepath.EvalSymlinks(abs)
 }
 
-func importFile(path string) (execute.Value, error) {
+// importFile imports the file at the provided canonical path. Each file is only executed the first
+// time it is imported; later imports return the same module.
+func (s *state) importFile(path string) (execute.Value, error) {
+	if m, ok := s.files[path]; ok {
+		return m, nil
+	}
+	for i, f := range s.runtime.Files() {
+		if cf, err := canonicalPath(f); err == nil && cf == path {
+			chain := slices.Clone(s.runtime.Files()[i:])
+			return nil, errors.CyclicImportError(append(chain, path))
+		}
+	}
 	bytes, err := osReadFile(path)
 	if err != nil {
 		return nil, errors.WrapFileError(err, path)
 	}
-	env := RootEnvironment.NewFrame()
-	evalEval(string(bytes), env, false)
-	return types.NewModule(path, env), nil
+	env := s.root.NewFrame()
+	s.runtime.PushFile(path)
+	_, err = evalEval(string(bytes), env)
+	s.runtime.PopFile()
+	if err != nil {
+		return nil, err
+	}
+	exports, ok := env.Exports()
+	if !ok {
+		exports = defaultExports(env)
+	}
+	m := types.NewModuleWithExports(path, env, exports)
+	s.files[path] = m
+	return m, nil
+}
+
+// defaultExports returns the names that a file exports if it doesn't export any explicitly: every
+// global variable except those whose names start with an underscore and those bound to modules that
+// the file imported.
+func defaultExports(env *execute.Environment) []string {
+	var exports []string
+	for _, n := range env.Names() {
+		if strings.HasPrefix(n, "_") {
+			continue
+		}
+		if v, err := env.Get(n); err == nil && v.Type() == types.ModuleType {
+			continue
+		}
+		exports = append(exports, n)
+	}
+	return exports
 }
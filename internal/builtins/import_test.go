@@ -3,19 +3,26 @@ package builtins
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/chrispyles/slow/internal/builtins/modules"
 	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/eval"
 	"github.com/chrispyles/slow/internal/execute"
 	slowtesting "github.com/chrispyles/slow/internal/testing"
+	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
 	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestBuiltins_import(t *testing.T) {
 	tests := []builtinTest{}
-	for _, name := range modules.AllModules {
-		mod, ok := modules.Get(name)
+	reg := modules.NewRegistry(nil)
+	for _, name := range reg.Names() {
+		mod, ok := reg.Get(name)
 		if !ok {
 			t.Fatalf("failed to get builtin module: %s", name)
 		}
@@ -28,25 +35,11 @@ func TestBuiltins_import(t *testing.T) {
 			fn:   "import",
 			args: []execute.Value{types.NewStr(name)},
 			want: types.NewModule(name, modEnv),
+			// Some modules' functions and files are bound to the runtime of the interpreter that imported
+			// them, so only their names and types can be compared.
+			cmpOpts: []cmp.Option{cmpopts.IgnoreFields(types.Func{}, "impl"), cmpopts.IgnoreUnexported(modules.File{}), cmpopts.EquateNaNs()},
 		})
 	}
-	mockOsReadFile := func() []any {
-		calls := make([]any, 2)
-		i := 0
-		osReadFile = func(name string) ([]byte, error) {
-			calls[i] = name
-			i++
-			return []byte("this is foobar.slo"), nil
-		}
-		evalEval = func(c string, _ *execute.Environment, _ bool) {
-			calls[i] = c
-			i++
-		}
-		return calls
-	}
-	cleanupMock := func() {
-		osReadFile = os.ReadFile
-	}
 	tests = append(tests, []builtinTest{
 		{
 			name:    "no_args",
@@ -73,14 +66,196 @@ func TestBuiltins_import(t *testing.T) {
 			wantErr: errors.NewImportError("foobar"),
 		},
 		{
-			name:        "relative_import",
-			fn:          "import",
-			args:        []execute.Value{types.NewStr("foobar.slo")},
-			want:        types.NewModule("foobar.slo", RootEnvironment.NewFrame()),
-			makeMock:    mockOsReadFile,
-			cleanupMock: cleanupMock,
-			wantCalls:   []any{"foobar.slo", "this is foobar.slo"},
+			name:    "nonexistent_file",
+			fn:      "import",
+			args:    []execute.Value{types.NewStr("foobar.slo")},
+			wantErr: errors.NewImportError("foobar.slo"),
 		},
 	}...)
 	doBuiltinTest(t, tests)
 }
+
+func TestBuiltins_importFiles(t *testing.T) {
+	abs := func(p string) string {
+		a, err := filepath.Abs(filepath.Join("testdata", "imports", p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	tests := []struct {
+		name       string
+		script     string
+		searchPath []string
+		code       string
+		want       execute.Value
+		wantErr    error
+	}{
+		{
+			name: "relative_to_working_directory",
+			code: `import("testdata/imports/lib/util.slo").total`,
+			want: types.NewInt(42),
+		},
+		{
+			name:   "relative_to_script",
+			script: "testdata/imports/main.slo",
+			code:   `import("lib/helper.slo").value`,
+			want:   types.NewInt(41),
+		},
+		{
+			name:   "explicit_relative",
+			script: "testdata/imports/lib/util.slo",
+			code:   `import("./helper.slo").value`,
+			want:   types.NewInt(41),
+		},
+		{
+			name: "relative_to_declaring_file",
+			code: `import("testdata/imports/lib/lazy.slo").load()`,
+			want: types.NewInt(41),
+		},
+		{
+			name:   "explicit_relative_not_searched",
+			script: "testdata/imports/main.slo",
+			searchPath: []string{
+				"testdata/imports/search",
+			},
+			code:    `import("./found.slo")`,
+			wantErr: errors.NewImportError("./found.slo"),
+		},
+		{
+			name:       "search_path",
+			searchPath: []string{"testdata/imports/lib", "testdata/imports/search"},
+			code:       `const m = import("testdata/imports/main.slo")` + "\n" + `[m.util.total, m.found.found, m.deep.deep]`,
+			want:       types.NewList([]execute.Value{types.NewInt(42), types.NewBool(true), types.NewBool(true)}),
+		},
+		{
+			name: "directory_package",
+			code: `import("testdata/imports/pkg").name`,
+			want: types.NewStr("pkg"),
+		},
+		{
+			name: "absolute",
+			code: fmt.Sprintf("import(%q).name", abs("pkg")),
+			want: types.NewStr("pkg"),
+		},
+		{
+			name: "cached",
+			code: `import("testdata/imports/pkg") == import("testdata/imports/pkg/index.slo")`,
+			want: types.NewBool(true),
+		},
+		{
+			name: "explicit_exports",
+			code: `dir(import("testdata/imports/exports/explicit.slo"))`,
+			want: types.NewList([]execute.Value{
+				types.NewStr("answer"),
+				types.NewStr("double"),
+				types.NewStr("greet"),
+				types.NewStr("helper"),
+				types.NewStr("version"),
+			}),
+		},
+		{
+			name:    "explicit_exports_hide_others",
+			code:    `import("testdata/imports/exports/explicit.slo").internal`,
+			wantErr: errors.NewAttributeError(types.ModuleType, "internal"),
+		},
+		{
+			name: "default_exports",
+			code: `dir(import("testdata/imports/exports/default.slo"))`,
+			want: types.NewList([]execute.Value{types.NewStr("public"), types.NewStr("visible")}),
+		},
+		{
+			name:    "default_exports_hide_private",
+			code:    `import("testdata/imports/exports/default.slo")._cache`,
+			wantErr: errors.NewAttributeError(types.ModuleType, "_cache"),
+		},
+		{
+			name:    "builtins_not_exported",
+			code:    `import("testdata/imports/exports/default.slo").print`,
+			wantErr: errors.NewAttributeError(types.ModuleType, "print"),
+		},
+		{
+			name: "import_names",
+			code: "import { answer, greet } from \"testdata/imports/exports/explicit.slo\"\ngreet(answer as str)",
+			want: types.NewStr("Hello, 42"),
+		},
+		{
+			name: "exported_function_uses_private_names",
+			code: "import { double } from \"testdata/imports/exports/explicit.slo\"\ndouble(4)",
+			want: types.NewInt(8),
+		},
+		{
+			name: "exported_function_uses_own_names",
+			code: "import { double } from \"testdata/imports/exports/explicit.slo\"\nfunc _double(x) { return 0 }\ndouble(4)",
+			want: types.NewInt(8),
+		},
+		{
+			name:    "import_unexported_name",
+			code:    `import { internal } from "testdata/imports/exports/explicit.slo"`,
+			wantErr: errors.NewAttributeError(types.ModuleType, "internal"),
+		},
+		{
+			name: "import_as",
+			code: "import \"testdata/imports/pkg\" as p\np.name",
+			want: types.NewStr("pkg"),
+		},
+		{
+			name: "import_builtin_as",
+			code: "import \"fs\" as files\ntype(files.read)",
+			want: types.NewStr("func"),
+		},
+		{
+			name: "cycle",
+			code: `import("testdata/imports/cycle/a.slo")`,
+			wantErr: errors.CyclicImportError([]string{
+				abs("cycle/a.slo"),
+				abs("cycle/b.slo"),
+				abs("cycle/c.slo"),
+				abs("cycle/a.slo"),
+			}),
+		},
+		{
+			name:   "cycle_through_script",
+			script: "testdata/imports/cycle/a.slo",
+			code:   `import("b.slo")`,
+			wantErr: errors.CyclicImportError([]string{
+				"testdata/imports/cycle/a.slo",
+				abs("cycle/b.slo"),
+				abs("cycle/c.slo"),
+				abs("cycle/a.slo"),
+			}),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := &execute.Runtime{Script: tc.script, SearchPath: tc.searchPath}
+			env := NewRootEnvironment(rt, modules.NewRegistry(rt)).NewFrame()
+			got, err := eval.Eval(tc.code, env)
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported(), slowcmpopts.IgnoreErrorLocations()); diff != "" {
+				t.Fatalf("Eval() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if tc.want != nil && !tc.want.Equals(got) && tc.want.String() != got.String() {
+				t.Errorf("Eval() = %v, want %v", got, tc.want)
+			}
+			if files := rt.Files(); len(files) > 1 {
+				t.Errorf("Files() = %v after importing, want only the script", files)
+			}
+		})
+	}
+}
+
+func TestBuiltins_importFileOnce(t *testing.T) {
+	var reads []string
+	osReadFile = func(name string) ([]byte, error) {
+		reads = append(reads, name)
+		return os.ReadFile(name)
+	}
+	t.Cleanup(func() { osReadFile = os.ReadFile })
+	env := newTestRootEnvironment(&strings.Builder{}).NewFrame()
+	if _, err := eval.Eval(`import("testdata/imports/lib/util.slo")`+"\n"+`import("testdata/imports/lib/helper.slo")`, env); err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	if got, want := len(reads), 2; got != want {
+		t.Errorf("import read %d files, want %d: %v", got, want, reads)
+	}
+}
@@ -0,0 +1,18 @@
+package builtins
+
+import (
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func (s *state) modulesImpl(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 0 {
+		return nil, errors.CallError("modules", len(args), 0)
+	}
+	var names []execute.Value
+	for _, n := range s.modules.Names() {
+		names = append(names, types.NewStr(n))
+	}
+	return types.NewList(names), nil
+}
@@ -0,0 +1,442 @@
+package modules
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/marshal"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+type csvModule struct{}
+
+// csvOptions are the options that can be passed in a map to the csv functions.
+type csvOptions struct {
+	Delimiter string
+	Comment   string
+	// Header is a bool when reading. When writing, it can also be a list of column names.
+	Header     execute.Value
+	LazyQuotes bool
+	TrimSpace  bool
+	QuoteAll   bool
+	CRLF       bool
+}
+
+var (
+	csvReadOptions  = []string{"comment", "delimiter", "header", "lazyQuotes", "trimSpace"}
+	csvWriteOptions = []string{"crlf", "delimiter", "header", "quoteAll"}
+)
+
+// csvArgs returns the options passed in the optional map args[n] to the csv function name, which
+// accepts the options in allowed, and checks that it was passed n or n+1 arguments.
+func csvArgs(name string, args []execute.Value, n int, allowed []string) (*csvOptions, error) {
+	if len(args) < n || len(args) > n+1 {
+		want := n
+		if len(args) > n+1 {
+			want = n + 1
+		}
+		return nil, errors.CallError(name, len(args), want)
+	}
+	opts := &csvOptions{Delimiter: ","}
+	if len(args) == n {
+		return opts, nil
+	}
+	m, ok := args[n].(*types.Map)
+	if !ok {
+		return nil, errors.NewTypeError(args[n].Type(), types.MapType)
+	}
+	it, _ := m.ToIterator()
+	for it.HasNext() {
+		k, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if ks, ok := k.(*types.Str); !ok || !slices.Contains(allowed, ks.Value()) {
+			return nil, errors.NewValueError(fmt.Sprintf("unknown csv option %s", k))
+		}
+	}
+	if err := marshal.FromValue(m, opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// delimiter returns the delimiter, returning an error if it isn't a single character that can be
+// used to separate fields.
+func (o *csvOptions) delimiter() (rune, error) {
+	r, n := utf8.DecodeRuneInString(o.Delimiter)
+	if n == 0 || n != len(o.Delimiter) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, errors.NewValueError(fmt.Sprintf("invalid csv delimiter %q", o.Delimiter))
+	}
+	return r, nil
+}
+
+// newReader returns a csv.Reader that reads from r with the provided options.
+func (o *csvOptions) newReader(r io.Reader) (*csv.Reader, error) {
+	delim, err := o.delimiter()
+	if err != nil {
+		return nil, err
+	}
+	var comment rune
+	if o.Comment != "" {
+		var n int
+		comment, n = utf8.DecodeRuneInString(o.Comment)
+		if n != len(o.Comment) || comment == delim || comment == '"' || comment == '\r' || comment == '\n' {
+			return nil, errors.NewValueError(fmt.Sprintf("invalid csv comment character %q", o.Comment))
+		}
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = delim
+	cr.Comment = comment
+	cr.LazyQuotes = o.LazyQuotes
+	cr.TrimLeadingSpace = o.TrimSpace
+	// Rows may have different numbers of fields unless they are read as maps, in which case they
+	// must all have the same number of fields as the header.
+	if !o.hasHeader() {
+		cr.FieldsPerRecord = -1
+	}
+	return cr, nil
+}
+
+// hasHeader returns whether the header option is set.
+func (o *csvOptions) hasHeader() bool {
+	return o.Header != nil && o.Header.ToBool()
+}
+
+// csvRowIterator lazily reads the rows of a CSV file as lists of strs, or as maps keyed by the
+// header if there is one.
+type csvRowIterator struct {
+	// file is the file that is being read, if the rows are being read from a file.
+	file   *File
+	reader *csv.Reader
+	header []string
+	next   []string
+	err    error
+	done   bool
+}
+
+// newCSVRowIterator returns an iterator over the rows read by r. If the options have a header, the
+// header is read immediately.
+func newCSVRowIterator(f *File, r *csv.Reader, opts *csvOptions) (*csvRowIterator, error) {
+	it := &csvRowIterator{file: f, reader: r}
+	if opts.hasHeader() {
+		header, err := r.Read()
+		if err == io.EOF {
+			it.done = true
+		} else if err != nil {
+			return nil, it.wrapError(err)
+		}
+		it.header = header
+	}
+	return it, nil
+}
+
+func (it *csvRowIterator) wrapError(err error) error {
+	if it.file != nil {
+		return errors.NewValueError(fmt.Sprintf("invalid CSV in file %q: %v", it.file.path, err))
+	}
+	return errors.NewValueError(fmt.Sprintf("invalid CSV: %v", err))
+}
+
+func (it *csvRowIterator) HasNext() bool {
+	if it.file.iteratorClosed() {
+		return true
+	}
+	if it.next == nil && it.err == nil && !it.done {
+		it.next, it.err = it.reader.Read()
+		if it.err == io.EOF {
+			it.err, it.done = nil, true
+		}
+	}
+	return it.next != nil || it.err != nil
+}
+
+func (it *csvRowIterator) Next() (execute.Value, error) {
+	if it.file != nil {
+		if err := it.file.checkOpen(); err != nil {
+			return nil, err
+		}
+	}
+	if !it.HasNext() {
+		return nil, errors.NewValueError("no rows left in CSV")
+	}
+	row, err := it.next, it.err
+	it.next, it.err = nil, nil
+	if err != nil {
+		// Rows with the wrong number of fields are still returned by the reader, but they are
+		// treated as errors since they can't be matched with the header.
+		it.done = true
+		return nil, it.wrapError(err)
+	}
+	if it.header == nil {
+		vs := make([]execute.Value, len(row))
+		for i, f := range row {
+			vs[i] = types.NewStr(f)
+		}
+		return types.NewList(vs), nil
+	}
+	m := types.NewMap()
+	for i, f := range row {
+		m.Set(types.NewStr(it.header[i]), types.NewStr(f))
+	}
+	return m, nil
+}
+
+func (it *csvRowIterator) WithContainerLen(uint64) *types.Generator {
+	return types.NewGenerator(it)
+}
+
+func csv_parse(args ...execute.Value) (execute.Value, error) {
+	opts, err := csvArgs("csv.parse", args, 1, csvReadOptions)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fileData(args[0])
+	if err != nil {
+		return nil, err
+	}
+	r, err := opts.newReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	it, err := newCSVRowIterator(nil, r, opts)
+	if err != nil {
+		return nil, err
+	}
+	rows := []execute.Value{}
+	for it.HasNext() {
+		row, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return types.NewList(rows), nil
+}
+
+func csv_iter(args ...execute.Value) (execute.Value, error) {
+	opts, err := csvArgs("csv.iter", args, 1, csvReadOptions)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := args[0].(*File)
+	if !ok {
+		return nil, errors.NewTypeError(args[0].Type(), FileType)
+	}
+	if err := f.checkOpen(); err != nil {
+		return nil, err
+	}
+	r, err := opts.newReader(f.reader)
+	if err != nil {
+		return nil, err
+	}
+	it, err := newCSVRowIterator(f, r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewGenerator(it), nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// Writing
+// -------------------------------------------------------------------------------------------------
+
+// csvField returns the text of a value written to a CSV field.
+func csvField(v execute.Value) (string, error) {
+	if f, ok := v.(*types.Float); ok {
+		return f.String(), nil
+	}
+	if v == types.Null {
+		return "", nil
+	}
+	return v.ToStr()
+}
+
+// writeCSV writes the rows in the iterable rows to w. Rows can be lists of fields or maps keyed by
+// column name.
+func writeCSV(w io.Writer, rows execute.Value, opts *csvOptions) error {
+	delim, err := opts.delimiter()
+	if err != nil {
+		return err
+	}
+	it, err := rows.ToIterator()
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	cw := csv.NewWriter(bw)
+	cw.Comma = delim
+	cw.UseCRLF = opts.CRLF
+	writeRecord := func(record []string) error {
+		if !opts.QuoteAll {
+			return cw.Write(record)
+		}
+		for i, f := range record {
+			if i > 0 {
+				bw.WriteRune(delim)
+			}
+			bw.WriteString(`"` + strings.ReplaceAll(f, `"`, `""`) + `"`)
+		}
+		if opts.CRLF {
+			bw.WriteString("\r\n")
+		} else {
+			bw.WriteByte('\n')
+		}
+		return nil
+	}
+
+	var columns []string
+	if l, ok := opts.Header.(*types.List); ok {
+		vs, _ := l.ToIterator()
+		for vs.HasNext() {
+			c, err := vs.Next()
+			if err != nil {
+				return err
+			}
+			s, err := c.ToStr()
+			if err != nil {
+				return err
+			}
+			columns = append(columns, s)
+		}
+		if err := writeRecord(columns); err != nil {
+			return err
+		}
+	}
+	for i := 1; it.HasNext(); i++ {
+		row, err := it.Next()
+		if err != nil {
+			return err
+		}
+		var fields []execute.Value
+		if m, ok := row.(*types.Map); ok {
+			var keys []string
+			ks, _ := m.ToIterator()
+			for ks.HasNext() {
+				k, err := ks.Next()
+				if err != nil {
+					return err
+				}
+				s, err := k.ToStr()
+				if err != nil {
+					return err
+				}
+				keys = append(keys, s)
+			}
+			slices.Sort(keys)
+			if columns == nil {
+				// Without a list of columns, the columns are the sorted keys of the first row.
+				columns = keys
+				if opts.hasHeader() {
+					if err := writeRecord(columns); err != nil {
+						return err
+					}
+				}
+			}
+			for _, k := range keys {
+				if !slices.Contains(columns, k) {
+					return errors.NewValueError(fmt.Sprintf("row %d has a column %q that isn't in the header", i, k))
+				}
+			}
+			for _, c := range columns {
+				f, err := m.Get(types.NewStr(c), types.Null)
+				if err != nil {
+					return err
+				}
+				fields = append(fields, f)
+			}
+		} else {
+			if opts.hasHeader() && columns == nil {
+				return errors.NewValueError("the csv header must be a list of column names when rows are lists")
+			}
+			rit, err := row.ToIterator()
+			if err != nil {
+				return err
+			}
+			for rit.HasNext() {
+				f, err := rit.Next()
+				if err != nil {
+					return err
+				}
+				fields = append(fields, f)
+			}
+		}
+		record := make([]string, len(fields))
+		for j, f := range fields {
+			if record[j], err = csvField(f); err != nil {
+				return err
+			}
+		}
+		if err := writeRecord(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func csv_stringify(args ...execute.Value) (execute.Value, error) {
+	opts, err := csvArgs("csv.stringify", args, 1, csvWriteOptions)
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	if err := writeCSV(&sb, args[0], opts); err != nil {
+		return nil, err
+	}
+	return types.NewStr(sb.String()), nil
+}
+
+func csv_write(args ...execute.Value) (execute.Value, error) {
+	opts, err := csvArgs("csv.write", args, 2, csvWriteOptions)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := args[0].(*File)
+	if !ok {
+		return nil, errors.NewTypeError(args[0].Type(), FileType)
+	}
+	if err := f.checkOpen(); err != nil {
+		return nil, err
+	}
+	if err := f.discardBuffer(); err != nil {
+		return nil, err
+	}
+	if err := writeCSV(f.file, args[1], opts); err != nil {
+		if _, ok := err.(*errors.SlowError); !ok {
+			err = errors.WrapFileError(err, f.path)
+		}
+		return nil, err
+	}
+	return types.Null, nil
+}
+
+var csvFunctions = map[string]types.FuncImpl{
+	"iter":      csv_iter,
+	"parse":     csv_parse,
+	"stringify": csv_stringify,
+	"write":     csv_write,
+}
+
+func (m *csvModule) Name() string {
+	return "csv"
+}
+
+func (m *csvModule) Import() (*execute.Environment, error) {
+	fns := make(map[string]execute.Value)
+	for name, impl := range csvFunctions {
+		fns[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(fns), nil
+}
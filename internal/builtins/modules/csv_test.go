@@ -0,0 +1,376 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+// csvMap returns a map with str keys and values.
+func csvMap(kvs ...string) *types.Map {
+	m := types.NewMap()
+	for i := 0; i < len(kvs); i += 2 {
+		m.Set(types.NewStr(kvs[i]), types.NewStr(kvs[i+1]))
+	}
+	return m
+}
+
+// csvOpts returns a map of csv options.
+func csvOpts(kvs ...any) *types.Map {
+	m := types.NewMap()
+	for i := 0; i < len(kvs); i += 2 {
+		var v execute.Value
+		switch o := kvs[i+1].(type) {
+		case string:
+			v = types.NewStr(o)
+		case bool:
+			v = types.NewBool(o)
+		case execute.Value:
+			v = o
+		}
+		m.Set(types.NewStr(kvs[i].(string)), v)
+	}
+	return m
+}
+
+// checkRow checks that the map got has the keys and values in want.
+func checkRow(t *testing.T, got execute.Value, want map[string]string) {
+	t.Helper()
+	if l, err := got.Length(); err != nil || int(l) != len(want) {
+		t.Fatalf("row %v has %d keys (error: %v), want %d", got, l, err, len(want))
+	}
+	for k, w := range want {
+		v, err := got.GetIndex(types.NewStr(k))
+		if err != nil {
+			t.Fatalf("GetIndex(%q) returned an unexpected error: %v", k, err)
+		}
+		if diff := cmp.Diff(types.NewStr(w), v, slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("GetIndex(%q) returned an unexpected diff (-want +got):\n%s", k, diff)
+		}
+	}
+}
+
+func rowList(rows ...[]string) *types.List {
+	var vs []execute.Value
+	for _, r := range rows {
+		vs = append(vs, types.NewList(strs(r...)))
+	}
+	return types.NewList(vs)
+}
+
+func Test_csv_Name(t *testing.T) {
+	m := &csvModule{}
+	if got, want := m.Name(), "csv"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_csv_parse(t *testing.T) {
+	tests := []testCase{
+		{
+			name: "success",
+			args: strs("a,b\n1,\"x, \"\"y\"\"\nz\"\n2\n"),
+			want: rowList([]string{"a", "b"}, []string{"1", "x, \"y\"\nz"}, []string{"2"}),
+		},
+		{
+			name: "bytes",
+			args: []execute.Value{types.NewBytes([]byte("a,b"))},
+			want: rowList([]string{"a", "b"}),
+		},
+		{
+			name: "empty",
+			args: strs(""),
+			want: types.NewList([]execute.Value{}),
+		},
+		{
+			name: "options",
+			args: []execute.Value{types.NewStr("# comment\na; \"b\"\n"), csvOpts("delimiter", ";", "comment", "#", "trimSpace", true)},
+			want: rowList([]string{"a", "b"}),
+		},
+		{
+			name: "lazy_quotes",
+			args: []execute.Value{types.NewStr(`a "b" c,d`), csvOpts("lazyQuotes", true)},
+			want: rowList([]string{`a "b" c`, "d"}),
+		},
+		{
+			name:    "bare_quote",
+			args:    strs(`a "b" c,d`),
+			wantErr: errors.NewValueError(`invalid CSV: parse error on line 1, column 3: bare " in non-quoted-field`),
+		},
+		{
+			name:    "wrong_number_of_fields",
+			args:    []execute.Value{types.NewStr("a,b\n1\n"), csvOpts("header", true)},
+			wantErr: errors.NewValueError("invalid CSV: record on line 2: wrong number of fields"),
+		},
+		{
+			name:    "invalid_delimiter",
+			args:    []execute.Value{types.NewStr("a"), csvOpts("delimiter", "ab")},
+			wantErr: errors.NewValueError(`invalid csv delimiter "ab"`),
+		},
+		{
+			name:    "invalid_comment",
+			args:    []execute.Value{types.NewStr("a"), csvOpts("comment", ",")},
+			wantErr: errors.NewValueError(`invalid csv comment character ","`),
+		},
+		{
+			name:    "unknown_option",
+			args:    []execute.Value{types.NewStr("a"), csvOpts("quoteAll", true)},
+			wantErr: errors.NewValueError(`unknown csv option "quoteAll"`),
+		},
+		{
+			name:    "non_bool_option",
+			args:    []execute.Value{types.NewStr("a"), csvOpts("trimSpace", "false")},
+			wantErr: errors.NewTypeError(types.StrType, reflect.TypeFor[bool]()),
+		},
+		{
+			name:    "options_not_map",
+			args:    []execute.Value{types.NewStr("a"), types.NewStr(",")},
+			wantErr: errors.NewTypeError(types.StrType, types.MapType),
+		},
+		{
+			name:    "no_args",
+			args:    strs(),
+			wantErr: errors.CallError("csv.parse", 0, 1),
+		},
+		{
+			name:    "too_many_args",
+			args:    []execute.Value{types.NewStr("a"), types.NewMap(), types.NewMap()},
+			wantErr: errors.CallError("csv.parse", 3, 2),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeModuleTestCallback(&csvModule{}, "parse", tc))
+	}
+
+	t.Run("header", func(t *testing.T) {
+		got, err := csv_parse(types.NewStr("a,b\n1,2\n3,4\n"), csvOpts("header", true))
+		if err != nil {
+			t.Fatalf("csv.parse() returned an unexpected error: %v", err)
+		}
+		want := []map[string]string{{"a": "1", "b": "2"}, {"a": "3", "b": "4"}}
+		if l, _ := got.Length(); int(l) != len(want) {
+			t.Fatalf("csv.parse() returned %d rows, want %d", l, len(want))
+		}
+		for i, w := range want {
+			row, _ := got.GetIndex(types.NewInt(int64(i)))
+			checkRow(t, row, w)
+		}
+	})
+}
+
+func Test_csv_iter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("a,b\n1,2\n3,4\n5\n"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("lists", func(t *testing.T) {
+		f := openTestFile(t, path, "r")
+		v, err := csv_iter(f)
+		if err != nil {
+			t.Fatalf("csv.iter() returned an unexpected error: %v", err)
+		}
+		it, _ := v.ToIterator()
+		var got []execute.Value
+		for it.HasNext() {
+			row, err := it.Next()
+			if err != nil {
+				t.Fatalf("Next() returned an unexpected error: %v", err)
+			}
+			got = append(got, row)
+		}
+		want := []execute.Value{
+			types.NewList(strs("a", "b")),
+			types.NewList(strs("1", "2")),
+			types.NewList(strs("3", "4")),
+			types.NewList(strs("5")),
+		}
+		if diff := cmp.Diff(want, got, slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("iterating over the rows returned an unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("header", func(t *testing.T) {
+		f := openTestFile(t, path, "r")
+		v, err := csv_iter(f, csvOpts("header", true))
+		if err != nil {
+			t.Fatalf("csv.iter() returned an unexpected error: %v", err)
+		}
+		it, _ := v.ToIterator()
+		for _, want := range []map[string]string{{"a": "1", "b": "2"}, {"a": "3", "b": "4"}} {
+			got, err := it.Next()
+			if err != nil {
+				t.Fatalf("Next() returned an unexpected error: %v", err)
+			}
+			checkRow(t, got, want)
+		}
+		_, err = it.Next()
+		checkCall(t, "Next()", nil, errors.NewValueError(`invalid CSV in file "`+path+`": record on line 4: wrong number of fields`), nil, err)
+		if it.HasNext() {
+			t.Errorf("HasNext() returned true after an error")
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		f := openTestFile(t, path, "r")
+		v, err := csv_iter(f)
+		if err != nil {
+			t.Fatalf("csv.iter() returned an unexpected error: %v", err)
+		}
+		callMethod(t, f, "close")
+		it, _ := v.ToIterator()
+		_, err = it.Next()
+		checkCall(t, "Next()", nil, errors.NewValueError(`file "`+path+`" is closed`), nil, err)
+	})
+
+	tests := []testCase{
+		{
+			name:    "not_file",
+			args:    strs("a,b"),
+			wantErr: errors.NewTypeError(types.StrType, FileType),
+		},
+		{
+			name:    "no_args",
+			args:    strs(),
+			wantErr: errors.CallError("csv.iter", 0, 1),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeModuleTestCallback(&csvModule{}, "iter", tc))
+	}
+}
+
+func Test_csv_stringify(t *testing.T) {
+	mixed := types.NewList([]execute.Value{
+		types.NewList(strs("a", "b,c", `"d"`)),
+		types.NewList([]execute.Value{types.NewInt(1), types.NewFloat(2), types.Null, types.NewBool(true)}),
+	})
+	mapRows := types.NewList([]execute.Value{
+		csvMap("b", "1", "a", "2"),
+		csvMap("a", "3"),
+	})
+	extraColumn := types.NewList([]execute.Value{
+		csvMap("a", "1"),
+		csvMap("a", "2", "b", "3"),
+	})
+	header := types.NewList(strs("b", "a"))
+
+	tests := []testCase{
+		{
+			name: "lists",
+			args: []execute.Value{mixed},
+			want: types.NewStr("a,\"b,c\",\"\"\"d\"\"\"\n1,2.0,,true\n"),
+		},
+		{
+			name: "maps",
+			args: []execute.Value{mapRows},
+			want: types.NewStr("2,1\n3,\n"),
+		},
+		{
+			name: "maps_with_header",
+			args: []execute.Value{mapRows, csvOpts("header", true)},
+			want: types.NewStr("a,b\n2,1\n3,\n"),
+		},
+		{
+			name: "header_list",
+			args: []execute.Value{mapRows, csvOpts("header", header)},
+			want: types.NewStr("b,a\n1,2\n,3\n"),
+		},
+		{
+			name: "header_list_with_list_rows",
+			args: []execute.Value{rowList([]string{"1", "2"}), csvOpts("header", header)},
+			want: types.NewStr("b,a\n1,2\n"),
+		},
+		{
+			name: "options",
+			args: []execute.Value{rowList([]string{"a", "b"}, []string{"c"}), csvOpts("delimiter", "\t", "quoteAll", true, "crlf", true)},
+			want: types.NewStr("\"a\"\t\"b\"\r\n\"c\"\r\n"),
+		},
+		{
+			name: "generator",
+			args: []execute.Value{types.NewGenerator(&rowGenerator{rows: []execute.Value{types.NewList(strs("a"))}})},
+			want: types.NewStr("a\n"),
+		},
+		{
+			name:    "extra_column",
+			args:    []execute.Value{extraColumn},
+			wantErr: errors.NewValueError(`row 2 has a column "b" that isn't in the header`),
+		},
+		{
+			name:    "header_true_with_list_rows",
+			args:    []execute.Value{rowList([]string{"a"}), csvOpts("header", true)},
+			wantErr: errors.NewValueError("the csv header must be a list of column names when rows are lists"),
+		},
+		{
+			name:    "not_iterable",
+			args:    []execute.Value{types.NewInt(1)},
+			wantErr: errors.NewTypeError(types.IntType, types.IteratorType),
+		},
+		{
+			name:    "unknown_option",
+			args:    []execute.Value{mixed, csvOpts("comment", "#")},
+			wantErr: errors.NewValueError(`unknown csv option "comment"`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeModuleTestCallback(&csvModule{}, "stringify", tc))
+	}
+}
+
+// rowGenerator is a generator over a fixed list of rows.
+type rowGenerator struct {
+	rows []execute.Value
+}
+
+func (it *rowGenerator) HasNext() bool {
+	return len(it.rows) > 0
+}
+
+func (it *rowGenerator) Next() (execute.Value, error) {
+	v := it.rows[0]
+	it.rows = it.rows[1:]
+	return v, nil
+}
+
+func (it *rowGenerator) WithContainerLen(uint64) *types.Generator {
+	return types.NewGenerator(it)
+}
+
+func Test_csv_write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.csv")
+	f := openTestFile(t, path, "w")
+	got, err := csv_write(f, rowList([]string{"a", "b"}), csvOpts("delimiter", ";"))
+	checkCall(t, "csv.write()", types.Null, nil, got, err)
+	got, err = csv_write(f, rowList([]string{"c", "d e"}))
+	checkCall(t, "csv.write()", types.Null, nil, got, err)
+	callMethod(t, f, "close")
+	if b, err := os.ReadFile(path); err != nil || string(b) != "a;b\nc,d e\n" {
+		t.Errorf("file contains %q (error: %v), want %q", b, err, "a;b\nc,d e\n")
+	}
+
+	_, err = csv_write(f, rowList())
+	checkCall(t, "csv.write()", nil, errors.NewValueError(`file "`+path+`" is closed`), nil, err)
+
+	tests := []testCase{
+		{
+			name:    "not_file",
+			args:    []execute.Value{types.NewStr(path), rowList()},
+			wantErr: errors.NewTypeError(types.StrType, FileType),
+		},
+		{
+			name:    "no_rows",
+			args:    []execute.Value{f},
+			wantErr: errors.CallError("csv.write", 1, 2),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeModuleTestCallback(&csvModule{}, "write", tc))
+	}
+}
@@ -0,0 +1,445 @@
+package modules
+
+import (
+	"bufio"
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Type definition
+// -------------------------------------------------------------------------------------------------
+
+type fileType struct{}
+
+func (t *fileType) IsNumeric() bool {
+	return false
+}
+
+func (t *fileType) New(v execute.Value) (execute.Value, error) {
+	panic("fileType.New() is not supported")
+}
+
+func (t *fileType) String() string {
+	return "file"
+}
+
+var FileType = &fileType{}
+
+// -------------------------------------------------------------------------------------------------
+// Type implementation
+// -------------------------------------------------------------------------------------------------
+
+// fileModes maps each mode that can be passed to fs.open to the flags that the file is opened with.
+var fileModes = map[string]int{
+	"r":  os.O_RDONLY,
+	"w":  os.O_WRONLY | os.O_CREATE | os.O_TRUNC,
+	"a":  os.O_WRONLY | os.O_CREATE | os.O_APPEND,
+	"r+": os.O_RDWR,
+	"w+": os.O_RDWR | os.O_CREATE | os.O_TRUNC,
+	"a+": os.O_RDWR | os.O_CREATE | os.O_APPEND,
+}
+
+// fileHandle is the underlying file that a File reads from and writes to.
+type fileHandle interface {
+	io.ReadWriteCloser
+	io.Seeker
+}
+
+// File is an open file. Reads are buffered so that lines can be read lazily; in binary mode, reads
+// return bytes instead of strs.
+type File struct {
+	path   string
+	file   fileHandle
+	reader *bufio.Reader
+	binary bool
+	closed bool
+}
+
+// openFile opens the file at path with a mode like "r", "w+", or "ab".
+func openFile(path, mode string) (*File, error) {
+	m, binary := strings.CutSuffix(mode, "b")
+	flag, ok := fileModes[m]
+	if !ok {
+		return nil, errors.NewValueError(fmt.Sprintf("invalid file mode %q", mode))
+	}
+	f, err := os.OpenFile(path, flag, 0o666)
+	if err != nil {
+		return nil, errors.WrapFileError(err, path)
+	}
+	return &File{path: path, file: f, reader: bufio.NewReader(f), binary: binary}, nil
+}
+
+// Errors returned by operations that a stream doesn't support.
+var (
+	errNotReadable = stderrors.New("stream is not readable")
+	errNotWritable = stderrors.New("stream is not writable")
+	errNotSeekable = stderrors.New("stream is not seekable")
+)
+
+// stream is a fileHandle for one of the interpreter's standard streams, which can be read from if r
+// is non-nil and written to if w is non-nil. Streams can't be seeked, and closing one doesn't close
+// the underlying reader or writer, since it is shared with the rest of the interpreter.
+type stream struct {
+	r io.Reader
+	w io.Writer
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	if s.r == nil {
+		return 0, errNotReadable
+	}
+	return s.r.Read(p)
+}
+
+func (s *stream) Write(p []byte) (int, error) {
+	if s.w == nil {
+		return 0, errNotWritable
+	}
+	return s.w.Write(p)
+}
+
+func (s *stream) Seek(int64, int) (int64, error) {
+	return 0, errNotSeekable
+}
+
+func (s *stream) Close() error {
+	return nil
+}
+
+// newStreamFile returns a text-mode File named name that reads from r and writes to w, either of
+// which may be nil.
+func newStreamFile(name string, r io.Reader, w io.Writer) *File {
+	f := &stream{r: r, w: w}
+	return &File{path: name, file: f, reader: bufio.NewReader(f)}
+}
+
+// checkOpen returns an error if the file has been closed.
+func (v *File) checkOpen() error {
+	if v.closed {
+		return errors.NewValueError(fmt.Sprintf("file %q is closed", v.path))
+	}
+	return nil
+}
+
+// iteratorClosed reports whether the file has been closed while an iterator over it was in use. In
+// that case, the iterator's HasNext method reports that there is another element so that Next can
+// return the error from checkOpen. It is safe to call on a nil file.
+func (v *File) iteratorClosed() bool {
+	return v != nil && v.closed
+}
+
+// discardBuffer moves the file's offset back to the position of the reader and discards the data
+// that the reader has buffered, so that the file can be written to or seeked.
+func (v *File) discardBuffer() error {
+	if n := v.reader.Buffered(); n > 0 {
+		if _, err := v.file.Seek(-int64(n), io.SeekCurrent); err != nil {
+			return errors.WrapFileError(err, v.path)
+		}
+	}
+	v.reader.Reset(v.file)
+	return nil
+}
+
+// value returns a str or bytes containing b, depending on whether the file is in binary mode.
+func (v *File) value(b []byte) execute.Value {
+	if v.binary {
+		return types.NewBytes(b)
+	}
+	return types.NewStr(string(b))
+}
+
+// readLine returns the next line of the file without its line ending, or nil at the end of the file.
+func (v *File) readLine() ([]byte, error) {
+	line, err := v.reader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, errors.WrapFileError(err, v.path)
+	}
+	if err == io.EOF && len(line) == 0 {
+		return nil, nil
+	}
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r")), nil
+}
+
+var fileMethods = map[string]func(*File) execute.Value{
+	"close": func(v *File) execute.Value {
+		name := "file.close"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) != 0 {
+				return nil, errors.CallError(name, len(vs), 0)
+			}
+			// Closing a file more than once is allowed so that files can be closed early even if a
+			// call to close has been deferred.
+			if v.closed {
+				return types.Null, nil
+			}
+			v.closed = true
+			if err := v.file.Close(); err != nil {
+				return nil, errors.WrapFileError(err, v.path)
+			}
+			return types.Null, nil
+		})
+	},
+	"read": func(v *File) execute.Value {
+		name := "file.read"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) > 1 {
+				return nil, errors.CallError(name, len(vs), 1)
+			}
+			if err := v.checkOpen(); err != nil {
+				return nil, err
+			}
+			if len(vs) == 0 {
+				b, err := io.ReadAll(v.reader)
+				if err != nil {
+					return nil, errors.WrapFileError(err, v.path)
+				}
+				return v.value(b), nil
+			}
+			n, err := vs[0].ToInt()
+			if err != nil {
+				return nil, err
+			}
+			if n < 0 {
+				return nil, errors.NewValueError(fmt.Sprintf("cannot read a negative number of bytes: %d", n))
+			}
+			// In text mode, n is a number of characters.
+			var buf bytes.Buffer
+			for i := int64(0); i < n; i++ {
+				var err error
+				if v.binary {
+					var b byte
+					if b, err = v.reader.ReadByte(); err == nil {
+						buf.WriteByte(b)
+					}
+				} else {
+					var r rune
+					if r, _, err = v.reader.ReadRune(); err == nil {
+						buf.WriteRune(r)
+					}
+				}
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return nil, errors.WrapFileError(err, v.path)
+				}
+			}
+			return v.value(buf.Bytes()), nil
+		})
+	},
+	"readline": func(v *File) execute.Value {
+		name := "file.readline"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) != 0 {
+				return nil, errors.CallError(name, len(vs), 0)
+			}
+			if err := v.checkOpen(); err != nil {
+				return nil, err
+			}
+			line, err := v.readLine()
+			if err != nil {
+				return nil, err
+			}
+			if line == nil {
+				return types.Null, nil
+			}
+			return v.value(line), nil
+		})
+	},
+	"seek": func(v *File) execute.Value {
+		name := "file.seek"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got := len(vs); got == 0 || got > 2 {
+				want := 1
+				if got > 2 {
+					want = 2
+				}
+				return nil, errors.CallError(name, got, want)
+			}
+			if err := v.checkOpen(); err != nil {
+				return nil, err
+			}
+			offset, err := vs[0].ToInt()
+			if err != nil {
+				return nil, err
+			}
+			whence := int64(io.SeekStart)
+			if len(vs) == 2 {
+				if whence, err = vs[1].ToInt(); err != nil {
+					return nil, err
+				}
+				if whence < io.SeekStart || whence > io.SeekEnd {
+					return nil, errors.NewValueError(fmt.Sprintf("invalid whence %d", whence))
+				}
+			}
+			if err := v.discardBuffer(); err != nil {
+				return nil, err
+			}
+			pos, err := v.file.Seek(offset, int(whence))
+			if err != nil {
+				return nil, errors.WrapFileError(err, v.path)
+			}
+			return types.NewInt(pos), nil
+		})
+	},
+	"write": func(v *File) execute.Value {
+		name := "file.write"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) != 1 {
+				return nil, errors.CallError(name, len(vs), 1)
+			}
+			if err := v.checkOpen(); err != nil {
+				return nil, err
+			}
+			data, err := fileData(vs[0])
+			if err != nil {
+				return nil, err
+			}
+			if err := v.discardBuffer(); err != nil {
+				return nil, err
+			}
+			n, err := v.file.Write(data)
+			if err != nil {
+				return nil, errors.WrapFileError(err, v.path)
+			}
+			return types.NewInt(int64(n)), nil
+		})
+	},
+}
+
+func (v *File) CloneIfPrimitive() execute.Value {
+	return v
+}
+
+func (v *File) CompareTo(o execute.Value) (int, bool) {
+	return 0, false
+}
+
+func (v *File) Equals(o execute.Value) bool {
+	of, ok := o.(*File)
+	return ok && v == of
+}
+
+func (v *File) GetAttribute(a string) (execute.Value, error) {
+	if methodFactory, ok := fileMethods[a]; ok {
+		return methodFactory(v), nil
+	}
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *File) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *File) HasAttribute(a string) bool {
+	_, ok := fileMethods[a]
+	return ok
+}
+
+func (v *File) HashBytes() ([]byte, error) {
+	return nil, errors.UnhashableTypeError(v.Type())
+}
+
+func (v *File) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *File) SetAttribute(a string, _ execute.Value) error {
+	return errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *File) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+func (v *File) String() string {
+	return fmt.Sprintf("<file %q>", v.path)
+}
+
+func (v *File) ToBool() bool {
+	return true
+}
+
+func (v *File) ToBytes() ([]byte, error) {
+	return nil, errors.NewTypeError(v.Type(), types.BytesType)
+}
+
+func (v *File) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), types.FuncType)
+}
+
+func (v *File) ToFloat() (float64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.FloatType)
+}
+
+func (v *File) ToInt() (int64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.IntType)
+}
+
+// ToIterator returns a generator that lazily reads the remaining lines of the file, without their
+// line endings.
+func (v *File) ToIterator() (execute.Iterator, error) {
+	if err := v.checkOpen(); err != nil {
+		return nil, err
+	}
+	return types.NewGenerator(&lineIterator{v}), nil
+}
+
+func (v *File) ToStr() (string, error) {
+	return v.String(), nil
+}
+
+func (v *File) ToUint() (uint64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.UintType)
+}
+
+func (v *File) Type() execute.Type {
+	return FileType
+}
+
+// -------------------------------------------------------------------------------------------------
+// Line iterator
+// -------------------------------------------------------------------------------------------------
+
+// lineIterator is an iterator over the lines of a File.
+type lineIterator struct {
+	file *File
+}
+
+func (it *lineIterator) HasNext() bool {
+	// If the file can't be read, report that there is another line so that Next can return the
+	// error.
+	if it.file.iteratorClosed() {
+		return true
+	}
+	_, err := it.file.reader.Peek(1)
+	return !stderrors.Is(err, io.EOF)
+}
+
+func (it *lineIterator) Next() (execute.Value, error) {
+	if err := it.file.checkOpen(); err != nil {
+		return nil, err
+	}
+	line, err := it.file.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if line == nil {
+		return nil, errors.NewValueError(fmt.Sprintf("no lines left in file %q", it.file.path))
+	}
+	return it.file.value(line), nil
+}
+
+func (it *lineIterator) WithContainerLen(uint64) *types.Generator {
+	return types.NewGenerator(it)
+}
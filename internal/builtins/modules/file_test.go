@@ -0,0 +1,224 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+// openTestFile opens a file with fs.open, failing the test if it can't be opened.
+func openTestFile(t *testing.T, path, mode string) *File {
+	t.Helper()
+	v, err := fs_open(types.NewStr(path), types.NewStr(mode))
+	if err != nil {
+		t.Fatalf("fs.open() returned an unexpected error: %v", err)
+	}
+	f := v.(*File)
+	t.Cleanup(func() { f.file.Close() })
+	return f
+}
+
+// callMethod calls the method of v with the provided name.
+func callMethod(t *testing.T, v execute.Value, name string, args ...execute.Value) (execute.Value, error) {
+	t.Helper()
+	m, err := v.GetAttribute(name)
+	if err != nil {
+		t.Fatalf("GetAttribute(%q) returned an unexpected error: %v", name, err)
+	}
+	c, err := m.ToCallable()
+	if err != nil {
+		t.Fatalf("ToCallable() returned an unexpected error: %v", err)
+	}
+	return c.Call(nil, args...)
+}
+
+func checkCall(t *testing.T, name string, want execute.Value, wantErr error, got execute.Value, err error) {
+	t.Helper()
+	if diff := cmp.Diff(wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+		t.Errorf("%s returned an unexpected error (-want +got):\n%s", name, diff)
+	}
+	if diff := cmp.Diff(want, got, slowcmpopts.AllowUnexported()); diff != "" {
+		t.Errorf("%s returned an unexpected diff (-want +got):\n%s", name, diff)
+	}
+}
+
+func Test_fs_open(t *testing.T) {
+	tests := []testCase{
+		{
+			name:    "no_args",
+			args:    []execute.Value{},
+			wantErr: errors.CallError("fs.open", 0, 1),
+		},
+		{
+			name:    "too_many_args",
+			args:    []execute.Value{types.NewStr("a"), types.NewStr("r"), types.NewStr("b")},
+			wantErr: errors.CallError("fs.open", 3, 2),
+		},
+		{
+			name:    "invalid_mode",
+			args:    []execute.Value{types.NewStr("testdata/a_file.txt"), types.NewStr("x")},
+			wantErr: errors.NewValueError(`invalid file mode "x"`),
+		},
+		{
+			name:    "file_does_not_exist",
+			args:    []execute.Value{types.NewStr("testdata/another_file.txt")},
+			wantErr: errors.WrapFileError(os.ErrNotExist, "testdata/another_file.txt"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeTestCallback("open", tc))
+	}
+}
+
+func TestFile_lines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lines.txt")
+	if err := os.WriteFile(path, []byte("one\r\ntwo\n\nthree"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		mode string
+		want []execute.Value
+	}{
+		{
+			mode: "r",
+			want: []execute.Value{types.NewStr("one"), types.NewStr("two"), types.NewStr(""), types.NewStr("three")},
+		},
+		{
+			mode: "rb",
+			want: []execute.Value{types.NewBytes([]byte("one")), types.NewBytes([]byte("two")), types.NewBytes([]byte{}), types.NewBytes([]byte("three"))},
+		},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			f := openTestFile(t, path, tc.mode)
+			it, err := f.ToIterator()
+			if err != nil {
+				t.Fatalf("ToIterator() returned an unexpected error: %v", err)
+			}
+			var got []execute.Value
+			for it.HasNext() {
+				v, err := it.Next()
+				if err != nil {
+					t.Fatalf("Next() returned an unexpected error: %v", err)
+				}
+				got = append(got, v)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("iterating over the file returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("readline", func(t *testing.T) {
+		f := openTestFile(t, path, "r")
+		for _, want := range []execute.Value{types.NewStr("one"), types.NewStr("two"), types.NewStr(""), types.NewStr("three"), types.Null} {
+			got, err := callMethod(t, f, "readline")
+			checkCall(t, "readline()", want, nil, got, err)
+		}
+	})
+}
+
+func TestFile_read(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "read.txt")
+	if err := os.WriteFile(path, []byte("héllo\nworld"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	f := openTestFile(t, path, "r")
+	got, err := callMethod(t, f, "read", types.NewInt(2))
+	checkCall(t, "read(2)", types.NewStr("hé"), nil, got, err)
+	got, err = callMethod(t, f, "readline")
+	checkCall(t, "readline()", types.NewStr("llo"), nil, got, err)
+	got, err = callMethod(t, f, "read")
+	checkCall(t, "read()", types.NewStr("world"), nil, got, err)
+	got, err = callMethod(t, f, "read", types.NewInt(1))
+	checkCall(t, "read(1)", types.NewStr(""), nil, got, err)
+	_, err = callMethod(t, f, "read", types.NewInt(-1))
+	checkCall(t, "read(-1)", nil, errors.NewValueError("cannot read a negative number of bytes: -1"), nil, err)
+
+	fb := openTestFile(t, path, "rb")
+	got, err = callMethod(t, fb, "read", types.NewInt(2))
+	checkCall(t, "read(2)", types.NewBytes([]byte("h\xc3")), nil, got, err)
+}
+
+func TestFile_writeAndSeek(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "write.txt")
+
+	f := openTestFile(t, path, "w+")
+	got, err := callMethod(t, f, "write", types.NewStr("hello\n"))
+	checkCall(t, "write()", types.NewInt(6), nil, got, err)
+	got, err = callMethod(t, f, "write", types.NewBytes([]byte("world\n")))
+	checkCall(t, "write()", types.NewInt(6), nil, got, err)
+	_, err = callMethod(t, f, "write", types.NewInt(1))
+	checkCall(t, "write(1)", nil, errors.NewTypeError(types.IntType, types.StrType), nil, err)
+
+	got, err = callMethod(t, f, "seek", types.NewInt(0))
+	checkCall(t, "seek(0)", types.NewInt(0), nil, got, err)
+	got, err = callMethod(t, f, "readline")
+	checkCall(t, "readline()", types.NewStr("hello"), nil, got, err)
+
+	// Writing after reading writes at the position of the reader, not the end of its buffer.
+	got, err = callMethod(t, f, "write", types.NewStr("W"))
+	checkCall(t, "write()", types.NewInt(1), nil, got, err)
+	got, err = callMethod(t, f, "seek", types.NewInt(-2), types.NewInt(2))
+	checkCall(t, "seek(-2, 2)", types.NewInt(10), nil, got, err)
+	got, err = callMethod(t, f, "read")
+	checkCall(t, "read()", types.NewStr("d\n"), nil, got, err)
+	_, err = callMethod(t, f, "seek", types.NewInt(0), types.NewInt(3))
+	checkCall(t, "seek(0, 3)", nil, errors.NewValueError("invalid whence 3"), nil, err)
+	_, err = callMethod(t, f, "seek")
+	checkCall(t, "seek()", nil, errors.CallError("file.seek", 0, 1), nil, err)
+
+	got, err = callMethod(t, f, "close")
+	checkCall(t, "close()", types.Null, nil, got, err)
+	if b, err := os.ReadFile(path); err != nil || string(b) != "hello\nWorld\n" {
+		t.Errorf("file contains %q (error: %v), want %q", b, err, "hello\nWorld\n")
+	}
+
+	a := openTestFile(t, path, "a")
+	callMethod(t, a, "write", types.NewStr("!"))
+	callMethod(t, a, "close")
+	if b, err := os.ReadFile(path); err != nil || string(b) != "hello\nWorld\n!" {
+		t.Errorf("file contains %q (error: %v), want %q", b, err, "hello\nWorld\n!")
+	}
+}
+
+func TestFile_close(t *testing.T) {
+	f := openTestFile(t, "testdata/a_file.txt", "r")
+	for range 2 {
+		got, err := callMethod(t, f, "close")
+		checkCall(t, "close()", types.Null, nil, got, err)
+	}
+	wantErr := errors.NewValueError(`file "testdata/a_file.txt" is closed`)
+	for _, m := range []string{"read", "readline"} {
+		_, err := callMethod(t, f, m)
+		checkCall(t, m+"()", nil, wantErr, nil, err)
+	}
+	_, err := f.ToIterator()
+	checkCall(t, "ToIterator()", nil, wantErr, nil, err)
+}
+
+func TestFile(t *testing.T) {
+	f := openTestFile(t, "testdata/a_file.txt", "r")
+	if got, want := f.String(), `<file "testdata/a_file.txt">`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := f.Type(); got != FileType {
+		t.Errorf("Type() = %v, want %v", got, FileType)
+	}
+	if !f.HasAttribute("readline") || f.HasAttribute("foo") {
+		t.Errorf("HasAttribute() returned incorrect results")
+	}
+	if !f.Equals(f) || f.Equals(openTestFile(t, "testdata/a_file.txt", "r")) {
+		t.Errorf("Equals() returned incorrect results")
+	}
+	if _, err := f.HashBytes(); err == nil {
+		t.Errorf("HashBytes() did not return an error")
+	}
+}
@@ -1,15 +1,85 @@
 package modules
 
 import (
+	stderrors "errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/marshal"
 	"github.com/chrispyles/slow/internal/types"
 )
 
 type fsModule struct{}
 
+// fileInfo is the information about a file that is returned by fs.stat.
+type fileInfo struct {
+	Name    string
+	Size    int64
+	Mode    uint32
+	ModTime time.Time `slow:"mtime"`
+	IsDir   bool
+}
+
+// pathArgs returns the arguments passed to the fs function name as strs, returning an error if
+// there aren't exactly n of them.
+func pathArgs(name string, args []execute.Value, n int) ([]string, error) {
+	if len(args) != n {
+		return nil, errors.CallError(name, len(args), n)
+	}
+	paths := make([]string, n)
+	for i, a := range args {
+		p, err := a.ToStr()
+		if err != nil {
+			return nil, err
+		}
+		paths[i] = p
+	}
+	return paths, nil
+}
+
+// fileData returns the contents of a str or bytes value that is being written to a file.
+func fileData(v execute.Value) ([]byte, error) {
+	switch v.Type() {
+	case types.BytesType:
+		return v.ToBytes()
+	case types.StrType:
+		s, err := v.ToStr()
+		return []byte(s), err
+	}
+	return nil, errors.NewTypeError(v.Type(), types.StrType)
+}
+
+func fs_open(args ...execute.Value) (execute.Value, error) {
+	if len(args) == 0 || len(args) > 2 {
+		want := 1
+		if len(args) > 2 {
+			want = 2
+		}
+		return nil, errors.CallError("fs.open", len(args), want)
+	}
+	path, err := args[0].ToStr()
+	if err != nil {
+		return nil, err
+	}
+	mode := "r"
+	if len(args) == 2 {
+		if mode, err = args[1].ToStr(); err != nil {
+			return nil, err
+		}
+	}
+	f, err := openFile(path, mode)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
 func fs_read(args ...execute.Value) (execute.Value, error) {
 	if len(args) != 1 {
 		return nil, errors.CallError("fs.read", len(args), 1)
@@ -40,9 +110,250 @@ func fs_readBytes(args ...execute.Value) (execute.Value, error) {
 	return types.NewBytes(bytes), nil
 }
 
-var functions = map[string]types.FuncImpl{
+// writeFile writes the data in args[1] to the file at the path in args[0], opening it with the
+// provided flags.
+func writeFile(name string, flag int, args []execute.Value) (execute.Value, error) {
+	if len(args) != 2 {
+		return nil, errors.CallError(name, len(args), 2)
+	}
+	path, err := args[0].ToStr()
+	if err != nil {
+		return nil, err
+	}
+	data, err := fileData(args[1])
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, flag, 0o666)
+	if err != nil {
+		return nil, errors.WrapFileError(err, path)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return nil, errors.WrapFileError(err, path)
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.WrapFileError(err, path)
+	}
+	return types.Null, nil
+}
+
+func fs_write(args ...execute.Value) (execute.Value, error) {
+	return writeFile("fs.write", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, args)
+}
+
+func fs_append(args ...execute.Value) (execute.Value, error) {
+	return writeFile("fs.append", os.O_WRONLY|os.O_CREATE|os.O_APPEND, args)
+}
+
+func fs_exists(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.exists", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(paths[0]); err != nil {
+		if stderrors.Is(err, os.ErrNotExist) {
+			return types.NewBool(false), nil
+		}
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return types.NewBool(true), nil
+}
+
+func fs_stat(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.stat", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(paths[0])
+	if err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return marshal.ToValue(fileInfo{
+		Name:    info.Name(),
+		Size:    info.Size(),
+		Mode:    uint32(info.Mode().Perm()),
+		ModTime: info.ModTime(),
+		IsDir:   info.IsDir(),
+	})
+}
+
+func fs_listdir(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.listdir", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(paths[0])
+	if err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	var names []execute.Value
+	for _, e := range entries {
+		names = append(names, types.NewStr(e.Name()))
+	}
+	return types.NewList(names), nil
+}
+
+func fs_glob(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.glob", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := filepath.Glob(paths[0])
+	if err != nil {
+		return nil, errors.NewValueError(fmt.Sprintf("invalid glob pattern %q", paths[0]))
+	}
+	slices.Sort(matches)
+	var vs []execute.Value
+	for _, m := range matches {
+		vs = append(vs, types.NewStr(m))
+	}
+	return types.NewList(vs), nil
+}
+
+func fs_mkdir(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.mkdir", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(paths[0], 0o777); err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return types.Null, nil
+}
+
+func fs_remove(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.remove", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(paths[0]); err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return types.Null, nil
+}
+
+func fs_removeAll(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.removeAll", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(paths[0]); err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return types.Null, nil
+}
+
+func fs_rename(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.rename", args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(paths[0], paths[1]); err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return types.Null, nil
+}
+
+// copyFile copies the contents and permissions of the file at src to dst.
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.WrapFileError(err, src)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return errors.WrapFileError(err, dst)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return errors.WrapFileError(err, dst)
+	}
+	if err := out.Close(); err != nil {
+		return errors.WrapFileError(err, dst)
+	}
+	return nil
+}
+
+func fs_copy(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("fs.copy", args, 2)
+	if err != nil {
+		return nil, err
+	}
+	src, dst := paths[0], paths[1]
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, errors.WrapFileError(err, src)
+	}
+	if !info.IsDir() {
+		if err := copyFile(src, dst, info.Mode()); err != nil {
+			return nil, err
+		}
+		return types.Null, nil
+	}
+	// Directories are copied recursively; os.CopyFS refuses to overwrite existing files.
+	if err := os.CopyFS(dst, os.DirFS(src)); err != nil {
+		return nil, errors.WrapFileError(err, dst)
+	}
+	return types.Null, nil
+}
+
+// tempArgs returns the prefix passed to a function that creates a temporary file or directory.
+func tempArgs(name string, args []execute.Value) (string, error) {
+	if len(args) > 1 {
+		return "", errors.CallError(name, len(args), 1)
+	}
+	if len(args) == 0 {
+		return "", nil
+	}
+	return args[0].ToStr()
+}
+
+func fs_tempFile(args ...execute.Value) (execute.Value, error) {
+	prefix, err := tempArgs("fs.tempFile", args)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp("", prefix+"*")
+	if err != nil {
+		return nil, errors.WrapFileError(err, os.TempDir())
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.WrapFileError(err, f.Name())
+	}
+	return types.NewStr(f.Name()), nil
+}
+
+func fs_tempDir(args ...execute.Value) (execute.Value, error) {
+	prefix, err := tempArgs("fs.tempDir", args)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", prefix+"*")
+	if err != nil {
+		return nil, errors.WrapFileError(err, os.TempDir())
+	}
+	return types.NewStr(dir), nil
+}
+
+var fsFunctions = map[string]types.FuncImpl{
+	"append":    fs_append,
+	"copy":      fs_copy,
+	"exists":    fs_exists,
+	"glob":      fs_glob,
+	"listdir":   fs_listdir,
+	"mkdir":     fs_mkdir,
+	"open":      fs_open,
 	"read":      fs_read,
 	"readBytes": fs_readBytes,
+	"remove":    fs_remove,
+	"removeAll": fs_removeAll,
+	"rename":    fs_rename,
+	"stat":      fs_stat,
+	"tempDir":   fs_tempDir,
+	"tempFile":  fs_tempFile,
+	"write":     fs_write,
 }
 
 func (m *fsModule) Name() string {
@@ -51,7 +362,7 @@ func (m *fsModule) Name() string {
 
 func (m *fsModule) Import() (*execute.Environment, error) {
 	fns := make(map[string]execute.Value)
-	for name, impl := range functions {
+	for name, impl := range fsFunctions {
 		fns[name] = types.NewGoFunc(name, impl)
 	}
 	return execute.FromMap(fns), nil
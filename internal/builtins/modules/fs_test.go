@@ -2,6 +2,8 @@ package modules
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/chrispyles/slow/internal/errors"
@@ -19,8 +21,12 @@ type testCase struct {
 }
 
 func makeTestCallback(fn string, tc testCase) func(*testing.T) {
+	return makeModuleTestCallback(&fsModule{}, fn, tc)
+}
+
+// makeModuleTestCallback returns a test that calls the function fn of module m.
+func makeModuleTestCallback(m Module, fn string, tc testCase) func(*testing.T) {
 	return func(t *testing.T) {
-		m := &fsModule{}
 		env, err := m.Import()
 		if err != nil {
 			t.Fatalf("m.Import() returned an unexepcted error: %v", err)
@@ -105,3 +111,285 @@ func Test_fs_readBytes(t *testing.T) {
 		t.Run(tc.name, makeTestCallback("readBytes", tc))
 	}
 }
+
+func Test_fs_write(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	tests := []testCase{
+		{
+			name: "str",
+			args: []execute.Value{types.NewStr(path), types.NewStr("foo")},
+			want: types.Null,
+		},
+		{
+			name: "bytes",
+			args: []execute.Value{types.NewStr(path), types.NewBytes([]byte("bar"))},
+			want: types.Null,
+		},
+		{
+			name:    "wrong_type",
+			args:    []execute.Value{types.NewStr(path), types.NewInt(1)},
+			wantErr: errors.NewTypeError(types.IntType, types.StrType),
+		},
+		{
+			name:    "missing_dir",
+			args:    []execute.Value{types.NewStr(filepath.Join(dir, "nope", "out.txt")), types.NewStr("foo")},
+			wantErr: errors.WrapFileError(os.ErrNotExist, filepath.Join(dir, "nope", "out.txt")),
+		},
+		{
+			name:    "too_few_args",
+			args:    []execute.Value{types.NewStr(path)},
+			wantErr: errors.CallError("fs.write", 1, 2),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeTestCallback("write", tc))
+	}
+	// Each write replaces the contents of the file.
+	if got, err := os.ReadFile(path); err != nil || string(got) != "bar" {
+		t.Errorf("file contains %q (error: %v), want %q", got, err, "bar")
+	}
+}
+
+func Test_fs_append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	tests := []testCase{
+		{
+			name: "create",
+			args: []execute.Value{types.NewStr(path), types.NewStr("foo\n")},
+			want: types.Null,
+		},
+		{
+			name: "append",
+			args: []execute.Value{types.NewStr(path), types.NewBytes([]byte("bar\n"))},
+			want: types.Null,
+		},
+		{
+			name:    "too_many_args",
+			args:    []execute.Value{types.NewStr(path), types.NewStr("a"), types.NewStr("b")},
+			wantErr: errors.CallError("fs.append", 3, 2),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeTestCallback("append", tc))
+	}
+	if got, err := os.ReadFile(path); err != nil || string(got) != "foo\nbar\n" {
+		t.Errorf("file contains %q (error: %v), want %q", got, err, "foo\nbar\n")
+	}
+}
+
+func Test_fs_exists(t *testing.T) {
+	tests := []testCase{
+		{
+			name: "file",
+			args: []execute.Value{types.NewStr("testdata/a_file.txt")},
+			want: types.NewBool(true),
+		},
+		{
+			name: "dir",
+			args: []execute.Value{types.NewStr("testdata")},
+			want: types.NewBool(true),
+		},
+		{
+			name: "missing",
+			args: []execute.Value{types.NewStr("testdata/another_file.txt")},
+			want: types.NewBool(false),
+		},
+		{
+			name:    "no_args",
+			args:    []execute.Value{},
+			wantErr: errors.CallError("fs.exists", 0, 1),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeTestCallback("exists", tc))
+	}
+}
+
+func Test_fs_stat(t *testing.T) {
+	path := "testdata/a_file.txt"
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := (&fsModule{}).Import()
+	if err != nil {
+		t.Fatalf("m.Import() returned an unexepcted error: %v", err)
+	}
+	f, err := env.Get("stat")
+	if err != nil {
+		t.Fatalf("failed to get function: %v", err)
+	}
+	fc, err := f.ToCallable()
+	if err != nil {
+		t.Fatalf("failed to convert Value to callable: %v", err)
+	}
+	got, err := fc.Call(env, types.NewStr(path))
+	if err != nil {
+		t.Fatalf("stat() returned an unexpected error: %v", err)
+	}
+	for key, want := range map[string]execute.Value{
+		"name":  types.NewStr("a_file.txt"),
+		"size":  types.NewInt(15),
+		"mode":  types.NewUint(uint64(info.Mode().Perm())),
+		"mtime": types.NewDatetime(info.ModTime()),
+		"isDir": types.NewBool(false),
+	} {
+		v, err := got.GetIndex(types.NewStr(key))
+		if err != nil {
+			t.Errorf("stat() result has no key %q: %v", key, err)
+			continue
+		}
+		if diff := cmp.Diff(want, v, slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("stat() returned incorrect %q (-want +got):\n%s", key, diff)
+		}
+	}
+
+	t.Run("missing", makeTestCallback("stat", testCase{
+		args:    []execute.Value{types.NewStr("testdata/another_file.txt")},
+		wantErr: errors.WrapFileError(os.ErrNotExist, "testdata/another_file.txt"),
+	}))
+}
+
+func Test_fs_directories(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"b.txt", "a.txt", "sub/c.txt"} {
+		p = filepath.Join(dir, p)
+		if err := os.MkdirAll(filepath.Dir(p), 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(p), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	str := func(p string) execute.Value { return types.NewStr(filepath.Join(dir, p)) }
+
+	t.Run("listdir", makeTestCallback("listdir", testCase{
+		args: []execute.Value{types.NewStr(dir)},
+		want: types.NewList([]execute.Value{types.NewStr("a.txt"), types.NewStr("b.txt"), types.NewStr("sub")}),
+	}))
+	t.Run("listdir_missing", makeTestCallback("listdir", testCase{
+		args:    []execute.Value{str("nope")},
+		wantErr: errors.WrapFileError(os.ErrNotExist, filepath.Join(dir, "nope")),
+	}))
+	t.Run("glob", makeTestCallback("glob", testCase{
+		args: []execute.Value{str("*.txt")},
+		want: types.NewList([]execute.Value{str("a.txt"), str("b.txt")}),
+	}))
+	t.Run("glob_no_matches", makeTestCallback("glob", testCase{
+		args: []execute.Value{str("*.csv")},
+		want: types.NewList(nil),
+	}))
+	t.Run("glob_bad_pattern", makeTestCallback("glob", testCase{
+		args:    []execute.Value{types.NewStr("[")},
+		wantErr: errors.NewValueError(`invalid glob pattern "["`),
+	}))
+	t.Run("mkdir", makeTestCallback("mkdir", testCase{
+		args: []execute.Value{str("x/y/z")},
+		want: types.Null,
+	}))
+	t.Run("mkdir_exists", makeTestCallback("mkdir", testCase{
+		args: []execute.Value{str("x/y")},
+		want: types.Null,
+	}))
+	if info, err := os.Stat(filepath.Join(dir, "x/y/z")); err != nil || !info.IsDir() {
+		t.Errorf("mkdir did not create the directory (error: %v)", err)
+	}
+	t.Run("copy_file", makeTestCallback("copy", testCase{
+		args: []execute.Value{str("a.txt"), str("x/a.txt")},
+		want: types.Null,
+	}))
+	t.Run("copy_dir", makeTestCallback("copy", testCase{
+		args: []execute.Value{str("sub"), str("x/sub")},
+		want: types.Null,
+	}))
+	t.Run("copy_missing", makeTestCallback("copy", testCase{
+		args:    []execute.Value{str("nope"), str("x/nope")},
+		wantErr: errors.WrapFileError(os.ErrNotExist, filepath.Join(dir, "nope")),
+	}))
+	for _, p := range []string{"x/a.txt", "x/sub/c.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, p)); err != nil {
+			t.Errorf("copy did not create %s: %v", p, err)
+		}
+	}
+	t.Run("rename", makeTestCallback("rename", testCase{
+		args: []execute.Value{str("b.txt"), str("x/b.txt")},
+		want: types.Null,
+	}))
+	t.Run("rename_missing", makeTestCallback("rename", testCase{
+		args:    []execute.Value{str("b.txt"), str("x/c.txt")},
+		wantErr: errors.WrapFileError(os.ErrNotExist, filepath.Join(dir, "b.txt")),
+	}))
+	t.Run("remove", makeTestCallback("remove", testCase{
+		args: []execute.Value{str("a.txt")},
+		want: types.Null,
+	}))
+	t.Run("remove_missing", makeTestCallback("remove", testCase{
+		args:    []execute.Value{str("a.txt")},
+		wantErr: errors.WrapFileError(os.ErrNotExist, filepath.Join(dir, "a.txt")),
+	}))
+	t.Run("removeAll", makeTestCallback("removeAll", testCase{
+		args: []execute.Value{str("x")},
+		want: types.Null,
+	}))
+	t.Run("removeAll_missing", makeTestCallback("removeAll", testCase{
+		args: []execute.Value{str("x")},
+		want: types.Null,
+	}))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "sub" {
+		t.Errorf("directory contains %v, want only sub", entries)
+	}
+}
+
+func Test_fs_temp(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	for _, tc := range []struct {
+		fn      string
+		args    []execute.Value
+		wantDir bool
+	}{
+		{fn: "tempFile", wantDir: false},
+		{fn: "tempDir", args: []execute.Value{types.NewStr("slow-")}, wantDir: true},
+	} {
+		t.Run(tc.fn, func(t *testing.T) {
+			env, err := (&fsModule{}).Import()
+			if err != nil {
+				t.Fatalf("m.Import() returned an unexepcted error: %v", err)
+			}
+			f, err := env.Get(tc.fn)
+			if err != nil {
+				t.Fatalf("failed to get function: %v", err)
+			}
+			fc, err := f.ToCallable()
+			if err != nil {
+				t.Fatalf("failed to convert Value to callable: %v", err)
+			}
+			got, err := fc.Call(env, tc.args...)
+			if err != nil {
+				t.Fatalf("%s() returned an unexpected error: %v", tc.fn, err)
+			}
+			path, err := got.ToStr()
+			if err != nil {
+				t.Fatalf("%s() returned a non-str value: %v", tc.fn, got)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("%s() returned a path that does not exist: %v", tc.fn, err)
+			}
+			if info.IsDir() != tc.wantDir {
+				t.Errorf("%s() returned a path with IsDir() = %v, want %v", tc.fn, info.IsDir(), tc.wantDir)
+			}
+			if len(tc.args) > 0 && !strings.HasPrefix(filepath.Base(path), "slow-") {
+				t.Errorf("%s() returned %q, which does not start with the prefix", tc.fn, path)
+			}
+		})
+	}
+	t.Run("too_many_args", makeTestCallback("tempFile", testCase{
+		args:    []execute.Value{types.NewStr("a"), types.NewStr("b")},
+		wantErr: errors.CallError("fs.tempFile", 2, 1),
+	}))
+}
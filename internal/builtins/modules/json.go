@@ -0,0 +1,416 @@
+package modules
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+type jsonModule struct{}
+
+// -------------------------------------------------------------------------------------------------
+// Decoding
+// -------------------------------------------------------------------------------------------------
+
+// decodeJSON reads the next value from dec one token at a time, so that the keys of objects are
+// added to maps in the order they appear in. dec must have UseNumber set.
+func decodeJSON(dec *json.Decoder) (execute.Value, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case bool:
+		return types.NewBool(tok), nil
+	case string:
+		return types.NewStr(tok), nil
+	case json.Number:
+		return jsonNumber(tok, dec.InputOffset())
+	case json.Delim:
+		if tok == '[' {
+			vs := []execute.Value{}
+			for dec.More() {
+				v, err := decodeJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+				vs = append(vs, v)
+			}
+			// Read the closing bracket.
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return types.NewList(vs), nil
+		}
+		m := types.NewMap()
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			// The decoder only returns strs for keys, and strs are always hashable, so Set can't fail.
+			m.Set(types.NewStr(k.(string)), v)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return types.Null, nil
+}
+
+// jsonRangeError is returned when a JSON number is too large to be represented by a float.
+type jsonRangeError struct {
+	// Offset is the offset of the number in the input.
+	Offset int64
+	number json.Number
+}
+
+func (e *jsonRangeError) Error() string {
+	return fmt.Sprintf("number %s is out of range for a float", e.number)
+}
+
+// jsonNumber converts a JSON number into an int if it is an integer that fits in one, a bigint if it
+// is a larger integer, and a float otherwise. end is the offset in the input just after the number.
+func jsonNumber(n json.Number, end int64) (execute.Value, error) {
+	if i, err := n.Int64(); err == nil {
+		return types.NewInt(i), nil
+	}
+	if b, ok := new(big.Int).SetString(n.String(), 10); ok {
+		return types.NewBigInt(b), nil
+	}
+	// The decoder has already validated the number, so the only possible error is that it is out of
+	// range.
+	f, err := n.Float64()
+	if err != nil {
+		return nil, &jsonRangeError{Offset: end - int64(len(n)), number: n}
+	}
+	return types.NewFloat(f), nil
+}
+
+// jsonError converts an error returned while decoding the JSON in data into a ValueError that
+// includes the position of the error.
+func jsonError(err error, data []byte) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var rangeErr *jsonRangeError
+	switch {
+	case stderrors.Is(err, io.ErrUnexpectedEOF), stderrors.Is(err, io.EOF):
+		return errors.NewValueError("invalid JSON: unexpected end of input")
+	case stderrors.As(err, &syntaxErr) && syntaxErr.Error() == "unexpected end of JSON input":
+		// Decoder.Token reports input that ends in the middle of a value as a syntax error.
+		return errors.NewValueError("invalid JSON: unexpected end of input")
+	case stderrors.As(err, &syntaxErr):
+		// The offset of a syntax error is the number of bytes read before it was found, which
+		// includes the offending byte.
+		return jsonErrorAt(data, syntaxErr.Offset-1, err.Error())
+	case stderrors.As(err, &typeErr):
+		return jsonErrorAt(data, typeErr.Offset-1, err.Error())
+	case stderrors.As(err, &rangeErr):
+		return jsonErrorAt(data, rangeErr.Offset, err.Error())
+	}
+	return errors.NewValueError(fmt.Sprintf("invalid JSON: %v", err))
+}
+
+// jsonErrorAt returns a ValueError for invalid JSON that gives the line and column of the byte at
+// offset in data.
+func jsonErrorAt(data []byte, offset int64, msg string) error {
+	before := data[:max(0, min(offset, int64(len(data))))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	return errors.NewValueError(fmt.Sprintf("invalid JSON at line %d, column %d: %s", line, col, msg))
+}
+
+func json_parse(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("json.parse", len(args), 1)
+	}
+	data, err := fileData(args[0])
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeJSON(dec)
+	if err != nil {
+		return nil, jsonError(err, data)
+	}
+	end := dec.InputOffset()
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return nil, jsonError(err, data)
+		}
+		offset := end + int64(len(data[end:])-len(bytes.TrimLeft(data[end:], " \t\r\n")))
+		return nil, jsonErrorAt(data, offset, "unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// Encoding
+// -------------------------------------------------------------------------------------------------
+
+// identifierPattern matches map keys that can be written with dot syntax in the paths included in
+// encoding errors.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// jsonEncoder converts Slow values into values that can be encoded by encoding/json, keeping track
+// of where it is in the value so that errors can say which part of it can't be encoded.
+type jsonEncoder struct {
+	// path is the path to the value being converted, e.g. `$.handlers[2]`.
+	path string
+	// seen is the set of lists and maps that contain the value being converted, which is used to
+	// detect cycles.
+	seen map[execute.Value]bool
+}
+
+func (e *jsonEncoder) enter(v execute.Value) error {
+	if e.seen[v] {
+		return errors.NewValueError(fmt.Sprintf("cyclic value at %s cannot be converted to JSON", e.path))
+	}
+	e.seen[v] = true
+	return nil
+}
+
+// withPath calls f with the path extended by elem.
+func (e *jsonEncoder) withPath(elem string, f func() (any, error)) (any, error) {
+	prev := e.path
+	e.path += elem
+	defer func() { e.path = prev }()
+	return f()
+}
+
+func (e *jsonEncoder) encode(v execute.Value) (any, error) {
+	switch v := v.(type) {
+	case *types.Str:
+		return v.Value(), nil
+	case *types.Bool:
+		return v.ToBool(), nil
+	case *types.Int:
+		i, _ := v.ToInt()
+		return json.Number(strconv.FormatInt(i, 10)), nil
+	case *types.Uint:
+		u, _ := v.ToUint()
+		return json.Number(strconv.FormatUint(u, 10)), nil
+	case *types.BigInt:
+		return json.Number(v.Value().String()), nil
+	case *types.Decimal:
+		return json.Number(v.Value().String()), nil
+	case *types.Float:
+		f, _ := v.ToFloat()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, errors.NewValueError(fmt.Sprintf("float %s at %s cannot be converted to JSON", v, e.path))
+		}
+		return json.Number(v.String()), nil
+	case *types.List:
+		if err := e.enter(v); err != nil {
+			return nil, err
+		}
+		defer delete(e.seen, v)
+		vs := []any{}
+		it, _ := v.ToIterator()
+		for i := 0; it.HasNext(); i++ {
+			elem, err := it.Next()
+			if err != nil {
+				return nil, err
+			}
+			ev, err := e.withPath(fmt.Sprintf("[%d]", i), func() (any, error) { return e.encode(elem) })
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, ev)
+		}
+		return vs, nil
+	case *types.Map:
+		if err := e.enter(v); err != nil {
+			return nil, err
+		}
+		defer delete(e.seen, v)
+		m := &jsonObject{}
+		it, _ := v.ToIterator()
+		for it.HasNext() {
+			k, err := it.Next()
+			if err != nil {
+				return nil, err
+			}
+			ks, ok := k.(*types.Str)
+			if !ok {
+				return nil, errors.TypeErrorFromMessage(fmt.Sprintf("map key %s at %s cannot be converted to JSON: keys must be strs", k, e.path))
+			}
+			elem, err := v.GetIndex(k)
+			if err != nil {
+				return nil, err
+			}
+			elemPath := "." + ks.Value()
+			if !identifierPattern.MatchString(ks.Value()) {
+				elemPath = fmt.Sprintf("[%s]", strconv.Quote(ks.Value()))
+			}
+			ev, err := e.withPath(elemPath, func() (any, error) { return e.encode(elem) })
+			if err != nil {
+				return nil, err
+			}
+			m.keys = append(m.keys, ks.Value())
+			m.values = append(m.values, ev)
+		}
+		return m, nil
+	}
+	if v == types.Null {
+		return nil, nil
+	}
+	return nil, errors.TypeErrorFromMessage(fmt.Sprintf("value of type %q at %s cannot be converted to JSON", v.Type(), e.path))
+}
+
+// jsonObject is a JSON object whose members are written in the order of its keys, since
+// encoding/json writes the keys of a map[string]any in sorted order.
+type jsonObject struct {
+	keys   []string
+	values []any
+}
+
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		// Encode writes a newline after each value, which is removed by the encoder that calls
+		// MarshalJSON.
+		if err := enc.Encode(k); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := enc.Encode(o.values[i]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func json_stringify(args ...execute.Value) (execute.Value, error) {
+	if len(args) == 0 || len(args) > 2 {
+		want := 1
+		if len(args) > 2 {
+			want = 2
+		}
+		return nil, errors.CallError("json.stringify", len(args), want)
+	}
+	var indent int64
+	if len(args) == 2 {
+		var err error
+		if indent, err = args[1].ToInt(); err != nil {
+			return nil, err
+		}
+		if indent < 0 {
+			return nil, errors.NewValueError(fmt.Sprintf("indent must be non-negative, got %d", indent))
+		}
+	}
+	e := &jsonEncoder{path: "$", seen: make(map[execute.Value]bool)}
+	v, err := e.encode(args[0])
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if indent > 0 {
+		enc.SetIndent("", strings.Repeat(" ", int(indent)))
+	}
+	if err := enc.Encode(v); err != nil {
+		return nil, errors.NewValueError(fmt.Sprintf("cannot convert value to JSON: %v", err))
+	}
+	return types.NewStr(strings.TrimSuffix(buf.String(), "\n")), nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// Streaming
+// -------------------------------------------------------------------------------------------------
+
+// jsonArrayIterator lazily decodes the elements of a JSON array.
+type jsonArrayIterator struct {
+	file *File
+	dec  *json.Decoder
+	done bool
+}
+
+func (it *jsonArrayIterator) HasNext() bool {
+	return it.file.iteratorClosed() || (!it.done && it.dec.More())
+}
+
+func (it *jsonArrayIterator) Next() (execute.Value, error) {
+	if err := it.file.checkOpen(); err != nil {
+		return nil, err
+	}
+	if it.done || !it.dec.More() {
+		return nil, errors.NewValueError(fmt.Sprintf("no elements left in JSON array in file %q", it.file.path))
+	}
+	v, err := decodeJSON(it.dec)
+	if err != nil {
+		it.done = true
+		return nil, errors.NewValueError(fmt.Sprintf("invalid JSON in file %q: %v", it.file.path, err))
+	}
+	return v, nil
+}
+
+func (it *jsonArrayIterator) WithContainerLen(uint64) *types.Generator {
+	return types.NewGenerator(it)
+}
+
+func json_iter(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("json.iter", len(args), 1)
+	}
+	f, ok := args[0].(*File)
+	if !ok {
+		return nil, errors.NewTypeError(args[0].Type(), FileType)
+	}
+	if err := f.checkOpen(); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(f.reader)
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil && err != io.EOF {
+		return nil, errors.NewValueError(fmt.Sprintf("invalid JSON in file %q: %v", f.path, err))
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return nil, errors.NewValueError(fmt.Sprintf("file %q does not contain a JSON array", f.path))
+	}
+	return types.NewGenerator(&jsonArrayIterator{file: f, dec: dec}), nil
+}
+
+var jsonFunctions = map[string]types.FuncImpl{
+	"iter":      json_iter,
+	"parse":     json_parse,
+	"stringify": json_stringify,
+}
+
+func (m *jsonModule) Name() string {
+	return "json"
+}
+
+func (m *jsonModule) Import() (*execute.Environment, error) {
+	fns := make(map[string]execute.Value)
+	for name, impl := range jsonFunctions {
+		fns[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(fns), nil
+}
@@ -0,0 +1,321 @@
+package modules
+
+import (
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_json_Name(t *testing.T) {
+	m := &jsonModule{}
+	if got, want := m.Name(), "json"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_json_parse(t *testing.T) {
+	big, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []testCase{
+		{
+			name: "list",
+			args: strs(`[1, -2.5, 1e3, 123456789012345678901234567890, "aé", true, false, null, []]`),
+			want: types.NewList([]execute.Value{
+				types.NewInt(1),
+				types.NewFloat(-2.5),
+				types.NewFloat(1000),
+				types.NewBigInt(big),
+				types.NewStr("aé"),
+				types.NewBool(true),
+				types.NewBool(false),
+				types.Null,
+				types.NewList([]execute.Value{}),
+			}),
+		},
+		{
+			name: "bytes",
+			args: []execute.Value{types.NewBytes([]byte(" 1\n"))},
+			want: types.NewInt(1),
+		},
+		{
+			name:    "not_str",
+			args:    []execute.Value{types.NewInt(1)},
+			wantErr: errors.NewTypeError(types.IntType, types.StrType),
+		},
+		{
+			name:    "no_args",
+			args:    strs(),
+			wantErr: errors.CallError("json.parse", 0, 1),
+		},
+		{
+			name:    "syntax_error",
+			args:    strs("{\n  \"a\": tru}"),
+			wantErr: errors.NewValueError(`invalid JSON at line 2, column 11: invalid character '}' in literal true (expecting 'e')`),
+		},
+		{
+			name:    "unexpected_end",
+			args:    strs(`[1, `),
+			wantErr: errors.NewValueError("invalid JSON: unexpected end of input"),
+		},
+		{
+			name:    "empty",
+			args:    strs(""),
+			wantErr: errors.NewValueError("invalid JSON: unexpected end of input"),
+		},
+		{
+			name:    "number_out_of_range",
+			args:    strs("[1,\n -1e400]"),
+			wantErr: errors.NewValueError("invalid JSON at line 2, column 2: number -1e400 is out of range for a float"),
+		},
+		{
+			name:    "trailing_data",
+			args:    strs("[1]\n  [2]"),
+			wantErr: errors.NewValueError("invalid JSON at line 2, column 3: unexpected data after top-level value"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeModuleTestCallback(&jsonModule{}, "parse", tc))
+	}
+
+	t.Run("object", func(t *testing.T) {
+		got, err := json_parse(types.NewStr(`{"a": {"b": [1]}, "c": "d"}`))
+		if err != nil {
+			t.Fatalf("json.parse() returned an unexpected error: %v", err)
+		}
+		c, err := got.GetIndex(types.NewStr("c"))
+		if err != nil {
+			t.Fatalf("GetIndex(%q) returned an unexpected error: %v", "c", err)
+		}
+		if diff := cmp.Diff(types.NewStr("d"), c, slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("c returned an unexpected diff (-want +got):\n%s", diff)
+		}
+		a, err := got.GetIndex(types.NewStr("a"))
+		if err != nil {
+			t.Fatalf("GetIndex(%q) returned an unexpected error: %v", "a", err)
+		}
+		b, err := a.GetIndex(types.NewStr("b"))
+		if err != nil {
+			t.Fatalf("GetIndex(%q) returned an unexpected error: %v", "b", err)
+		}
+		if diff := cmp.Diff(types.NewList([]execute.Value{types.NewInt(1)}), b, slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("a.b returned an unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("key_order", func(t *testing.T) {
+		const data = `{"z":1,"a":{"y":[],"b":null},"m":"x"}`
+		got, err := json_parse(types.NewStr(data))
+		if err != nil {
+			t.Fatalf("json.parse() returned an unexpected error: %v", err)
+		}
+		if got, want := got.String(), `{"z": 1, "a": {"y": [], "b": null}, "m": "x"}`; got != want {
+			t.Errorf("json.parse() = %s, want %s", got, want)
+		}
+		s, err := json_stringify(got)
+		if err != nil {
+			t.Fatalf("json.stringify() returned an unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(types.NewStr(data), s, slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("json.stringify() returned an unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func Test_json_stringify(t *testing.T) {
+	m := types.NewMap()
+	m.Set(types.NewStr("b"), types.NewList([]execute.Value{types.NewInt(1), types.NewFloat(2), types.Null}))
+	m.Set(types.NewStr("a"), types.NewStr("<x>"))
+	m.Set(types.NewStr("c"), types.NewMap())
+
+	nested := types.NewMap()
+	nested.Set(types.NewStr("handlers"), types.NewList([]execute.Value{types.NewInt(1), types.NewGoFunc("f", nil)}))
+	weird := types.NewMap()
+	weird.Set(types.NewStr("my key"), types.NewFloat(math.NaN()))
+	intKeys := types.NewMap()
+	intKeys.Set(types.NewInt(1), types.NewInt(2))
+	cycle := types.NewList([]execute.Value{types.NewInt(1)})
+	cycle.SetIndex(types.NewInt(0), cycle)
+	// A value can appear more than once as long as it doesn't contain itself.
+	shared := types.NewList([]execute.Value{})
+
+	d, err := decimal.Parse("1.50")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []testCase{
+		{
+			name: "compact",
+			args: []execute.Value{m},
+			want: types.NewStr(`{"b":[1,2.0,null],"a":"<x>","c":{}}`),
+		},
+		{
+			name: "indent",
+			args: []execute.Value{m, types.NewInt(2)},
+			want: types.NewStr("{\n  \"b\": [\n    1,\n    2.0,\n    null\n  ],\n  \"a\": \"<x>\",\n  \"c\": {}\n}"),
+		},
+		{
+			name: "numbers",
+			args: []execute.Value{types.NewList([]execute.Value{
+				types.NewUint(math.MaxUint64),
+				types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 70)),
+				types.NewDecimal(d),
+				types.NewFloat(1e21),
+				types.NewBool(true),
+			})},
+			want: types.NewStr(`[18446744073709551615,1180591620717411303424,1.50,1e+21,true]`),
+		},
+		{
+			name: "shared_value",
+			args: []execute.Value{types.NewList([]execute.Value{shared, shared})},
+			want: types.NewStr(`[[],[]]`),
+		},
+		{
+			name:    "unsupported_value",
+			args:    []execute.Value{nested},
+			wantErr: errors.TypeErrorFromMessage(`value of type "func" at $.handlers[1] cannot be converted to JSON`),
+		},
+		{
+			name:    "bytes",
+			args:    []execute.Value{types.NewBytes([]byte("a"))},
+			wantErr: errors.TypeErrorFromMessage(`value of type "bytes" at $ cannot be converted to JSON`),
+		},
+		{
+			name:    "nan",
+			args:    []execute.Value{weird},
+			wantErr: errors.NewValueError(`float NaN at $["my key"] cannot be converted to JSON`),
+		},
+		{
+			name:    "non_str_key",
+			args:    []execute.Value{intKeys},
+			wantErr: errors.TypeErrorFromMessage("map key 1 at $ cannot be converted to JSON: keys must be strs"),
+		},
+		{
+			name:    "cycle",
+			args:    []execute.Value{cycle},
+			wantErr: errors.NewValueError("cyclic value at $[0] cannot be converted to JSON"),
+		},
+		{
+			name:    "negative_indent",
+			args:    []execute.Value{m, types.NewInt(-1)},
+			wantErr: errors.NewValueError("indent must be non-negative, got -1"),
+		},
+		{
+			name:    "no_args",
+			args:    []execute.Value{},
+			wantErr: errors.CallError("json.stringify", 0, 1),
+		},
+		{
+			name:    "too_many_args",
+			args:    []execute.Value{m, types.NewInt(1), types.NewInt(1)},
+			wantErr: errors.CallError("json.stringify", 3, 2),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeModuleTestCallback(&jsonModule{}, "stringify", tc))
+	}
+}
+
+func Test_json_iter(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o666); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("success", func(t *testing.T) {
+		f := openTestFile(t, write("a.json", "[1,\n  \"a\", [2], null]\n"), "r")
+		v, err := json_iter(f)
+		if err != nil {
+			t.Fatalf("json.iter() returned an unexpected error: %v", err)
+		}
+		it, err := v.ToIterator()
+		if err != nil {
+			t.Fatalf("ToIterator() returned an unexpected error: %v", err)
+		}
+		var got []execute.Value
+		for it.HasNext() {
+			v, err := it.Next()
+			if err != nil {
+				t.Fatalf("Next() returned an unexpected error: %v", err)
+			}
+			got = append(got, v)
+		}
+		want := []execute.Value{types.NewInt(1), types.NewStr("a"), types.NewList([]execute.Value{types.NewInt(2)}), types.Null}
+		if diff := cmp.Diff(want, got, slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("iterating over the array returned an unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid_element", func(t *testing.T) {
+		path := write("b.json", "[1, x]")
+		f := openTestFile(t, path, "r")
+		v, err := json_iter(f)
+		if err != nil {
+			t.Fatalf("json.iter() returned an unexpected error: %v", err)
+		}
+		it, _ := v.ToIterator()
+		got, err := it.Next()
+		checkCall(t, "Next()", types.NewInt(1), nil, got, err)
+		_, err = it.Next()
+		checkCall(t, "Next()", nil, errors.NewValueError(`invalid JSON in file "`+path+`": invalid character 'x' looking for beginning of value`), nil, err)
+	})
+
+	t.Run("number_out_of_range", func(t *testing.T) {
+		path := write("e.json", "[1e400]")
+		f := openTestFile(t, path, "r")
+		v, err := json_iter(f)
+		if err != nil {
+			t.Fatalf("json.iter() returned an unexpected error: %v", err)
+		}
+		it, _ := v.ToIterator()
+		_, err = it.Next()
+		checkCall(t, "Next()", nil, errors.NewValueError(`invalid JSON in file "`+path+`": number 1e400 is out of range for a float`), nil, err)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		path := write("c.json", "[1]")
+		f := openTestFile(t, path, "r")
+		v, err := json_iter(f)
+		if err != nil {
+			t.Fatalf("json.iter() returned an unexpected error: %v", err)
+		}
+		callMethod(t, f, "close")
+		it, _ := v.ToIterator()
+		_, err = it.Next()
+		checkCall(t, "Next()", nil, errors.NewValueError(`file "`+path+`" is closed`), nil, err)
+	})
+
+	notArray := write("d.json", `{"a": 1}`)
+	tests := []testCase{
+		{
+			name:    "not_array",
+			args:    []execute.Value{openTestFile(t, notArray, "r")},
+			wantErr: errors.NewValueError(`file "` + notArray + `" does not contain a JSON array`),
+		},
+		{
+			name:    "not_file",
+			args:    strs("[]"),
+			wantErr: errors.NewTypeError(types.StrType, FileType),
+		},
+		{
+			name:    "no_args",
+			args:    strs(),
+			wantErr: errors.CallError("json.iter", 0, 1),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, makeModuleTestCallback(&jsonModule{}, "iter", tc))
+	}
+}
@@ -0,0 +1,463 @@
+package modules
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/operators"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+type mathModule struct {
+	runtime *execute.Runtime
+}
+
+// isNumber returns whether v is a number that can be passed to the math functions.
+func isNumber(v execute.Value) bool {
+	return v.Type().IsNumeric() && v.Type() != types.BytesType
+}
+
+// floatArg returns the value of a number passed to a math function as a float.
+func floatArg(v execute.Value) (float64, error) {
+	if !isNumber(v) {
+		return 0, errors.NewTypeError(v.Type(), types.FloatType)
+	}
+	return v.ToFloat()
+}
+
+// floatFunc returns a math function that takes a single number and returns the float returned by
+// f. If domain is not nil, a ValueError is raised for numbers for which it returns false.
+func floatFunc(name string, f func(float64) float64, domain func(float64) bool) types.FuncImpl {
+	return func(args ...execute.Value) (execute.Value, error) {
+		if len(args) != 1 {
+			return nil, errors.CallError(name, len(args), 1)
+		}
+		x, err := floatArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if domain != nil && !math.IsNaN(x) && !domain(x) {
+			return nil, domainError(name, args[0])
+		}
+		return types.NewFloat(f(x)), nil
+	}
+}
+
+func domainError(name string, v execute.Value) error {
+	return errors.NewValueError(fmt.Sprintf("%s is undefined for %s", name, v))
+}
+
+func nonNegative(x float64) bool { return x >= 0 }
+func positive(x float64) bool    { return x > 0 }
+func unitRange(x float64) bool   { return x >= -1 && x <= 1 }
+
+func math_log(args ...execute.Value) (execute.Value, error) {
+	if len(args) == 0 || len(args) > 2 {
+		want := 1
+		if len(args) > 2 {
+			want = 2
+		}
+		return nil, errors.CallError("math.log", len(args), want)
+	}
+	x, err := floatArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if x <= 0 {
+		return nil, domainError("math.log", args[0])
+	}
+	if len(args) == 1 {
+		return types.NewFloat(math.Log(x)), nil
+	}
+	base, err := floatArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if base <= 0 || base == 1 {
+		return nil, errors.NewValueError(fmt.Sprintf("math.log is undefined for base %s", args[1]))
+	}
+	return types.NewFloat(math.Log(x) / math.Log(base)), nil
+}
+
+func math_atan2(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 2 {
+		return nil, errors.CallError("math.atan2", len(args), 2)
+	}
+	y, err := floatArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	x, err := floatArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return types.NewFloat(math.Atan2(y, x)), nil
+}
+
+// integer returns an int containing n if it fits in one, and a bigint otherwise.
+func integer(n *big.Int) execute.Value {
+	if n.IsInt64() {
+		return types.NewInt(n.Int64())
+	}
+	return types.NewBigInt(n)
+}
+
+// roundFunc returns a math function that rounds a number using the provided mode, either to an
+// integer or, if a second argument is given, to that many digits after the decimal point. Floats are
+// rounded to integers with f.
+func roundFunc(name string, f func(float64) float64, mode decimal.RoundingMode) types.FuncImpl {
+	return func(args ...execute.Value) (execute.Value, error) {
+		if len(args) == 0 || len(args) > 2 {
+			want := 1
+			if len(args) > 2 {
+				want = 2
+			}
+			return nil, errors.CallError(name, len(args), want)
+		}
+		if len(args) == 1 {
+			return roundToInteger(args[0], f, mode)
+		}
+		if !isNumber(args[0]) {
+			return nil, errors.NewTypeError(args[0].Type(), types.FloatType)
+		}
+		if !integerTypes[args[1].Type()] {
+			return nil, errors.NewTypeError(args[1].Type(), types.IntType)
+		}
+		places, err := args[1].ToInt()
+		if err != nil {
+			return nil, err
+		}
+		return roundToPlaces(args[0], places, f, mode)
+	}
+}
+
+// roundToInteger rounds v to an int, or a bigint if the result doesn't fit in an int.
+func roundToInteger(v execute.Value, f func(float64) float64, mode decimal.RoundingMode) (execute.Value, error) {
+	switch v := v.(type) {
+	case *types.Float:
+		x, _ := v.ToFloat()
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, errors.NewValueError(fmt.Sprintf("cannot convert %s to an integer", v))
+		}
+		n, _ := big.NewFloat(f(x)).Int(nil)
+		return integer(n), nil
+	case *types.Decimal:
+		return integer(v.Value().Round(0, mode).Int()), nil
+	}
+	if !isNumber(v) {
+		return nil, errors.NewTypeError(v.Type(), types.FloatType)
+	}
+	n, err := types.ToBigInt(v)
+	if err != nil {
+		return nil, err
+	}
+	return integer(n), nil
+}
+
+// roundToPlaces rounds v to the provided number of digits after the decimal point, which may be
+// negative to round to the left of it. Floats are rounded to the left of the decimal point with f.
+func roundToPlaces(v execute.Value, places int64, f func(float64) float64, mode decimal.RoundingMode) (execute.Value, error) {
+	switch v := v.(type) {
+	case *types.Float:
+		x, _ := v.ToFloat()
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return v, nil
+		}
+		if places < 0 {
+			p := math.Pow(10, float64(-places))
+			return types.NewFloat(f(x/p) * p), nil
+		}
+		if mode == decimal.HalfEven {
+			// Formatting the float rounds its exact value, so that e.g. 2.675 (which is stored as
+			// 2.67499999...) is rounded down.
+			r, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'f', int(min(places, 400)), 64), 64)
+			return types.NewFloat(r), nil
+		}
+		// Other modes use the shortest decimal that represents the float, so that e.g. 0.1 (which is
+		// stored as 0.10000000000000000555...) isn't rounded up to 0.2.
+		d, _ := decimal.FromFloat(x)
+		if int64(d.Scale()) <= places {
+			return v, nil
+		}
+		return types.NewFloat(d.Round(int(places), mode).Float64()), nil
+	case *types.Decimal:
+		return types.NewDecimal(v.Value().Round(int(places), mode)), nil
+	}
+	n, err := types.ToBigInt(v)
+	if err != nil {
+		return nil, err
+	}
+	if places >= 0 {
+		return integer(n), nil
+	}
+	return integer(decimal.FromInt(n).Round(int(places), mode).Int()), nil
+}
+
+// integerTypes are the types that functions that only accept integers, like gcd and lcm, accept.
+var integerTypes = map[execute.Type]bool{
+	types.BigIntType: true,
+	types.BoolType:   true,
+	types.IntType:    true,
+	types.UintType:   true,
+}
+
+// integerArg returns the value of an argument that must be an integer.
+func integerArg(v execute.Value) (*big.Int, error) {
+	if !integerTypes[v.Type()] {
+		return nil, errors.NewTypeError(v.Type(), types.IntType)
+	}
+	return types.ToBigInt(v)
+}
+
+// integerArgs returns the absolute values of the arguments passed to a math function that only
+// accepts integers.
+func integerArgs(args []execute.Value) ([]*big.Int, error) {
+	ns := make([]*big.Int, len(args))
+	for i, a := range args {
+		n, err := integerArg(a)
+		if err != nil {
+			return nil, err
+		}
+		ns[i] = new(big.Int).Abs(n)
+	}
+	return ns, nil
+}
+
+func math_gcd(args ...execute.Value) (execute.Value, error) {
+	ns, err := integerArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	g := new(big.Int)
+	for _, n := range ns {
+		g.GCD(nil, nil, g, n)
+	}
+	return integer(g), nil
+}
+
+func math_lcm(args ...execute.Value) (execute.Value, error) {
+	ns, err := integerArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	l := big.NewInt(1)
+	for _, n := range ns {
+		if n.Sign() == 0 {
+			return types.NewInt(0), nil
+		}
+		g := new(big.Int).GCD(nil, nil, l, n)
+		l.Mul(l, new(big.Int).Quo(n, g))
+	}
+	return integer(l), nil
+}
+
+func math_isclose(args ...execute.Value) (execute.Value, error) {
+	if len(args) < 2 || len(args) > 4 {
+		want := 2
+		if len(args) > 4 {
+			want = 4
+		}
+		return nil, errors.CallError("math.isclose", len(args), want)
+	}
+	// The default tolerances are a relative tolerance of 1e-9 and no absolute tolerance.
+	xs := []float64{0, 0, 1e-9, 0}
+	for i, a := range args {
+		x, err := floatArg(a)
+		if err != nil {
+			return nil, err
+		}
+		xs[i] = x
+	}
+	a, b, relTol, absTol := xs[0], xs[1], xs[2], xs[3]
+	if relTol < 0 || absTol < 0 {
+		return nil, errors.NewValueError("tolerances must be non-negative")
+	}
+	if a == b {
+		return types.NewBool(true), nil
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return types.NewBool(false), nil
+	}
+	diff := math.Abs(a - b)
+	return types.NewBool(diff <= relTol*math.Max(math.Abs(a), math.Abs(b)) || diff <= absTol), nil
+}
+
+func isFloatFunc(name string, f func(float64) bool) types.FuncImpl {
+	return func(args ...execute.Value) (execute.Value, error) {
+		if len(args) != 1 {
+			return nil, errors.CallError(name, len(args), 1)
+		}
+		x, err := floatArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return types.NewBool(f(x)), nil
+	}
+}
+
+// -------------------------------------------------------------------------------------------------
+// Functions that use the interpreter's arithmetic options
+// -------------------------------------------------------------------------------------------------
+
+func (m *mathModule) abs(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("math.abs", len(args), 1)
+	}
+	v := args[0]
+	if !isNumber(v) {
+		return nil, errors.NewTypeError(v.Type(), types.FloatType)
+	}
+	if f, ok := v.(*types.Float); ok {
+		x, _ := f.ToFloat()
+		return types.NewFloat(math.Abs(x)), nil
+	}
+	opts := operators.RuntimeOptions(m.runtime)
+	neg, err := operators.BinOp_LT.ValueWith(v, types.NewInt(0), opts)
+	if err != nil {
+		return nil, err
+	}
+	if !neg.ToBool() {
+		return v.CloneIfPrimitive(), nil
+	}
+	return operators.UnOp_NEG.ValueWith(v, opts)
+}
+
+func (m *mathModule) pow(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 2 {
+		return nil, errors.CallError("math.pow", len(args), 2)
+	}
+	return operators.BinOp_EXP.ValueWith(args[0], args[1], operators.RuntimeOptions(m.runtime))
+}
+
+// values returns the values passed to min, max, or sum, which are either the elements of a single
+// iterable argument or the arguments themselves.
+func values(args []execute.Value) ([]execute.Value, error) {
+	if len(args) != 1 {
+		return args, nil
+	}
+	it, err := args[0].ToIterator()
+	if err != nil {
+		return nil, err
+	}
+	var vs []execute.Value
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
+
+// extremum returns the first of the values passed to min or max for which op holds when it is
+// compared with every other value.
+func (m *mathModule) extremum(name string, op *operators.BinaryOperator, args []execute.Value) (execute.Value, error) {
+	if len(args) == 0 {
+		return nil, errors.CallError(name, 0, 1)
+	}
+	vs, err := values(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) == 0 {
+		return nil, errors.NewValueError(fmt.Sprintf("%s requires at least one value", name))
+	}
+	opts := operators.RuntimeOptions(m.runtime)
+	best := vs[0]
+	for _, v := range vs[1:] {
+		better, err := op.ValueWith(v, best, opts)
+		if err != nil {
+			return nil, err
+		}
+		if better.ToBool() {
+			best = v
+		}
+	}
+	return best, nil
+}
+
+func (m *mathModule) min(args ...execute.Value) (execute.Value, error) {
+	return m.extremum("math.min", operators.BinOp_LT, args)
+}
+
+func (m *mathModule) max(args ...execute.Value) (execute.Value, error) {
+	return m.extremum("math.max", operators.BinOp_GT, args)
+}
+
+func (m *mathModule) sum(args ...execute.Value) (execute.Value, error) {
+	if len(args) == 0 || len(args) > 2 {
+		want := 1
+		if len(args) > 2 {
+			want = 2
+		}
+		return nil, errors.CallError("math.sum", len(args), want)
+	}
+	vs, err := values(args[:1])
+	if err != nil {
+		return nil, err
+	}
+	var total execute.Value = types.NewInt(0)
+	if len(args) == 2 {
+		total = args[1]
+	}
+	opts := operators.RuntimeOptions(m.runtime)
+	for _, v := range vs {
+		if total, err = operators.BinOp_PLUS.ValueWith(total, v, opts); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+var mathFunctions = map[string]types.FuncImpl{
+	"acos":    floatFunc("math.acos", math.Acos, unitRange),
+	"asin":    floatFunc("math.asin", math.Asin, unitRange),
+	"atan":    floatFunc("math.atan", math.Atan, nil),
+	"atan2":   math_atan2,
+	"ceil":    roundFunc("math.ceil", math.Ceil, decimal.Ceiling),
+	"cos":     floatFunc("math.cos", math.Cos, nil),
+	"exp":     floatFunc("math.exp", math.Exp, nil),
+	"floor":   roundFunc("math.floor", math.Floor, decimal.Floor),
+	"gcd":     math_gcd,
+	"isclose": math_isclose,
+	"isinf":   isFloatFunc("math.isinf", func(x float64) bool { return math.IsInf(x, 0) }),
+	"isnan":   isFloatFunc("math.isnan", math.IsNaN),
+	"lcm":     math_lcm,
+	"log":     math_log,
+	"log10":   floatFunc("math.log10", math.Log10, positive),
+	"log2":    floatFunc("math.log2", math.Log2, positive),
+	"round":   roundFunc("math.round", math.RoundToEven, decimal.HalfEven),
+	"sin":     floatFunc("math.sin", math.Sin, nil),
+	"sqrt":    floatFunc("math.sqrt", math.Sqrt, nonNegative),
+	"tan":     floatFunc("math.tan", math.Tan, nil),
+}
+
+func (m *mathModule) Name() string {
+	return "math"
+}
+
+func (m *mathModule) Import() (*execute.Environment, error) {
+	vs := map[string]execute.Value{
+		"e":   types.NewFloat(math.E),
+		"inf": types.NewFloat(math.Inf(1)),
+		"nan": types.NewFloat(math.NaN()),
+		"pi":  types.NewFloat(math.Pi),
+		// These functions are bound to the module because they use the interpreter's arithmetic
+		// options, like the operators they're based on.
+		"abs": types.NewGoFunc("abs", m.abs),
+		"max": types.NewGoFunc("max", m.max),
+		"min": types.NewGoFunc("min", m.min),
+		"pow": types.NewGoFunc("pow", m.pow),
+		"sum": types.NewGoFunc("sum", m.sum),
+	}
+	for name, impl := range mathFunctions {
+		vs[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(vs), nil
+}
@@ -0,0 +1,182 @@
+package modules
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func nums(vs ...any) []execute.Value {
+	out := make([]execute.Value, len(vs))
+	for i, v := range vs {
+		switch v := v.(type) {
+		case int:
+			out[i] = types.NewInt(int64(v))
+		case float64:
+			out[i] = types.NewFloat(v)
+		case execute.Value:
+			out[i] = v
+		}
+	}
+	return out
+}
+
+func Test_math_Name(t *testing.T) {
+	m := &mathModule{}
+	if got, want := m.Name(), "math"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_math(t *testing.T) {
+	big100, _ := new(big.Int).SetString("100000000000000000000", 10)
+	tests := []struct {
+		fn string
+		testCase
+	}{
+		{fn: "sqrt", testCase: testCase{name: "int", args: nums(16), want: types.NewFloat(4)}},
+		{fn: "sqrt", testCase: testCase{name: "negative", args: nums(-1), wantErr: errors.NewValueError("math.sqrt is undefined for -1")}},
+		{fn: "sqrt", testCase: testCase{name: "str", args: strs("4"), wantErr: errors.NewTypeError(types.StrType, types.FloatType)}},
+		{fn: "sqrt", testCase: testCase{name: "bytes", args: []execute.Value{types.NewBytes([]byte{4})}, wantErr: errors.NewTypeError(types.BytesType, types.FloatType)}},
+		{fn: "sqrt", testCase: testCase{name: "no_args", args: nums(), wantErr: errors.CallError("math.sqrt", 0, 1)}},
+		{fn: "exp", testCase: testCase{name: "zero", args: nums(0), want: types.NewFloat(1)}},
+		{fn: "sin", testCase: testCase{name: "zero", args: nums(0), want: types.NewFloat(0)}},
+		{fn: "cos", testCase: testCase{name: "zero", args: nums(types.NewUint(0)), want: types.NewFloat(1)}},
+		{fn: "tan", testCase: testCase{name: "zero", args: nums(0.0), want: types.NewFloat(0)}},
+		{fn: "asin", testCase: testCase{name: "one", args: nums(1), want: types.NewFloat(math.Pi / 2)}},
+		{fn: "acos", testCase: testCase{name: "out_of_range", args: nums(-1.5), wantErr: errors.NewValueError("math.acos is undefined for -1.5")}},
+		{fn: "atan", testCase: testCase{name: "inf", args: nums(math.Inf(1)), want: types.NewFloat(math.Pi / 2)}},
+		{fn: "atan2", testCase: testCase{name: "success", args: nums(1, -1), want: types.NewFloat(3 * math.Pi / 4)}},
+		{fn: "atan2", testCase: testCase{name: "one_arg", args: nums(1), wantErr: errors.CallError("math.atan2", 1, 2)}},
+		{fn: "log", testCase: testCase{name: "natural", args: nums(math.E), want: types.NewFloat(1)}},
+		{fn: "log", testCase: testCase{name: "base", args: nums(100, 10), want: types.NewFloat(2)}},
+		{fn: "log", testCase: testCase{name: "zero", args: nums(0), wantErr: errors.NewValueError("math.log is undefined for 0")}},
+		{fn: "log", testCase: testCase{name: "base_one", args: nums(2, 1), wantErr: errors.NewValueError("math.log is undefined for base 1")}},
+		{fn: "log", testCase: testCase{name: "too_many_args", args: nums(1, 2, 3), wantErr: errors.CallError("math.log", 3, 2)}},
+		{fn: "log2", testCase: testCase{name: "success", args: nums(8), want: types.NewFloat(3)}},
+		{fn: "log10", testCase: testCase{name: "negative", args: nums(-10), wantErr: errors.NewValueError("math.log10 is undefined for -10")}},
+		{fn: "floor", testCase: testCase{name: "float", args: nums(-2.5), want: types.NewInt(-3)}},
+		{fn: "floor", testCase: testCase{name: "int", args: nums(7), want: types.NewInt(7)}},
+		{fn: "floor", testCase: testCase{name: "decimal", args: nums(types.NewDecimal(decimal.MustParse("-1.5"))), want: types.NewInt(-2)}},
+		{fn: "floor", testCase: testCase{name: "large", args: nums(1e20), want: types.NewBigInt(big100)}},
+		{fn: "floor", testCase: testCase{name: "nan", args: nums(math.NaN()), wantErr: errors.NewValueError("cannot convert NaN to an integer")}},
+		{fn: "ceil", testCase: testCase{name: "float", args: nums(-2.5), want: types.NewInt(-2)}},
+		{fn: "ceil", testCase: testCase{name: "decimal", args: nums(types.NewDecimal(decimal.MustParse("1.1"))), want: types.NewInt(2)}},
+		{fn: "floor", testCase: testCase{name: "places", args: nums(2.678, 2), want: types.NewFloat(2.67)}},
+		{fn: "floor", testCase: testCase{name: "negative_float_places", args: nums(-0.25, 1), want: types.NewFloat(-0.3)}},
+		{fn: "floor", testCase: testCase{name: "negative_places", args: nums(1299.0, -2), want: types.NewFloat(1200)}},
+		{fn: "floor", testCase: testCase{name: "int_negative_places", args: nums(-1210, -2), want: types.NewInt(-1300)}},
+		{fn: "floor", testCase: testCase{name: "decimal_places", args: nums(types.NewDecimal(decimal.MustParse("1.29")), 1), want: types.NewDecimal(decimal.MustParse("1.2"))}},
+		{fn: "floor", testCase: testCase{name: "too_many_args", args: nums(1, 2, 3), wantErr: errors.CallError("math.floor", 3, 2)}},
+		{fn: "ceil", testCase: testCase{name: "places", args: nums(2.671, 2), want: types.NewFloat(2.68)}},
+		{fn: "ceil", testCase: testCase{name: "exact_places", args: nums(0.1, 1), want: types.NewFloat(0.1)}},
+		{fn: "ceil", testCase: testCase{name: "negative_places", args: nums(1201.0, -2), want: types.NewFloat(1300)}},
+		{fn: "ceil", testCase: testCase{name: "int_places", args: nums(1234, 2), want: types.NewInt(1234)}},
+		{fn: "ceil", testCase: testCase{name: "decimal_places", args: nums(types.NewDecimal(decimal.MustParse("-1.25")), 1), want: types.NewDecimal(decimal.MustParse("-1.2"))}},
+		{fn: "ceil", testCase: testCase{name: "inf_places", args: nums(math.Inf(1), 1), want: types.NewFloat(math.Inf(1))}},
+		{fn: "ceil", testCase: testCase{name: "float_places", args: nums(1.5, 1.0), wantErr: errors.NewTypeError(types.FloatType, types.IntType)}},
+		{fn: "round", testCase: testCase{name: "half_even_down", args: nums(2.5), want: types.NewInt(2)}},
+		{fn: "round", testCase: testCase{name: "half_even_up", args: nums(3.5), want: types.NewInt(4)}},
+		{fn: "round", testCase: testCase{name: "places", args: nums(2.675, 2), want: types.NewFloat(2.67)}},
+		{fn: "round", testCase: testCase{name: "negative_places", args: nums(1250.0, -2), want: types.NewFloat(1200)}},
+		{fn: "round", testCase: testCase{name: "int_places", args: nums(1234, 2), want: types.NewInt(1234)}},
+		{fn: "round", testCase: testCase{name: "int_negative_places", args: nums(1350, -2), want: types.NewInt(1400)}},
+		{fn: "round", testCase: testCase{name: "decimal_places", args: nums(types.NewDecimal(decimal.MustParse("1.25")), 1), want: types.NewDecimal(decimal.MustParse("1.2"))}},
+		{fn: "round", testCase: testCase{name: "inf_places", args: nums(math.Inf(-1), 1), want: types.NewFloat(math.Inf(-1))}},
+		{fn: "round", testCase: testCase{name: "float_places", args: nums(1.5, 1.0), wantErr: errors.NewTypeError(types.FloatType, types.IntType)}},
+		{fn: "round", testCase: testCase{name: "str", args: []execute.Value{types.NewStr("1"), types.NewInt(1)}, wantErr: errors.NewTypeError(types.StrType, types.FloatType)}},
+		{fn: "gcd", testCase: testCase{name: "success", args: nums(12, -18, types.NewUint(24)), want: types.NewInt(6)}},
+		{fn: "gcd", testCase: testCase{name: "no_args", args: nums(), want: types.NewInt(0)}},
+		{fn: "gcd", testCase: testCase{name: "float", args: nums(1.5), wantErr: errors.NewTypeError(types.FloatType, types.IntType)}},
+		{fn: "lcm", testCase: testCase{name: "success", args: nums(4, 6, -10), want: types.NewInt(60)}},
+		{fn: "lcm", testCase: testCase{name: "zero", args: nums(4, 0), want: types.NewInt(0)}},
+		{fn: "lcm", testCase: testCase{name: "no_args", args: nums(), want: types.NewInt(1)}},
+		{fn: "isclose", testCase: testCase{name: "close", args: nums(0.1+0.2, 0.3), want: types.NewBool(true)}},
+		{fn: "isclose", testCase: testCase{name: "not_close", args: nums(1, 1.1), want: types.NewBool(false)}},
+		{fn: "isclose", testCase: testCase{name: "rel_tol", args: nums(1, 1.1, 0.1), want: types.NewBool(true)}},
+		{fn: "isclose", testCase: testCase{name: "abs_tol", args: nums(0, 1e-10, 0, 1e-9), want: types.NewBool(true)}},
+		{fn: "isclose", testCase: testCase{name: "inf", args: nums(math.Inf(1), math.Inf(1)), want: types.NewBool(true)}},
+		{fn: "isclose", testCase: testCase{name: "negative_tol", args: nums(1, 1, -1), wantErr: errors.NewValueError("tolerances must be non-negative")}},
+		{fn: "isclose", testCase: testCase{name: "one_arg", args: nums(1), wantErr: errors.CallError("math.isclose", 1, 2)}},
+		{fn: "isnan", testCase: testCase{name: "nan", args: nums(math.NaN()), want: types.NewBool(true)}},
+		{fn: "isnan", testCase: testCase{name: "int", args: nums(1), want: types.NewBool(false)}},
+		{fn: "isinf", testCase: testCase{name: "inf", args: nums(math.Inf(-1)), want: types.NewBool(true)}},
+		{fn: "isinf", testCase: testCase{name: "null", args: []execute.Value{types.Null}, wantErr: errors.NewTypeError(types.NullType, types.FloatType)}},
+		{fn: "abs", testCase: testCase{name: "int", args: nums(-3), want: types.NewInt(3)}},
+		{fn: "abs", testCase: testCase{name: "float", args: nums(-0.0), want: types.NewFloat(0)}},
+		{fn: "abs", testCase: testCase{name: "uint", args: nums(types.NewUint(3)), want: types.NewUint(3)}},
+		{fn: "abs", testCase: testCase{name: "bigint", args: nums(types.NewBigInt(big.NewInt(-2))), want: types.NewBigInt(big.NewInt(2))}},
+		{fn: "abs", testCase: testCase{name: "decimal", args: nums(types.NewDecimal(decimal.MustParse("-1.5"))), want: types.NewDecimal(decimal.MustParse("1.5"))}},
+		{fn: "pow", testCase: testCase{name: "int", args: nums(2, 10), want: types.NewInt(1024)}},
+		{fn: "pow", testCase: testCase{name: "float", args: nums(4, 0.5), want: types.NewFloat(2)}},
+		{fn: "min", testCase: testCase{name: "iterable", args: []execute.Value{types.NewList(nums(3, 1.5, 2))}, want: types.NewFloat(1.5)}},
+		{fn: "min", testCase: testCase{name: "args", args: nums(3, 1, 2), want: types.NewInt(1)}},
+		{fn: "min", testCase: testCase{name: "first_of_equal", args: nums(1.0, 1), want: types.NewFloat(1)}},
+		{fn: "min", testCase: testCase{name: "empty", args: []execute.Value{types.NewList(nil)}, wantErr: errors.NewValueError("math.min requires at least one value")}},
+		{fn: "min", testCase: testCase{name: "no_args", args: nums(), wantErr: errors.CallError("math.min", 0, 1)}},
+		{fn: "min", testCase: testCase{name: "not_iterable", args: nums(1), wantErr: errors.NewTypeError(types.IntType, types.IteratorType)}},
+		{fn: "max", testCase: testCase{name: "strs", args: []execute.Value{types.NewList(strs("a", "c", "b"))}, want: types.NewStr("c")}},
+		{fn: "sum", testCase: testCase{name: "ints", args: []execute.Value{types.NewList(nums(1, 2, 3))}, want: types.NewInt(6)}},
+		{fn: "sum", testCase: testCase{name: "mixed", args: []execute.Value{types.NewList(nums(1, 2.5))}, want: types.NewFloat(3.5)}},
+		{fn: "sum", testCase: testCase{name: "empty", args: []execute.Value{types.NewList(nil)}, want: types.NewInt(0)}},
+		{fn: "sum", testCase: testCase{name: "start", args: []execute.Value{types.NewList(strs("b", "c")), types.NewStr("a")}, want: types.NewStr("abc")}},
+		{fn: "sum", testCase: testCase{name: "too_many_args", args: nums(1, 2, 3), wantErr: errors.CallError("math.sum", 3, 2)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.fn+"_"+tc.name, makeModuleTestCallback(&mathModule{}, tc.fn, tc.testCase))
+	}
+}
+
+func Test_math_constants(t *testing.T) {
+	env, err := (&mathModule{}).Import()
+	if err != nil {
+		t.Fatalf("Import() returned an unexpected error: %v", err)
+	}
+	for name, want := range map[string]float64{"pi": math.Pi, "e": math.E, "inf": math.Inf(1)} {
+		v, err := env.Get(name)
+		if err != nil {
+			t.Fatalf("Get(%q) returned an unexpected error: %v", name, err)
+		}
+		if got, _ := v.ToFloat(); got != want {
+			t.Errorf("math.%s = %v, want %v", name, got, want)
+		}
+	}
+	v, err := env.Get("nan")
+	if err != nil {
+		t.Fatalf("Get(%q) returned an unexpected error: %v", "nan", err)
+	}
+	if got, _ := v.ToFloat(); !math.IsNaN(got) {
+		t.Errorf("math.nan = %v, want NaN", got)
+	}
+}
+
+func Test_math_nan(t *testing.T) {
+	// NaN isn't outside the domain of any function; it is passed through instead.
+	for _, fn := range []string{"sqrt", "log", "log2", "asin"} {
+		got, err := mathFunctions[fn](types.NewFloat(math.NaN()))
+		if err != nil {
+			t.Fatalf("math.%s(nan) returned an unexpected error: %v", fn, err)
+		}
+		if f, _ := got.ToFloat(); !math.IsNaN(f) {
+			t.Errorf("math.%s(nan) = %v, want NaN", fn, got)
+		}
+	}
+}
+
+func Test_math_checkedArithmetic(t *testing.T) {
+	m := &mathModule{runtime: &execute.Runtime{CheckedArithmetic: true}}
+	overflow := errors.NewOverflowError(types.IntType, "+")
+	t.Run("sum", makeModuleTestCallback(m, "sum", testCase{
+		args:    []execute.Value{types.NewList(nums(types.NewInt(math.MaxInt64), 1))},
+		wantErr: overflow,
+	}))
+	t.Run("abs", makeModuleTestCallback(m, "abs", testCase{
+		args:    nums(types.NewInt(math.MinInt64)),
+		wantErr: errors.NewOverflowError(types.IntType, "-"),
+	}))
+}
@@ -1,28 +1,108 @@
 package modules
 
-import "github.com/chrispyles/slow/internal/execute"
+import (
+	"fmt"
+	"regexp"
+	"slices"
 
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// Module is a module that can be imported by name. Import is called the first time the module is
+// imported by an interpreter and the environment it returns is cached for later imports, so any
+// expensive initialization should be done in Import.
 type Module interface {
 	Name() string
 	Import() (*execute.Environment, error)
 }
 
-var modules = map[string]Module{
-	"fs": &fsModule{},
+// builtinModules returns new instances of every module that is built into Slow. Modules that
+// depend on the state of the interpreter, like the file that is executing, read it from rt.
+func builtinModules(rt *execute.Runtime) []Module {
+	return []Module{
+		&csvModule{},
+		&fsModule{},
+		&jsonModule{},
+		&mathModule{runtime: rt},
+		&osModule{runtime: rt},
+		&pathModule{runtime: rt},
+		&randomModule{},
+		&reModule{},
+		&sysModule{runtime: rt},
+		&timeModule{},
+	}
 }
 
-var AllModules []string
+// namePattern matches valid module names: one or more identifiers separated by slashes, e.g. "fs"
+// or "acme/db".
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(/[A-Za-z_][A-Za-z0-9_]*)*$`)
 
-func Get(name string) (Module, bool) {
-	m, ok := modules[name]
+// Registry is the set of modules available to a single interpreter. Each module is imported at most
+// once per registry.
+type Registry struct {
+	modules map[string]Module
+	loaded  map[string]*types.Module
+}
+
+// NewRegistry returns a new Registry containing every built-in module, bound to the runtime of the
+// interpreter that the registry belongs to.
+func NewRegistry(rt *execute.Runtime) *Registry {
+	r := &Registry{
+		modules: make(map[string]Module),
+		loaded:  make(map[string]*types.Module),
+	}
+	for _, m := range builtinModules(rt) {
+		r.modules[m.Name()] = m
+	}
+	return r
+}
+
+// Register adds a module to the registry. Module names may be namespaced with slashes (e.g.
+// "acme/db"); an error is returned if the name is invalid or if a module with the same name has
+// already been registered.
+func (r *Registry) Register(m Module) error {
+	name := m.Name()
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid module name %q", name)
+	}
+	if _, ok := r.modules[name]; ok {
+		return fmt.Errorf("module %q is already registered", name)
+	}
+	r.modules[name] = m
+	return nil
+}
+
+func (r *Registry) Get(name string) (Module, bool) {
+	m, ok := r.modules[name]
 	return m, ok
 }
 
-func init() {
-	AllModules = make([]string, len(modules))
-	i := 0
-	for m := range modules {
-		AllModules[i] = m
-		i++
+// Import returns the module with the provided name, importing it if it has not been imported yet.
+func (r *Registry) Import(name string) (*types.Module, error) {
+	if mod, ok := r.loaded[name]; ok {
+		return mod, nil
+	}
+	m, ok := r.modules[name]
+	if !ok {
+		return nil, errors.NewImportError(name)
+	}
+	env, err := m.Import()
+	if err != nil {
+		return nil, err
+	}
+	mod := types.NewModule(name, env)
+	r.loaded[name] = mod
+	return mod, nil
+}
+
+// Names returns the names of every module in the registry in sorted order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.modules))
+	for n := range r.modules {
+		names = append(names, n)
 	}
+	slices.Sort(names)
+	return names
 }
@@ -1,57 +1,193 @@
 package modules
 
 import (
-	"reflect"
 	"testing"
 
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
 	"github.com/google/go-cmp/cmp"
 )
 
-func TestAllModules(t *testing.T) {
-	want := []string{"fs"}
-	if diff := cmp.Diff(want, AllModules); diff != "" {
-		t.Errorf("AllModules has a diff (-want +got):\n%s", diff)
+func TestRegistry_Names(t *testing.T) {
+	want := []string{"csv", "fs", "json", "math", "os", "path", "random", "re", "sys", "time"}
+	if diff := cmp.Diff(want, NewRegistry(nil).Names()); diff != "" {
+		t.Errorf("Names() has a diff (-want +got):\n%s", diff)
 	}
 }
 
-func TestGet(t *testing.T) {
+func TestRegistry_Get(t *testing.T) {
 	tests := []struct {
 		name       string
 		moduleName string
-		want       Module
+		wantName   string
 		wantOk     bool
 	}{
 		{
 			name:       "fs",
 			moduleName: "fs",
-			want:       modules["fs"],
+			wantName:   "fs",
 			wantOk:     true,
 		},
 		{
 			name:       "nonexistent_module",
 			moduleName: "foo",
-			want:       nil,
 			wantOk:     false,
 		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got, ok := Get(tc.moduleName)
+			got, ok := NewRegistry(nil).Get(tc.moduleName)
 			if ok != tc.wantOk {
 				t.Errorf("Get returned incorrect ok value: got %v, want %v", ok, tc.wantOk)
 			}
-			// If either want or got is nil, handle this case separately since reflect.Value.Pointer()
-			// doesn't work on sero values.
-			if gn, wn := got == nil, tc.want == nil; gn || wn {
-				if gn && wn {
-					// they match -- do nothing
-					return
+			if !tc.wantOk {
+				if got != nil {
+					t.Errorf("Get returned non-nil module: %v", got)
 				}
-				t.Errorf("Get returned incorrect value: got == nil -> %v, want == nil -> %v", gn, wn)
+				return
 			}
-			if reflect.ValueOf(got).Pointer() != reflect.ValueOf(tc.want).Pointer() {
-				t.Errorf("Get returned incorrect value")
+			if got.Name() != tc.wantName {
+				t.Errorf("Get returned incorrect module: got %q, want %q", got.Name(), tc.wantName)
 			}
 		})
 	}
 }
+
+type testModule struct {
+	name    string
+	imports int
+	err     error
+}
+
+func (m *testModule) Name() string {
+	return m.name
+}
+
+func (m *testModule) Import() (*execute.Environment, error) {
+	m.imports++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return execute.FromMap(map[string]execute.Value{"x": types.NewInt(1)}), nil
+}
+
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []string
+		wantErr string
+	}{
+		{
+			name:    "simple",
+			modules: []string{"foo"},
+		},
+		{
+			name:    "namespaced",
+			modules: []string{"acme/db", "acme/http_2"},
+		},
+		{
+			name:    "duplicate",
+			modules: []string{"acme/db", "acme/db"},
+			wantErr: `module "acme/db" is already registered`,
+		},
+		{
+			name:    "builtin",
+			modules: []string{"fs"},
+			wantErr: `module "fs" is already registered`,
+		},
+		{
+			name:    "empty_namespace",
+			modules: []string{"acme//db"},
+			wantErr: `invalid module name "acme//db"`,
+		},
+		{
+			name:    "file",
+			modules: []string{"foo.slo"},
+			wantErr: `invalid module name "foo.slo"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRegistry(nil)
+			var err error
+			for _, n := range tc.modules {
+				if err = r.Register(&testModule{name: n}); err != nil {
+					break
+				}
+			}
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Register() returned an unexpected error: %v", err)
+				}
+				for _, n := range tc.modules {
+					if _, ok := r.Get(n); !ok {
+						t.Errorf("Get(%q) did not return the registered module", n)
+					}
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("Register() returned incorrect error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistry_Import(t *testing.T) {
+	r := NewRegistry(nil)
+	m := &testModule{name: "acme/db"}
+	if err := r.Register(m); err != nil {
+		t.Fatalf("Register() returned an unexpected error: %v", err)
+	}
+	if m.imports != 0 {
+		t.Errorf("Register() imported the module")
+	}
+	first, err := r.Import("acme/db")
+	if err != nil {
+		t.Fatalf("Import() returned an unexpected error: %v", err)
+	}
+	second, err := r.Import("acme/db")
+	if err != nil {
+		t.Fatalf("Import() returned an unexpected error: %v", err)
+	}
+	if first != second {
+		t.Errorf("Import() returned different modules for the same name")
+	}
+	if m.imports != 1 {
+		t.Errorf("Import() imported the module %d times, want 1", m.imports)
+	}
+	if diff := cmp.Diff("<module acme/db>", first.String()); diff != "" {
+		t.Errorf("Import() returned incorrect module (-want +got):\n%s", diff)
+	}
+
+	// Other registries should import the module again.
+	r2 := NewRegistry(nil)
+	r2.Register(m)
+	if _, err := r2.Import("acme/db"); err != nil {
+		t.Fatalf("Import() returned an unexpected error: %v", err)
+	}
+	if m.imports != 2 {
+		t.Errorf("Import() imported the module %d times, want 2", m.imports)
+	}
+
+	if _, err := r.Import("acme/nope"); err == nil {
+		t.Errorf("Import() did not return an error for a nonexistent module")
+	}
+}
+
+func TestRegistry_ImportError(t *testing.T) {
+	r := NewRegistry(nil)
+	wantErr := errors.NewValueError("no database")
+	m := &testModule{name: "db", err: wantErr}
+	r.Register(m)
+	for range 2 {
+		if _, err := r.Import("db"); err != wantErr {
+			t.Errorf("Import() returned incorrect error: got %v, want %v", err, wantErr)
+		}
+	}
+	// Failed imports are not cached so that they can be retried.
+	if m.imports != 2 {
+		t.Errorf("Import() imported the module %d times, want 2", m.imports)
+	}
+}
@@ -0,0 +1,132 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// osModule is the os module. The environment and working directory it reads and changes belong to
+// the process, so they are shared by every interpreter in it.
+type osModule struct {
+	runtime *execute.Runtime
+}
+
+func os_getenv(args ...execute.Value) (execute.Value, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return nil, errors.CallError("os.getenv", len(args), min(max(len(args), 1), 2))
+	}
+	name, err := args[0].ToStr()
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := os.LookupEnv(name); ok {
+		return types.NewStr(value), nil
+	}
+	if len(args) == 2 {
+		return args[1], nil
+	}
+	return types.Null, nil
+}
+
+func os_setenv(args ...execute.Value) (execute.Value, error) {
+	strs, err := pathArgs("os.setenv", args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Setenv(strs[0], strs[1]); err != nil {
+		return nil, errors.NewValueError(fmt.Sprintf("cannot set environment variable %q", strs[0]))
+	}
+	return types.Null, nil
+}
+
+func os_unsetenv(args ...execute.Value) (execute.Value, error) {
+	strs, err := pathArgs("os.unsetenv", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Unsetenv(strs[0]); err != nil {
+		return nil, errors.NewValueError(fmt.Sprintf("cannot unset environment variable %q", strs[0]))
+	}
+	return types.Null, nil
+}
+
+func os_environ(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 0 {
+		return nil, errors.CallError("os.environ", len(args), 0)
+	}
+	env := os.Environ()
+	slices.Sort(env)
+	m := types.NewMap()
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		if _, err := m.Set(types.NewStr(name), types.NewStr(value)); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func os_cwd(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 0 {
+		return nil, errors.CallError("os.cwd", len(args), 0)
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, errors.WrapFileError(err, ".")
+	}
+	return types.NewStr(dir), nil
+}
+
+var osFunctions = map[string]types.FuncImpl{
+	"cwd":      os_cwd,
+	"environ":  os_environ,
+	"getenv":   os_getenv,
+	"setenv":   os_setenv,
+	"unsetenv": os_unsetenv,
+}
+
+// chdir changes the working directory. The paths of the main script and the search path are made
+// absolute first so that imports and path.scriptDir still refer to the same directories afterwards.
+func (m *osModule) chdir(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("os.chdir", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	if m.runtime != nil {
+		if m.runtime.Script != "" {
+			if m.runtime.Script, err = filepath.Abs(m.runtime.Script); err != nil {
+				return nil, errors.WrapFileError(err, m.runtime.Script)
+			}
+		}
+		for i, dir := range m.runtime.SearchPath {
+			if m.runtime.SearchPath[i], err = filepath.Abs(dir); err != nil {
+				return nil, errors.WrapFileError(err, dir)
+			}
+		}
+	}
+	if err := os.Chdir(paths[0]); err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return types.Null, nil
+}
+
+func (m *osModule) Name() string {
+	return "os"
+}
+
+func (m *osModule) Import() (*execute.Environment, error) {
+	fns := map[string]execute.Value{
+		"chdir": types.NewGoFunc("chdir", m.chdir),
+	}
+	for name, impl := range osFunctions {
+		fns[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(fns), nil
+}
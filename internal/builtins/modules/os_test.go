@@ -0,0 +1,92 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func Test_os_Name(t *testing.T) {
+	m := &osModule{}
+	if got, want := m.Name(), "os"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_os(t *testing.T) {
+	t.Setenv("SLOW_TEST_SET", "foo")
+	t.Setenv("SLOW_TEST_EMPTY", "")
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		fn string
+		testCase
+	}{
+		{fn: "getenv", testCase: testCase{name: "set", args: strs("SLOW_TEST_SET"), want: types.NewStr("foo")}},
+		{fn: "getenv", testCase: testCase{name: "empty", args: strs("SLOW_TEST_EMPTY", "bar"), want: types.NewStr("")}},
+		{fn: "getenv", testCase: testCase{name: "unset", args: strs("SLOW_TEST_UNSET"), want: types.Null}},
+		{fn: "getenv", testCase: testCase{name: "default", args: []execute.Value{types.NewStr("SLOW_TEST_UNSET"), types.NewInt(1)}, want: types.NewInt(1)}},
+		{fn: "getenv", testCase: testCase{name: "no_args", args: strs(), wantErr: errors.CallError("os.getenv", 0, 1)}},
+		{fn: "getenv", testCase: testCase{name: "too_many_args", args: strs("a", "b", "c"), wantErr: errors.CallError("os.getenv", 3, 2)}},
+		{fn: "setenv", testCase: testCase{name: "invalid_name", args: strs("", "foo"), wantErr: errors.NewValueError(`cannot set environment variable ""`)}},
+		{fn: "setenv", testCase: testCase{name: "no_value", args: strs("SLOW_TEST_SET"), wantErr: errors.CallError("os.setenv", 1, 2)}},
+		{fn: "unsetenv", testCase: testCase{name: "no_args", args: strs(), wantErr: errors.CallError("os.unsetenv", 0, 1)}},
+		{fn: "environ", testCase: testCase{name: "too_many_args", args: strs("a"), wantErr: errors.CallError("os.environ", 1, 0)}},
+		{fn: "cwd", testCase: testCase{name: "success", want: types.NewStr(wd)}},
+		{fn: "cwd", testCase: testCase{name: "too_many_args", args: strs("a"), wantErr: errors.CallError("os.cwd", 1, 0)}},
+		{fn: "chdir", testCase: testCase{name: "nonexistent", args: strs("testdata/nonexistent"), wantErr: errors.NewFileNotFoundError("testdata/nonexistent")}},
+		{fn: "chdir", testCase: testCase{name: "no_args", args: strs(), wantErr: errors.CallError("os.chdir", 0, 1)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.fn+"_"+tc.name, makeModuleTestCallback(&osModule{}, tc.fn, tc.testCase))
+	}
+}
+
+func Test_os_setenv(t *testing.T) {
+	t.Setenv("SLOW_TEST_SET", "foo")
+	m := &osModule{}
+	t.Run("set", makeModuleTestCallback(m, "setenv", testCase{args: strs("SLOW_TEST_SET", "bar"), want: types.Null}))
+	if got, want := os.Getenv("SLOW_TEST_SET"), "bar"; got != want {
+		t.Errorf("os.setenv() set the variable to %q, want %q", got, want)
+	}
+	env, err := os_environ()
+	if err != nil {
+		t.Fatalf("os.environ() returned an unexpected error: %v", err)
+	}
+	got, err := env.(*types.Map).Get(types.NewStr("SLOW_TEST_SET"), types.Null)
+	if err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if want := types.NewStr("bar"); !got.Equals(want) {
+		t.Errorf("os.environ() has SLOW_TEST_SET = %v, want %v", got, want)
+	}
+	t.Run("unset", makeModuleTestCallback(m, "unsetenv", testCase{args: strs("SLOW_TEST_SET"), want: types.Null}))
+	if _, ok := os.LookupEnv("SLOW_TEST_SET"); ok {
+		t.Errorf("os.unsetenv() did not unset the variable")
+	}
+}
+
+func Test_os_chdir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	rt := &execute.Runtime{Script: "testdata/main.slo", SearchPath: []string{"lib", "/usr/lib/slow"}}
+	m := &osModule{runtime: rt}
+	t.Run("success", makeModuleTestCallback(m, "chdir", testCase{args: strs("testdata"), want: types.Null}))
+	if got, want := rt.Script, filepath.Join(wd, "testdata/main.slo"); got != want {
+		t.Errorf("os.chdir() set the script to %q, want %q", got, want)
+	}
+	if got, want := rt.SearchPath, []string{filepath.Join(wd, "lib"), "/usr/lib/slow"}; !slices.Equal(got, want) {
+		t.Errorf("os.chdir() set the search path to %q, want %q", got, want)
+	}
+	t.Run("cwd", makeModuleTestCallback(m, "cwd", testCase{want: types.NewStr(filepath.Join(wd, "testdata"))}))
+}
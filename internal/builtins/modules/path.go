@@ -0,0 +1,124 @@
+package modules
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+type pathModule struct {
+	runtime *execute.Runtime
+}
+
+// pathFunc returns a path function that takes a single path and returns the str returned by f.
+func pathFunc(name string, f func(string) string) types.FuncImpl {
+	return func(args ...execute.Value) (execute.Value, error) {
+		paths, err := pathArgs(name, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewStr(f(paths[0])), nil
+	}
+}
+
+func path_join(args ...execute.Value) (execute.Value, error) {
+	elems := make([]string, len(args))
+	for i, a := range args {
+		s, err := a.ToStr()
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = s
+	}
+	return types.NewStr(filepath.Join(elems...)), nil
+}
+
+func path_abs(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("path.abs", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(paths[0])
+	if err != nil {
+		return nil, errors.WrapFileError(err, paths[0])
+	}
+	return types.NewStr(abs), nil
+}
+
+func path_rel(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("path.rel", args, 2)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(paths[0], paths[1])
+	if err != nil {
+		return nil, errors.NewValueError(fmt.Sprintf("cannot make %q relative to %q", paths[1], paths[0]))
+	}
+	return types.NewStr(rel), nil
+}
+
+func path_split(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("path.split", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	dir, file := filepath.Split(paths[0])
+	return types.NewList([]execute.Value{types.NewStr(dir), types.NewStr(file)}), nil
+}
+
+func path_match(args ...execute.Value) (execute.Value, error) {
+	paths, err := pathArgs("path.match", args, 2)
+	if err != nil {
+		return nil, err
+	}
+	matched, err := filepath.Match(paths[0], paths[1])
+	if err != nil {
+		return nil, errors.NewValueError(fmt.Sprintf("invalid glob pattern %q", paths[0]))
+	}
+	return types.NewBool(matched), nil
+}
+
+var pathFunctions = map[string]types.FuncImpl{
+	"abs":      path_abs,
+	"basename": pathFunc("path.basename", filepath.Base),
+	"clean":    pathFunc("path.clean", filepath.Clean),
+	"dirname":  pathFunc("path.dirname", filepath.Dir),
+	"ext":      pathFunc("path.ext", filepath.Ext),
+	"join":     path_join,
+	"match":    path_match,
+	"rel":      path_rel,
+	"split":    path_split,
+}
+
+// scriptDir returns the absolute path of the directory containing the file that is executing, or of
+// the working directory if no file is executing (e.g. in the REPL).
+func (m *pathModule) scriptDir(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 0 {
+		return nil, errors.CallError("path.scriptDir", len(args), 0)
+	}
+	dir := m.runtime.CurrentDir()
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.WrapFileError(err, dir)
+	}
+	return types.NewStr(abs), nil
+}
+
+func (m *pathModule) Name() string {
+	return "path"
+}
+
+func (m *pathModule) Import() (*execute.Environment, error) {
+	fns := map[string]execute.Value{
+		// The directory is looked up each time scriptDir is called because the same module is shared
+		// by every file that imports it.
+		"scriptDir": types.NewGoFunc("scriptDir", m.scriptDir),
+	}
+	for name, impl := range pathFunctions {
+		fns[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(fns), nil
+}
@@ -0,0 +1,83 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func strs(ss ...string) []execute.Value {
+	vs := make([]execute.Value, len(ss))
+	for i, s := range ss {
+		vs[i] = types.NewStr(s)
+	}
+	return vs
+}
+
+func Test_path_Name(t *testing.T) {
+	m := &pathModule{}
+	if got, want := m.Name(), "path"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_path(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		fn string
+		testCase
+	}{
+		{fn: "join", testCase: testCase{name: "no_args", args: strs(), want: types.NewStr("")}},
+		{fn: "join", testCase: testCase{name: "success", args: strs("a", "b/", "../c", "d.slo"), want: types.NewStr("a/c/d.slo")}},
+		{fn: "dirname", testCase: testCase{name: "success", args: strs("a/b/c.slo"), want: types.NewStr("a/b")}},
+		{fn: "dirname", testCase: testCase{name: "no_dir", args: strs("c.slo"), want: types.NewStr(".")}},
+		{fn: "dirname", testCase: testCase{name: "no_args", args: strs(), wantErr: errors.CallError("path.dirname", 0, 1)}},
+		{fn: "basename", testCase: testCase{name: "success", args: strs("a/b/c.slo"), want: types.NewStr("c.slo")}},
+		{fn: "basename", testCase: testCase{name: "trailing_slash", args: strs("a/b/"), want: types.NewStr("b")}},
+		{fn: "ext", testCase: testCase{name: "success", args: strs("a/b.tar.gz"), want: types.NewStr(".gz")}},
+		{fn: "ext", testCase: testCase{name: "no_ext", args: strs("a/b"), want: types.NewStr("")}},
+		{fn: "clean", testCase: testCase{name: "success", args: strs("a//b/./../c/"), want: types.NewStr("a/c")}},
+		{fn: "clean", testCase: testCase{name: "too_many_args", args: strs("a", "b"), wantErr: errors.CallError("path.clean", 2, 1)}},
+		{fn: "abs", testCase: testCase{name: "relative", args: strs("a/b"), want: types.NewStr(filepath.Join(wd, "a/b"))}},
+		{fn: "abs", testCase: testCase{name: "absolute", args: strs("/a/../b"), want: types.NewStr("/b")}},
+		{fn: "rel", testCase: testCase{name: "success", args: strs("/a/b", "/a/c/d"), want: types.NewStr("../c/d")}},
+		{fn: "rel", testCase: testCase{name: "error", args: strs("/a", "b"), wantErr: errors.NewValueError(`cannot make "b" relative to "/a"`)}},
+		{fn: "rel", testCase: testCase{name: "no_args", args: strs("/a"), wantErr: errors.CallError("path.rel", 1, 2)}},
+		{fn: "split", testCase: testCase{name: "success", args: strs("a/b/c.slo"), want: types.NewList(strs("a/b/", "c.slo"))}},
+		{fn: "split", testCase: testCase{name: "no_dir", args: strs("c.slo"), want: types.NewList(strs("", "c.slo"))}},
+		{fn: "match", testCase: testCase{name: "match", args: strs("*.slo", "a.slo"), want: types.NewBool(true)}},
+		{fn: "match", testCase: testCase{name: "no_match", args: strs("*.slo", "a/b.slo"), want: types.NewBool(false)}},
+		{fn: "match", testCase: testCase{name: "invalid_pattern", args: strs("[", "a"), wantErr: errors.NewValueError(`invalid glob pattern "["`)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.fn+"_"+tc.name, makeModuleTestCallback(&pathModule{}, tc.fn, tc.testCase))
+	}
+}
+
+func Test_path_scriptDir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt := &execute.Runtime{}
+	m := &pathModule{runtime: rt}
+	t.Run("no_script", makeModuleTestCallback(m, "scriptDir", testCase{want: types.NewStr(wd)}))
+
+	rt.Script = "testdata/main.slo"
+	t.Run("script", makeModuleTestCallback(m, "scriptDir", testCase{want: types.NewStr(filepath.Join(wd, "testdata"))}))
+
+	rt.PushFile("/lib/util/index.slo")
+	t.Run("importing", makeModuleTestCallback(m, "scriptDir", testCase{want: types.NewStr("/lib/util")}))
+
+	t.Run("too_many_args", makeModuleTestCallback(m, "scriptDir", testCase{
+		args:    strs("a"),
+		wantErr: errors.CallError("path.scriptDir", 1, 0),
+	}))
+}
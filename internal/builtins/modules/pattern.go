@@ -0,0 +1,550 @@
+package modules
+
+import (
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Type definitions
+// -------------------------------------------------------------------------------------------------
+
+type patternType struct{}
+
+func (t *patternType) IsNumeric() bool {
+	return false
+}
+
+func (t *patternType) New(v execute.Value) (execute.Value, error) {
+	panic("patternType.New() is not supported")
+}
+
+func (t *patternType) String() string {
+	return "pattern"
+}
+
+var PatternType = &patternType{}
+
+type matchType struct{}
+
+func (t *matchType) IsNumeric() bool {
+	return false
+}
+
+func (t *matchType) New(v execute.Value) (execute.Value, error) {
+	panic("matchType.New() is not supported")
+}
+
+func (t *matchType) String() string {
+	return "match"
+}
+
+var MatchType = &matchType{}
+
+// -------------------------------------------------------------------------------------------------
+// Pattern implementation
+// -------------------------------------------------------------------------------------------------
+
+// Pattern is a compiled regular expression.
+type Pattern struct {
+	re *regexp.Regexp
+	// anchored matches the same text as re, but only at the start of the input. It is compiled the
+	// first time it is needed.
+	anchored *regexp.Regexp
+}
+
+// compilePattern compiles a regular expression with Go's syntax.
+func compilePattern(src string) (*Pattern, error) {
+	re, err := regexp.Compile(src)
+	if err != nil {
+		msg := err.Error()
+		var se *syntax.Error
+		if stderrors.As(err, &se) {
+			msg = string(se.Code)
+		}
+		return nil, errors.NewValueError(fmt.Sprintf("invalid regular expression %q: %s", src, msg))
+	}
+	return &Pattern{re: re}, nil
+}
+
+// search returns the first match of the pattern in text, or null if there isn't one.
+func (v *Pattern) search(text string) execute.Value {
+	loc := v.re.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return types.Null
+	}
+	return &Match{re: v.re, text: text, loc: loc}
+}
+
+// match returns the match of the pattern at the start of text, or null if there isn't one.
+func (v *Pattern) match(text string) execute.Value {
+	if v.anchored == nil {
+		// The pattern is wrapped in a group so that flags and alternations in it don't apply to the
+		// anchor; the group doesn't capture, so group numbers are unchanged.
+		v.anchored = regexp.MustCompile(`\A(?:` + v.re.String() + `)`)
+	}
+	loc := v.anchored.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return types.Null
+	}
+	return &Match{re: v.re, text: text, loc: loc}
+}
+
+// findall returns a generator that lazily finds the matches of the pattern in text.
+func (v *Pattern) findall(text string) execute.Value {
+	return types.NewGenerator(&matchIterator{re: v.re, text: text})
+}
+
+// sub replaces at most count matches of the pattern in text, or every match if count is 0. If repl
+// is a str, $1 or ${name} in it are replaced by the text of the corresponding group; otherwise, it
+// is called with each match and must return a str.
+func (v *Pattern) sub(env *execute.Environment, repl execute.Value, text string, count int64) (execute.Value, error) {
+	var replStr string
+	var replFunc execute.Callable
+	if s, ok := repl.(*types.Str); ok {
+		replStr = s.Value()
+	} else {
+		var err error
+		if replFunc, err = repl.ToCallable(); err != nil {
+			return nil, errors.NewTypeError(repl.Type(), types.StrType)
+		}
+	}
+	n := -1
+	if count > 0 {
+		n = int(count)
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range v.re.FindAllStringSubmatchIndex(text, n) {
+		b.WriteString(text[last:loc[0]])
+		last = loc[1]
+		if replFunc == nil {
+			b.Write(v.re.ExpandString(nil, replStr, text, loc))
+			continue
+		}
+		r, err := replFunc.Call(env.NewFrame(), &Match{re: v.re, text: text, loc: loc})
+		if err != nil {
+			return nil, err
+		}
+		rs, ok := r.(*types.Str)
+		if !ok {
+			return nil, errors.NewTypeError(r.Type(), types.StrType)
+		}
+		b.WriteString(rs.Value())
+	}
+	b.WriteString(text[last:])
+	return types.NewStr(b.String()), nil
+}
+
+// split splits text around the matches of the pattern, splitting at most limit times, or at every
+// match if limit is 0.
+func (v *Pattern) split(text string, limit int64) execute.Value {
+	n := -1
+	if limit > 0 {
+		n = int(limit) + 1
+	}
+	parts := v.re.Split(text, n)
+	vs := make([]execute.Value, len(parts))
+	for i, p := range parts {
+		vs[i] = types.NewStr(p)
+	}
+	return types.NewList(vs)
+}
+
+// patternMethod returns a method of a pattern that takes a str and zero or more optional integers,
+// and calls f with them.
+func patternMethod(name string, optional int, f func(p *Pattern, text string, opts []int64) execute.Value) func(*Pattern) execute.Value {
+	return func(v *Pattern) execute.Value {
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			text, opts, err := reArgs(name, vs, 1, optional)
+			if err != nil {
+				return nil, err
+			}
+			return f(v, text[0], opts), nil
+		})
+	}
+}
+
+var patternMethods = map[string]func(*Pattern) execute.Value{
+	"match": patternMethod("pattern.match", 0, func(p *Pattern, text string, _ []int64) execute.Value {
+		return p.match(text)
+	}),
+	"search": patternMethod("pattern.search", 0, func(p *Pattern, text string, _ []int64) execute.Value {
+		return p.search(text)
+	}),
+	"findall": patternMethod("pattern.findall", 0, func(p *Pattern, text string, _ []int64) execute.Value {
+		return p.findall(text)
+	}),
+	"split": patternMethod("pattern.split", 1, func(p *Pattern, text string, opts []int64) execute.Value {
+		return p.split(text, opts[0])
+	}),
+	"sub": func(v *Pattern) execute.Value {
+		name := "pattern.sub"
+		return types.NewEnvGoFunc(name, func(env *execute.Environment, vs ...execute.Value) (execute.Value, error) {
+			if len(vs) < 2 || len(vs) > 3 {
+				return nil, errors.CallError(name, len(vs), min(max(len(vs), 2), 3))
+			}
+			text, opts, err := reArgs(name, vs[1:], 1, 1)
+			if err != nil {
+				return nil, err
+			}
+			return v.sub(env, vs[0], text[0], opts[0])
+		})
+	},
+	"pattern": func(v *Pattern) execute.Value {
+		name := "pattern.pattern"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) != 0 {
+				return nil, errors.CallError(name, len(vs), 0)
+			}
+			return types.NewStr(v.re.String()), nil
+		})
+	},
+}
+
+func (v *Pattern) CloneIfPrimitive() execute.Value {
+	return v
+}
+
+func (v *Pattern) CompareTo(o execute.Value) (int, bool) {
+	return 0, false
+}
+
+func (v *Pattern) Equals(o execute.Value) bool {
+	op, ok := o.(*Pattern)
+	return ok && v == op
+}
+
+func (v *Pattern) GetAttribute(a string) (execute.Value, error) {
+	if methodFactory, ok := patternMethods[a]; ok {
+		return methodFactory(v), nil
+	}
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Pattern) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Pattern) HasAttribute(a string) bool {
+	_, ok := patternMethods[a]
+	return ok
+}
+
+func (v *Pattern) HashBytes() ([]byte, error) {
+	return nil, errors.UnhashableTypeError(v.Type())
+}
+
+func (v *Pattern) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *Pattern) SetAttribute(a string, _ execute.Value) error {
+	return errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Pattern) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Pattern) String() string {
+	return fmt.Sprintf("<pattern %q>", v.re.String())
+}
+
+func (v *Pattern) ToBool() bool {
+	return true
+}
+
+func (v *Pattern) ToBytes() ([]byte, error) {
+	return nil, errors.NewTypeError(v.Type(), types.BytesType)
+}
+
+func (v *Pattern) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), types.FuncType)
+}
+
+func (v *Pattern) ToFloat() (float64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.FloatType)
+}
+
+func (v *Pattern) ToInt() (int64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.IntType)
+}
+
+func (v *Pattern) ToIterator() (execute.Iterator, error) {
+	return nil, errors.NewTypeError(v.Type(), types.IteratorType)
+}
+
+func (v *Pattern) ToStr() (string, error) {
+	return v.String(), nil
+}
+
+func (v *Pattern) ToUint() (uint64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.UintType)
+}
+
+func (v *Pattern) Type() execute.Type {
+	return PatternType
+}
+
+// -------------------------------------------------------------------------------------------------
+// Match implementation
+// -------------------------------------------------------------------------------------------------
+
+// Match is a match of a pattern in a str. Its offsets are byte offsets, like str indices.
+type Match struct {
+	re   *regexp.Regexp
+	text string
+	// loc holds the start and end offsets of the whole match followed by those of each group, with
+	// -1 for groups that didn't participate in the match.
+	loc []int
+}
+
+// groupIndex returns the number of the group identified by v, which is either a group number or
+// the name of a named group.
+func (v *Match) groupIndex(g execute.Value) (int, error) {
+	if s, ok := g.(*types.Str); ok {
+		if i := v.re.SubexpIndex(s.Value()); i >= 0 {
+			return i, nil
+		}
+		return 0, errors.NewIndexError(fmt.Sprintf("no group named %q", s.Value()))
+	}
+	n, err := integerArg(g)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsInt64() || n.Sign() < 0 || n.Int64() > int64(v.re.NumSubexp()) {
+		return 0, errors.NewIndexError(n.String())
+	}
+	return int(n.Int64()), nil
+}
+
+// group returns the text matched by group i, or null if it didn't participate in the match.
+func (v *Match) group(i int) execute.Value {
+	start, end := v.loc[2*i], v.loc[2*i+1]
+	if start < 0 {
+		return types.Null
+	}
+	return types.NewStr(v.text[start:end])
+}
+
+// groupMethod returns a method of a match that takes an optional group, which defaults to the whole
+// match, and calls f with its number.
+func groupMethod(name string, f func(m *Match, i int) execute.Value) func(*Match) execute.Value {
+	return func(v *Match) execute.Value {
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) > 1 {
+				return nil, errors.CallError(name, len(vs), 1)
+			}
+			i := 0
+			if len(vs) == 1 {
+				var err error
+				if i, err = v.groupIndex(vs[0]); err != nil {
+					return nil, err
+				}
+			}
+			return f(v, i), nil
+		})
+	}
+}
+
+var matchMethods = map[string]func(*Match) execute.Value{
+	"group": groupMethod("match.group", func(m *Match, i int) execute.Value {
+		return m.group(i)
+	}),
+	"start": groupMethod("match.start", func(m *Match, i int) execute.Value {
+		return types.NewInt(int64(m.loc[2*i]))
+	}),
+	"end": groupMethod("match.end", func(m *Match, i int) execute.Value {
+		return types.NewInt(int64(m.loc[2*i+1]))
+	}),
+	"groups": func(v *Match) execute.Value {
+		name := "match.groups"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) != 0 {
+				return nil, errors.CallError(name, len(vs), 0)
+			}
+			gs := make([]execute.Value, v.re.NumSubexp())
+			for i := range gs {
+				gs[i] = v.group(i + 1)
+			}
+			return types.NewList(gs), nil
+		})
+	},
+	"named": func(v *Match) execute.Value {
+		name := "match.named"
+		return types.NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if len(vs) != 0 {
+				return nil, errors.CallError(name, len(vs), 0)
+			}
+			m := types.NewMap()
+			for i, n := range v.re.SubexpNames() {
+				if n == "" {
+					continue
+				}
+				if _, err := m.Set(types.NewStr(n), v.group(i)); err != nil {
+					return nil, err
+				}
+			}
+			return m, nil
+		})
+	},
+}
+
+func (v *Match) CloneIfPrimitive() execute.Value {
+	return v
+}
+
+func (v *Match) CompareTo(o execute.Value) (int, bool) {
+	return 0, false
+}
+
+func (v *Match) Equals(o execute.Value) bool {
+	om, ok := o.(*Match)
+	return ok && v == om
+}
+
+func (v *Match) GetAttribute(a string) (execute.Value, error) {
+	if methodFactory, ok := matchMethods[a]; ok {
+		return methodFactory(v), nil
+	}
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Match) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Match) HasAttribute(a string) bool {
+	_, ok := matchMethods[a]
+	return ok
+}
+
+func (v *Match) HashBytes() ([]byte, error) {
+	return nil, errors.UnhashableTypeError(v.Type())
+}
+
+func (v *Match) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *Match) SetAttribute(a string, _ execute.Value) error {
+	return errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Match) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Match) String() string {
+	return fmt.Sprintf("<match %q>", v.text[v.loc[0]:v.loc[1]])
+}
+
+func (v *Match) ToBool() bool {
+	return true
+}
+
+func (v *Match) ToBytes() ([]byte, error) {
+	return nil, errors.NewTypeError(v.Type(), types.BytesType)
+}
+
+func (v *Match) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), types.FuncType)
+}
+
+func (v *Match) ToFloat() (float64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.FloatType)
+}
+
+func (v *Match) ToInt() (int64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.IntType)
+}
+
+func (v *Match) ToIterator() (execute.Iterator, error) {
+	return nil, errors.NewTypeError(v.Type(), types.IteratorType)
+}
+
+func (v *Match) ToStr() (string, error) {
+	return v.String(), nil
+}
+
+func (v *Match) ToUint() (uint64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.UintType)
+}
+
+func (v *Match) Type() execute.Type {
+	return MatchType
+}
+
+// -------------------------------------------------------------------------------------------------
+// Match iterator
+// -------------------------------------------------------------------------------------------------
+
+// matchIterator is an iterator over the matches of a pattern in a str. For a pattern without
+// groups, each value is the text of a match; for a pattern with one group, it is the text of that
+// group; and for a pattern with more groups, it is a list of the groups' text.
+type matchIterator struct {
+	re   *regexp.Regexp
+	text string
+	// locs are the matches that have been found so far, and next is the index of the next one to
+	// return.
+	locs [][]int
+	next int
+	done bool
+}
+
+func (it *matchIterator) HasNext() bool {
+	if it.next < len(it.locs) {
+		return true
+	}
+	if it.done {
+		return false
+	}
+	// The regexp package can't resume a search where a previous one stopped without losing the
+	// context that anchors like \b depend on, so the search is repeated for twice as many matches
+	// each time more are needed. This keeps the total work proportional to the text that has been
+	// searched while still stopping early if the iteration does.
+	n := max(2*len(it.locs), 8)
+	it.locs = it.re.FindAllStringSubmatchIndex(it.text, n)
+	it.done = len(it.locs) < n
+	return it.next < len(it.locs)
+}
+
+func (it *matchIterator) Next() (execute.Value, error) {
+	if !it.HasNext() {
+		return nil, errors.NewValueError("no matches left")
+	}
+	m := &Match{re: it.re, text: it.text, loc: it.locs[it.next]}
+	it.next++
+	switch it.re.NumSubexp() {
+	case 0:
+		return m.group(0), nil
+	case 1:
+		return groupText(m.group(1)), nil
+	}
+	gs := make([]execute.Value, it.re.NumSubexp())
+	for i := range gs {
+		gs[i] = groupText(m.group(i + 1))
+	}
+	return types.NewList(gs), nil
+}
+
+func (it *matchIterator) WithContainerLen(uint64) *types.Generator {
+	return types.NewGenerator(it)
+}
+
+// groupText returns the text of a group for re.findall, which uses an empty str for groups that
+// didn't participate in a match.
+func groupText(g execute.Value) execute.Value {
+	if g == types.Null {
+		return types.NewStr("")
+	}
+	return g
+}
@@ -0,0 +1,247 @@
+package modules
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// randomModule is the random module. Each instance has its own generator, so the random numbers
+// produced in one interpreter aren't affected by other interpreters, and seeding it makes every
+// later result deterministic.
+type randomModule struct {
+	rng *rand.Rand
+}
+
+// reseed replaces the module's generator with a new one seeded with the provided value.
+func (m *randomModule) reseed(seed uint64) {
+	m.rng = rand.New(rand.NewPCG(seed, 0))
+}
+
+// generator returns the module's generator, seeding it randomly if it hasn't been seeded yet.
+func (m *randomModule) generator() *rand.Rand {
+	if m.rng == nil {
+		m.reseed(rand.Uint64())
+	}
+	return m.rng
+}
+
+func (m *randomModule) seed(args ...execute.Value) (execute.Value, error) {
+	if len(args) > 1 {
+		return nil, errors.CallError("random.seed", len(args), 1)
+	}
+	if len(args) == 0 {
+		m.reseed(rand.Uint64())
+		return types.Null, nil
+	}
+	n, err := integerArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case n.IsUint64():
+		m.reseed(n.Uint64())
+	case n.IsInt64():
+		m.reseed(uint64(n.Int64()))
+	default:
+		return nil, errors.NewValueError(fmt.Sprintf("seed %s does not fit in 64 bits", n))
+	}
+	return types.Null, nil
+}
+
+// randBigN returns a uniformly distributed random integer in [0, n).
+func randBigN(rng *rand.Rand, n *big.Int) *big.Int {
+	bits := n.BitLen()
+	buf := make([]byte, (bits+63)/64*8)
+	excess := len(buf)*8 - bits
+	r := new(big.Int)
+	for {
+		for i := 0; i < len(buf); i += 8 {
+			binary.BigEndian.PutUint64(buf[i:], rng.Uint64())
+		}
+		// Clear the bits above the length of n so that at least half of the candidates are
+		// accepted.
+		for i := 0; i < excess; i++ {
+			buf[i/8] &^= 0x80 >> (i % 8)
+		}
+		if r.SetBytes(buf).Cmp(n) < 0 {
+			return r
+		}
+	}
+}
+
+func (m *randomModule) randInt(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 2 {
+		return nil, errors.CallError("random.int", len(args), 2)
+	}
+	lo, err := integerArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	hi, err := integerArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if lo.Cmp(hi) > 0 {
+		return nil, errors.NewValueError(fmt.Sprintf("random.int requires lo <= hi, got %s and %s", lo, hi))
+	}
+	span := new(big.Int).Sub(hi, lo)
+	span.Add(span, big.NewInt(1))
+	var n *big.Int
+	if span.IsUint64() {
+		n = new(big.Int).SetUint64(m.generator().Uint64N(span.Uint64()))
+	} else {
+		n = randBigN(m.generator(), span)
+	}
+	return integer(n.Add(n, lo)), nil
+}
+
+func (m *randomModule) randFloat(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 0 {
+		return nil, errors.CallError("random.float", len(args), 0)
+	}
+	return types.NewFloat(m.generator().Float64()), nil
+}
+
+func (m *randomModule) uniform(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 2 {
+		return nil, errors.CallError("random.uniform", len(args), 2)
+	}
+	lo, err := floatArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	hi, err := floatArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return types.NewFloat(lo + (hi-lo)*m.generator().Float64()), nil
+}
+
+func (m *randomModule) gauss(args ...execute.Value) (execute.Value, error) {
+	if len(args) > 2 {
+		return nil, errors.CallError("random.gauss", len(args), 2)
+	}
+	// The defaults are the standard normal distribution's mean of 0 and standard deviation of 1.
+	xs := []float64{0, 1}
+	for i, a := range args {
+		x, err := floatArg(a)
+		if err != nil {
+			return nil, err
+		}
+		xs[i] = x
+	}
+	mu, sigma := xs[0], xs[1]
+	if sigma < 0 {
+		return nil, errors.NewValueError(fmt.Sprintf("standard deviation must be non-negative, got %s", args[1]))
+	}
+	return types.NewFloat(mu + sigma*m.generator().NormFloat64()), nil
+}
+
+func (m *randomModule) exponential(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("random.exponential", len(args), 1)
+	}
+	rate, err := floatArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if !(rate > 0) {
+		return nil, errors.NewValueError(fmt.Sprintf("rate must be positive, got %s", args[0]))
+	}
+	return types.NewFloat(m.generator().ExpFloat64() / rate), nil
+}
+
+func (m *randomModule) choice(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("random.choice", len(args), 1)
+	}
+	vs, err := values(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) == 0 {
+		return nil, errors.NewValueError("random.choice requires at least one value")
+	}
+	return vs[m.generator().IntN(len(vs))], nil
+}
+
+func (m *randomModule) sample(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 2 {
+		return nil, errors.CallError("random.sample", len(args), 2)
+	}
+	vs, err := values(args[:1])
+	if err != nil {
+		return nil, err
+	}
+	k, err := integerArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if k.Sign() < 0 || k.Cmp(big.NewInt(int64(len(vs)))) > 0 {
+		return nil, errors.NewValueError(fmt.Sprintf("cannot sample %s values from %d values", k, len(vs)))
+	}
+	// Copy the values so that shuffling them doesn't modify a list that was passed in, then move a
+	// random remaining value into each of the first k positions.
+	vs = append([]execute.Value(nil), vs...)
+	rng := m.generator()
+	n := int(k.Int64())
+	for i := 0; i < n; i++ {
+		j := i + rng.IntN(len(vs)-i)
+		vs[i], vs[j] = vs[j], vs[i]
+	}
+	return types.NewList(vs[:n]), nil
+}
+
+func (m *randomModule) shuffle(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("random.shuffle", len(args), 1)
+	}
+	l, ok := args[0].(*types.List)
+	if !ok {
+		return nil, errors.NewTypeError(args[0].Type(), types.ListType)
+	}
+	vs, err := values(args)
+	if err != nil {
+		return nil, err
+	}
+	m.generator().Shuffle(len(vs), func(i, j int) {
+		vs[i], vs[j] = vs[j], vs[i]
+	})
+	// Setting the first element fails if the list is immutable, so the list is either shuffled
+	// completely or left unchanged.
+	for i, v := range vs {
+		if err := l.SetIndex(types.NewInt(int64(i)), v); err != nil {
+			return nil, err
+		}
+	}
+	return types.Null, nil
+}
+
+func (m *randomModule) Name() string {
+	return "random"
+}
+
+func (m *randomModule) Import() (*execute.Environment, error) {
+	fs := map[string]types.FuncImpl{
+		"choice":      m.choice,
+		"exponential": m.exponential,
+		"float":       m.randFloat,
+		"gauss":       m.gauss,
+		"int":         m.randInt,
+		"sample":      m.sample,
+		"seed":        m.seed,
+		"shuffle":     m.shuffle,
+		"uniform":     m.uniform,
+	}
+	vs := make(map[string]execute.Value, len(fs))
+	for name, impl := range fs {
+		vs[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(vs), nil
+}
@@ -0,0 +1,222 @@
+package modules
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+// callRandom calls the function with the provided name in m, failing the test if it returns an
+// error.
+func callRandom(t *testing.T, m *randomModule, fn string, args ...execute.Value) execute.Value {
+	t.Helper()
+	env, err := m.Import()
+	if err != nil {
+		t.Fatalf("m.Import() returned an unexpected error: %v", err)
+	}
+	f, err := env.Get(fn)
+	if err != nil {
+		t.Fatalf("failed to get function: %v", err)
+	}
+	fc, err := f.ToCallable()
+	if err != nil {
+		t.Fatalf("failed to convert Value to callable: %v", err)
+	}
+	v, err := fc.Call(env, args...)
+	if err != nil {
+		t.Fatalf("random.%s() returned an unexpected error: %v", fn, err)
+	}
+	return v
+}
+
+// seededRandom returns a random module whose generator has been seeded with the provided seed.
+func seededRandom(t *testing.T, seed int) *randomModule {
+	t.Helper()
+	m := &randomModule{}
+	callRandom(t, m, "seed", nums(seed)...)
+	return m
+}
+
+func Test_random_Name(t *testing.T) {
+	m := &randomModule{}
+	if got, want := m.Name(), "random"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_random(t *testing.T) {
+	big1, _ := new(big.Int).SetString("100000000000000000000", 10)
+	tests := []struct {
+		fn string
+		testCase
+	}{
+		{fn: "seed", testCase: testCase{name: "int", args: nums(-1), want: types.Null}},
+		{fn: "seed", testCase: testCase{name: "no_args", args: nums(), want: types.Null}},
+		{fn: "seed", testCase: testCase{name: "float", args: nums(1.5), wantErr: errors.NewTypeError(types.FloatType, types.IntType)}},
+		{fn: "seed", testCase: testCase{name: "too_large", args: nums(types.NewBigInt(big1)), wantErr: errors.NewValueError("seed 100000000000000000000 does not fit in 64 bits")}},
+		{fn: "seed", testCase: testCase{name: "too_many_args", args: nums(1, 2), wantErr: errors.CallError("random.seed", 2, 1)}},
+		{fn: "int", testCase: testCase{name: "single_value", args: nums(5, 5), want: types.NewInt(5)}},
+		{fn: "int", testCase: testCase{name: "single_bigint", args: nums(types.NewBigInt(big1), types.NewBigInt(big1)), want: types.NewBigInt(big1)}},
+		{fn: "int", testCase: testCase{name: "empty_range", args: nums(2, 1), wantErr: errors.NewValueError("random.int requires lo <= hi, got 2 and 1")}},
+		{fn: "int", testCase: testCase{name: "float", args: nums(0, 1.0), wantErr: errors.NewTypeError(types.FloatType, types.IntType)}},
+		{fn: "int", testCase: testCase{name: "one_arg", args: nums(1), wantErr: errors.CallError("random.int", 1, 2)}},
+		{fn: "float", testCase: testCase{name: "args", args: nums(1), wantErr: errors.CallError("random.float", 1, 0)}},
+		{fn: "uniform", testCase: testCase{name: "single_value", args: nums(2, 2), want: types.NewFloat(2)}},
+		{fn: "uniform", testCase: testCase{name: "str", args: strs("a", "b"), wantErr: errors.NewTypeError(types.StrType, types.FloatType)}},
+		{fn: "gauss", testCase: testCase{name: "no_deviation", args: nums(3, 0), want: types.NewFloat(3)}},
+		{fn: "gauss", testCase: testCase{name: "negative_deviation", args: nums(0, -1), wantErr: errors.NewValueError("standard deviation must be non-negative, got -1")}},
+		{fn: "gauss", testCase: testCase{name: "too_many_args", args: nums(0, 1, 2), wantErr: errors.CallError("random.gauss", 3, 2)}},
+		{fn: "exponential", testCase: testCase{name: "zero_rate", args: nums(0), wantErr: errors.NewValueError("rate must be positive, got 0")}},
+		{fn: "exponential", testCase: testCase{name: "no_args", args: nums(), wantErr: errors.CallError("random.exponential", 0, 1)}},
+		{fn: "choice", testCase: testCase{name: "single_value", args: []execute.Value{types.NewList(strs("a"))}, want: types.NewStr("a")}},
+		{fn: "choice", testCase: testCase{name: "empty", args: []execute.Value{types.NewList(nil)}, wantErr: errors.NewValueError("random.choice requires at least one value")}},
+		{fn: "sample", testCase: testCase{name: "none", args: []execute.Value{types.NewList(nums(1, 2)), types.NewInt(0)}, want: types.NewList([]execute.Value{})}},
+		{fn: "sample", testCase: testCase{name: "too_many", args: []execute.Value{types.NewList(nums(1, 2)), types.NewInt(3)}, wantErr: errors.NewValueError("cannot sample 3 values from 2 values")}},
+		{fn: "sample", testCase: testCase{name: "negative", args: []execute.Value{types.NewList(nums(1, 2)), types.NewInt(-1)}, wantErr: errors.NewValueError("cannot sample -1 values from 2 values")}},
+		{fn: "shuffle", testCase: testCase{name: "empty", args: []execute.Value{types.NewList(nil)}, want: types.Null}},
+		{fn: "shuffle", testCase: testCase{name: "str", args: strs("abc"), wantErr: errors.NewTypeError(types.StrType, types.ListType)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.fn+"_"+tc.name, makeModuleTestCallback(&randomModule{}, tc.fn, tc.testCase))
+	}
+}
+
+func Test_random_seed(t *testing.T) {
+	draw := func(m *randomModule) []execute.Value {
+		l := types.NewList(nums(1, 2, 3, 4, 5))
+		callRandom(t, m, "shuffle", l)
+		return []execute.Value{
+			callRandom(t, m, "int", nums(1, 100)...),
+			callRandom(t, m, "int", types.NewInt(0), types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 100))),
+			callRandom(t, m, "float"),
+			callRandom(t, m, "uniform", nums(-1, 1)...),
+			callRandom(t, m, "gauss"),
+			callRandom(t, m, "exponential", nums(2)...),
+			callRandom(t, m, "choice", strs("abcdef")...),
+			callRandom(t, m, "sample", types.NewList(nums(1, 2, 3, 4, 5)), types.NewInt(3)),
+			l,
+		}
+	}
+
+	a, b := seededRandom(t, 42), seededRandom(t, 42)
+	want := draw(a)
+	// Drawing from another generator in between must not change the results.
+	draw(seededRandom(t, 7))
+	if diff := cmp.Diff(want, draw(b), slowcmpopts.AllowUnexported()); diff != "" {
+		t.Errorf("generators with the same seed returned an unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// Reseeding a generator restarts its sequence.
+	callRandom(t, a, "seed", nums(42)...)
+	if diff := cmp.Diff(want, draw(a), slowcmpopts.AllowUnexported()); diff != "" {
+		t.Errorf("reseeded generator returned an unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(want, draw(seededRandom(t, 43)), slowcmpopts.AllowUnexported()); diff == "" {
+		t.Errorf("generators with different seeds returned the same values")
+	}
+}
+
+func Test_random_ranges(t *testing.T) {
+	m := seededRandom(t, 1)
+	lo, hi := new(big.Int).Lsh(big.NewInt(-1), 70), new(big.Int).Lsh(big.NewInt(1), 70)
+	seen := make(map[int64]bool)
+	for i := 0; i < 1000; i++ {
+		n, err := types.ToBigInt(callRandom(t, m, "int", nums(-2, 2)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[n.Int64()] = true
+
+		b, err := types.ToBigInt(callRandom(t, m, "int", types.NewBigInt(lo), types.NewBigInt(hi)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Cmp(lo) < 0 || b.Cmp(hi) > 0 {
+			t.Errorf("random.int(%s, %s) = %s, want a value in the range", lo, hi, b)
+		}
+
+		f, _ := callRandom(t, m, "float").ToFloat()
+		if f < 0 || f >= 1 {
+			t.Errorf("random.float() = %v, want a value in [0, 1)", f)
+		}
+
+		u, _ := callRandom(t, m, "uniform", nums(10, 20)...).ToFloat()
+		if u < 10 || u > 20 {
+			t.Errorf("random.uniform(10, 20) = %v, want a value in [10, 20]", u)
+		}
+
+		e, _ := callRandom(t, m, "exponential", nums(0.5)...).ToFloat()
+		if e < 0 {
+			t.Errorf("random.exponential(0.5) = %v, want a non-negative value", e)
+		}
+	}
+	if want := map[int64]bool{-2: true, -1: true, 0: true, 1: true, 2: true}; !cmp.Equal(want, seen) {
+		t.Errorf("random.int(-2, 2) returned %v, want every value from -2 to 2", seen)
+	}
+}
+
+func Test_random_shuffle(t *testing.T) {
+	m := seededRandom(t, 1)
+	vs := nums(1, 2, 3, 4, 5, 6, 7, 8)
+	l := types.NewList(slices.Clone(vs))
+	callRandom(t, m, "shuffle", l)
+	got := listValues(t, l)
+	if cmp.Equal(vs, got, slowcmpopts.AllowUnexported()) {
+		t.Errorf("random.shuffle() didn't change the order of the list")
+	}
+	slices.SortFunc(got, compareInts)
+	if diff := cmp.Diff(vs, got, slowcmpopts.AllowUnexported()); diff != "" {
+		t.Errorf("random.shuffle() returned an unexpected diff in the list's values (-want +got):\n%s", diff)
+	}
+
+	t.Run("immutable", func(t *testing.T) {
+		imm, err := callMethod(t, types.NewList(slices.Clone(vs)), "to_immutable")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = m.shuffle(imm)
+		checkCall(t, "random.shuffle()", nil, errors.NewValueError("list is immutable"), nil, err)
+		if diff := cmp.Diff(vs, listValues(t, imm), slowcmpopts.AllowUnexported()); diff != "" {
+			t.Errorf("random.shuffle() changed an immutable list (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func Test_random_sample(t *testing.T) {
+	m := seededRandom(t, 1)
+	vs := nums(1, 2, 3, 4, 5, 6, 7, 8)
+	l := types.NewList(slices.Clone(vs))
+	got := listValues(t, callRandom(t, m, "sample", l, types.NewInt(5)))
+	if len(got) != 5 {
+		t.Fatalf("random.sample() returned %d values, want 5", len(got))
+	}
+	slices.SortFunc(got, compareInts)
+	if got = slices.CompactFunc(got, func(a, b execute.Value) bool { return a.Equals(b) }); len(got) != 5 {
+		t.Errorf("random.sample() returned duplicate values: %v", got)
+	}
+	if diff := cmp.Diff(vs, listValues(t, l), slowcmpopts.AllowUnexported()); diff != "" {
+		t.Errorf("random.sample() changed the population (-want +got):\n%s", diff)
+	}
+}
+
+func listValues(t *testing.T, v execute.Value) []execute.Value {
+	t.Helper()
+	vs, err := values([]execute.Value{v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
+
+func compareInts(a, b execute.Value) int {
+	x, _ := a.ToInt()
+	y, _ := b.ToInt()
+	return int(x - y)
+}
@@ -0,0 +1,144 @@
+package modules
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// reModule is the re module, which uses Go's regular expression syntax.
+type reModule struct{}
+
+// reArgs returns the arguments passed to the re function name: nStr strs followed by up to optional
+// non-negative integer limits, which default to 0.
+func reArgs(name string, args []execute.Value, nStr, optional int) ([]string, []int64, error) {
+	if len(args) < nStr || len(args) > nStr+optional {
+		want := nStr
+		if len(args) > nStr {
+			want = nStr + optional
+		}
+		return nil, nil, errors.CallError(name, len(args), want)
+	}
+	strs := make([]string, nStr)
+	for i, a := range args[:nStr] {
+		s, ok := a.(*types.Str)
+		if !ok {
+			return nil, nil, errors.NewTypeError(a.Type(), types.StrType)
+		}
+		strs[i] = s.Value()
+	}
+	opts := make([]int64, optional)
+	for i, a := range args[nStr:] {
+		n, err := integerArg(a)
+		if err != nil {
+			return nil, nil, err
+		}
+		if n.Sign() < 0 {
+			return nil, nil, errors.NewValueError(fmt.Sprintf("%s requires a non-negative limit, got %s", name, n))
+		}
+		if n.IsInt64() {
+			opts[i] = n.Int64()
+		}
+	}
+	return strs, opts, nil
+}
+
+// patternArg returns the pattern passed to a re function, compiling it if it is a str.
+func patternArg(v execute.Value) (*Pattern, error) {
+	switch v := v.(type) {
+	case *Pattern:
+		return v, nil
+	case *types.Str:
+		return compilePattern(v.Value())
+	}
+	return nil, errors.NewTypeError(v.Type(), types.StrType)
+}
+
+// reFunc returns a module function that takes a pattern, a str, and up to optional integer limits,
+// and calls f with them.
+func reFunc(name string, optional int, f func(p *Pattern, text string, opts []int64) execute.Value) types.FuncImpl {
+	return func(args ...execute.Value) (execute.Value, error) {
+		if len(args) < 2 || len(args) > 2+optional {
+			return nil, errors.CallError(name, len(args), min(max(len(args), 2), 2+optional))
+		}
+		p, err := patternArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		text, opts, err := reArgs(name, args[1:], 1, optional)
+		if err != nil {
+			return nil, err
+		}
+		return f(p, text[0], opts), nil
+	}
+}
+
+func re_compile(args ...execute.Value) (execute.Value, error) {
+	src, _, err := reArgs("re.compile", args, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	p, err := compilePattern(src[0])
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func re_escape(args ...execute.Value) (execute.Value, error) {
+	text, _, err := reArgs("re.escape", args, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewStr(regexp.QuoteMeta(text[0])), nil
+}
+
+func re_sub(env *execute.Environment, args ...execute.Value) (execute.Value, error) {
+	if len(args) < 3 || len(args) > 4 {
+		return nil, errors.CallError("re.sub", len(args), min(max(len(args), 3), 4))
+	}
+	p, err := patternArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+	text, opts, err := reArgs("re.sub", args[2:], 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	return p.sub(env, args[1], text[0], opts[0])
+}
+
+var reFunctions = map[string]types.FuncImpl{
+	"compile": re_compile,
+	"escape":  re_escape,
+	"match": reFunc("re.match", 0, func(p *Pattern, text string, _ []int64) execute.Value {
+		return p.match(text)
+	}),
+	"search": reFunc("re.search", 0, func(p *Pattern, text string, _ []int64) execute.Value {
+		return p.search(text)
+	}),
+	"findall": reFunc("re.findall", 0, func(p *Pattern, text string, _ []int64) execute.Value {
+		return p.findall(text)
+	}),
+	"split": reFunc("re.split", 1, func(p *Pattern, text string, opts []int64) execute.Value {
+		return p.split(text, opts[0])
+	}),
+}
+
+func (m *reModule) Name() string {
+	return "re"
+}
+
+func (m *reModule) Import() (*execute.Environment, error) {
+	vs := map[string]execute.Value{
+		// sub calls user-defined replacement functions, so it needs the environment it is called from.
+		"sub": types.NewEnvGoFunc("sub", re_sub),
+	}
+	for name, impl := range reFunctions {
+		vs[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(vs), nil
+}
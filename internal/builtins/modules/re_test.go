@@ -0,0 +1,233 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+// mustCompile compiles a pattern, failing the test if it is invalid.
+func mustCompile(t *testing.T, src string) *Pattern {
+	t.Helper()
+	p, err := compilePattern(src)
+	if err != nil {
+		t.Fatalf("compilePattern(%q) returned an unexpected error: %v", src, err)
+	}
+	return p
+}
+
+func Test_re_Name(t *testing.T) {
+	m := &reModule{}
+	if got, want := m.Name(), "re"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_re(t *testing.T) {
+	upper := types.NewGoFunc("upper", func(vs ...execute.Value) (execute.Value, error) {
+		s, err := vs[0].(*Match).group(0).ToStr()
+		return types.NewStr("<" + s + ">"), err
+	})
+	notStr := types.NewGoFunc("notStr", func(vs ...execute.Value) (execute.Value, error) {
+		return types.NewInt(1), nil
+	})
+	failing := types.NewGoFunc("failing", func(vs ...execute.Value) (execute.Value, error) {
+		return nil, errors.NewValueError("failed")
+	})
+	tests := []struct {
+		fn string
+		testCase
+	}{
+		{fn: "compile", testCase: testCase{name: "invalid", args: strs("(a"), wantErr: errors.NewValueError(`invalid regular expression "(a": missing closing )`)}},
+		{fn: "compile", testCase: testCase{name: "int", args: nums(1), wantErr: errors.NewTypeError(types.IntType, types.StrType)}},
+		{fn: "compile", testCase: testCase{name: "no_args", args: nums(), wantErr: errors.CallError("re.compile", 0, 1)}},
+		{fn: "escape", testCase: testCase{name: "success", args: strs("1+1=2?"), want: types.NewStr(`1\+1=2\?`)}},
+		{fn: "match", testCase: testCase{name: "not_at_start", args: strs(`\d+`, "abc 123"), want: types.Null}},
+		{fn: "match", testCase: testCase{name: "invalid", args: strs("[a", "a"), wantErr: errors.NewValueError(`invalid regular expression "[a": missing closing ]`)}},
+		{fn: "match", testCase: testCase{name: "int_pattern", args: []execute.Value{types.NewInt(1), types.NewStr("1")}, wantErr: errors.NewTypeError(types.IntType, types.StrType)}},
+		{fn: "search", testCase: testCase{name: "no_match", args: strs(`\d+`, "abc"), want: types.Null}},
+		{fn: "search", testCase: testCase{name: "bytes", args: []execute.Value{types.NewStr("a"), types.NewBytes([]byte("a"))}, wantErr: errors.NewTypeError(types.BytesType, types.StrType)}},
+		{fn: "search", testCase: testCase{name: "one_arg", args: strs("a"), wantErr: errors.CallError("re.search", 1, 2)}},
+		{fn: "sub", testCase: testCase{name: "str", args: strs("[aeiou]", "*", "banana"), want: types.NewStr("b*n*n*")}},
+		{fn: "sub", testCase: testCase{name: "count", args: append(strs("[aeiou]", "*", "banana"), types.NewInt(2)), want: types.NewStr("b*n*na")}},
+		{fn: "sub", testCase: testCase{name: "groups", args: strs(`(\w+)@(?P<host>\w+)`, "${host} at $1", "me@example"), want: types.NewStr("example at me")}},
+		{fn: "sub", testCase: testCase{name: "empty_matches", args: strs("x*", "-", "abc"), want: types.NewStr("-a-b-c-")}},
+		{fn: "sub", testCase: testCase{name: "func", args: []execute.Value{types.NewStr("a+"), upper, types.NewStr("baaad a")}, want: types.NewStr("b<aaa>d <a>")}},
+		{fn: "sub", testCase: testCase{name: "func_not_str", args: []execute.Value{types.NewStr("a"), notStr, types.NewStr("a")}, wantErr: errors.NewTypeError(types.IntType, types.StrType)}},
+		{fn: "sub", testCase: testCase{name: "func_error", args: []execute.Value{types.NewStr("a"), failing, types.NewStr("a")}, wantErr: errors.NewValueError("failed")}},
+		{fn: "sub", testCase: testCase{name: "func_not_called", args: []execute.Value{types.NewStr("a"), failing, types.NewStr("b")}, want: types.NewStr("b")}},
+		{fn: "sub", testCase: testCase{name: "bad_repl", args: []execute.Value{types.NewStr("a"), types.NewInt(1), types.NewStr("a")}, wantErr: errors.NewTypeError(types.IntType, types.StrType)}},
+		{fn: "sub", testCase: testCase{name: "negative_count", args: append(strs("a", "b", "a"), types.NewInt(-1)), wantErr: errors.NewValueError("re.sub requires a non-negative limit, got -1")}},
+		{fn: "sub", testCase: testCase{name: "two_args", args: strs("a", "b"), wantErr: errors.CallError("re.sub", 2, 3)}},
+		{fn: "split", testCase: testCase{name: "all", args: strs(`,\s*`, "a, b,c,   d"), want: types.NewList(strs("a", "b", "c", "d"))}},
+		{fn: "split", testCase: testCase{name: "limit", args: append(strs(",", "a,b,c,d"), types.NewInt(2)), want: types.NewList(strs("a", "b", "c,d"))}},
+		{fn: "split", testCase: testCase{name: "no_match", args: strs(",", "abc"), want: types.NewList(strs("abc"))}},
+		{fn: "split", testCase: testCase{name: "float_limit", args: append(strs(",", "a,b"), types.NewFloat(1)), wantErr: errors.NewTypeError(types.FloatType, types.IntType)}},
+		{fn: "split", testCase: testCase{name: "too_many_args", args: append(strs(",", "a,b"), nums(1, 2)...), wantErr: errors.CallError("re.split", 4, 3)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.fn+"_"+tc.name, makeModuleTestCallback(&reModule{}, tc.fn, tc.testCase))
+	}
+}
+
+func Test_re_match(t *testing.T) {
+	p := mustCompile(t, `(?P<key>\w+)=(?P<value>\d+)?`)
+	for _, tc := range []struct {
+		name string
+		fn   func(string) execute.Value
+		text string
+		// groups are the values returned by match.group for the whole match and then each group.
+		groups []execute.Value
+		starts []int64
+		end    int64
+	}{
+		{
+			name:   "search",
+			fn:     p.search,
+			text:   "a; x=10, y=",
+			groups: []execute.Value{types.NewStr("x=10"), types.NewStr("x"), types.NewStr("10")},
+			starts: []int64{3, 3, 5},
+			end:    7,
+		},
+		{
+			name:   "match",
+			fn:     p.match,
+			text:   "y=; x=10",
+			groups: []execute.Value{types.NewStr("y="), types.NewStr("y"), types.Null},
+			starts: []int64{0, 0, -1},
+			end:    2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m, ok := tc.fn(tc.text).(*Match)
+			if !ok {
+				t.Fatalf("%s(%q) didn't return a match", tc.name, tc.text)
+			}
+			for i, want := range tc.groups {
+				got, err := callMethod(t, m, "group", types.NewInt(int64(i)))
+				checkCall(t, "match.group()", want, nil, got, err)
+				got, err = callMethod(t, m, "start", types.NewInt(int64(i)))
+				checkCall(t, "match.start()", types.NewInt(tc.starts[i]), nil, got, err)
+			}
+			got, err := callMethod(t, m, "group")
+			checkCall(t, "match.group()", tc.groups[0], nil, got, err)
+			got, err = callMethod(t, m, "group", types.NewStr("value"))
+			checkCall(t, "match.group()", tc.groups[2], nil, got, err)
+			got, err = callMethod(t, m, "end")
+			checkCall(t, "match.end()", types.NewInt(tc.end), nil, got, err)
+			got, err = callMethod(t, m, "groups")
+			checkCall(t, "match.groups()", types.NewList(tc.groups[1:]), nil, got, err)
+
+			named, err := callMethod(t, m, "named")
+			if err != nil {
+				t.Fatalf("match.named() returned an unexpected error: %v", err)
+			}
+			for i, key := range []string{"key", "value"} {
+				got, err := named.(*types.Map).Get(types.NewStr(key), nil)
+				checkCall(t, "match.named()", tc.groups[i+1], nil, got, err)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		m := p.search("x=1")
+		_, err := callMethod(t, m, "group", types.NewInt(3))
+		checkCall(t, "match.group()", nil, errors.NewIndexError("3"), nil, err)
+		_, err = callMethod(t, m, "start", types.NewStr("name"))
+		checkCall(t, "match.start()", nil, errors.NewIndexError(`no group named "name"`), nil, err)
+		_, err = callMethod(t, m, "end", types.NewFloat(1))
+		checkCall(t, "match.end()", nil, errors.NewTypeError(types.FloatType, types.IntType), nil, err)
+		_, err = callMethod(t, m, "groups", types.NewInt(1))
+		checkCall(t, "match.groups()", nil, errors.CallError("match.groups", 1, 0), nil, err)
+	})
+
+	t.Run("alternation", func(t *testing.T) {
+		// The anchor applies to every alternative.
+		if got := mustCompile(t, `a|b`).match("cb"); got != types.Null {
+			t.Errorf("match() = %v, want null", got)
+		}
+	})
+}
+
+func Test_re_findall(t *testing.T) {
+	many := make([]execute.Value, 20)
+	manyText := ""
+	for i := range many {
+		many[i] = types.NewStr("ab")
+		manyText += "ab "
+	}
+	for _, tc := range []struct {
+		name    string
+		pattern string
+		text    string
+		want    []execute.Value
+	}{
+		{name: "no_groups", pattern: `\w+`, text: "the quick fox", want: strs("the", "quick", "fox")},
+		{name: "one_group", pattern: `(\w)\w*`, text: "the quick fox", want: strs("t", "q", "f")},
+		{
+			name:    "groups",
+			pattern: `(\w)=(\d)?`,
+			text:    "a=1 b=",
+			want:    []execute.Value{types.NewList(strs("a", "1")), types.NewList(strs("b", ""))},
+		},
+		// An empty match directly after another match is skipped.
+		{name: "empty_matches", pattern: `a*`, text: "baaac", want: strs("", "aaa", "")},
+		{name: "no_matches", pattern: `\d`, text: "abc", want: nil},
+		// Word boundaries are found correctly after the first batch of matches.
+		{name: "many", pattern: `\bab\b`, text: manyText, want: many},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			it, err := mustCompile(t, tc.pattern).findall(tc.text).ToIterator()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []execute.Value
+			for it.HasNext() {
+				v, err := it.Next()
+				if err != nil {
+					t.Fatalf("Next() returned an unexpected error: %v", err)
+				}
+				got = append(got, v)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("findall() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+			if _, err := it.Next(); err == nil {
+				t.Errorf("Next() didn't return an error after the last match")
+			}
+		})
+	}
+}
+
+func Test_re_pattern(t *testing.T) {
+	p := mustCompile(t, `(\d+)`)
+	for _, tc := range []struct {
+		method string
+		testCase
+	}{
+		{method: "pattern", testCase: testCase{name: "success", want: types.NewStr(`(\d+)`)}},
+		{method: "match", testCase: testCase{name: "no_match", args: strs("a1"), want: types.Null}},
+		{method: "search", testCase: testCase{name: "no_match", args: strs("a"), want: types.Null}},
+		{method: "sub", testCase: testCase{name: "success", args: strs("<$1>", "a1b22"), want: types.NewStr("a<1>b<22>")}},
+		{method: "sub", testCase: testCase{name: "one_arg", args: strs("x"), wantErr: errors.CallError("pattern.sub", 1, 2)}},
+		{method: "split", testCase: testCase{name: "success", args: strs("a1b22c"), want: types.NewList(strs("a", "b", "c"))}},
+		{method: "split", testCase: testCase{name: "no_args", wantErr: errors.CallError("pattern.split", 0, 1)}},
+		{method: "findall", testCase: testCase{name: "int", args: nums(1), wantErr: errors.NewTypeError(types.IntType, types.StrType)}},
+	} {
+		t.Run(tc.method+"_"+tc.name, func(t *testing.T) {
+			got, err := callMethod(t, p, tc.method, tc.args...)
+			checkCall(t, "pattern."+tc.method+"()", tc.want, tc.wantErr, got, err)
+		})
+	}
+
+	if got := p.search("a12b").String(); got != `<match "12">` {
+		t.Errorf("String() = %s, want %s", got, `<match "12">`)
+	}
+	if got := p.String(); got != `<pattern "(\\d+)">` {
+		t.Errorf("String() = %s, want %s", got, `<pattern "(\\d+)">`)
+	}
+}
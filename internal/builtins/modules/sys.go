@@ -0,0 +1,33 @@
+package modules
+
+import (
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// sysModule is the sys module, which exposes the arguments and standard streams of the interpreter.
+type sysModule struct {
+	runtime *execute.Runtime
+}
+
+func (m *sysModule) Name() string {
+	return "sys"
+}
+
+func (m *sysModule) Import() (*execute.Environment, error) {
+	rt := m.runtime
+	if rt == nil {
+		rt = execute.NewRuntime()
+	}
+	// The first argument is the path to the main script, which is empty in the REPL.
+	argv := []execute.Value{types.NewStr(rt.Script)}
+	for _, a := range rt.Args {
+		argv = append(argv, types.NewStr(a))
+	}
+	return execute.FromMap(map[string]execute.Value{
+		"argv":   types.NewList(argv),
+		"stdin":  newStreamFile("<stdin>", rt.Stdin, nil),
+		"stdout": newStreamFile("<stdout>", nil, rt.Stdout),
+		"stderr": newStreamFile("<stderr>", nil, rt.Stderr),
+	}), nil
+}
@@ -0,0 +1,141 @@
+package modules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_sys_Name(t *testing.T) {
+	m := &sysModule{}
+	if got, want := m.Name(), "sys"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+// importSys imports the sys module for a runtime that reads stdin from the provided string and
+// returns the module's environment and the runtime's stdout and stderr.
+func importSys(t *testing.T, rt *execute.Runtime, stdin string) (*execute.Environment, *strings.Builder, *strings.Builder) {
+	t.Helper()
+	var stdout, stderr strings.Builder
+	rt.Stdin, rt.Stdout, rt.Stderr = strings.NewReader(stdin), &stdout, &stderr
+	env, err := (&sysModule{runtime: rt}).Import()
+	if err != nil {
+		t.Fatalf("Import() returned an unexpected error: %v", err)
+	}
+	return env, &stdout, &stderr
+}
+
+// getSys returns the value of a variable in the sys module.
+func getSys(t *testing.T, env *execute.Environment, name string) execute.Value {
+	t.Helper()
+	v, err := env.Get(name)
+	if err != nil {
+		t.Fatalf("env.Get(%q) returned an unexpected error: %v", name, err)
+	}
+	return v
+}
+
+// checkStreamError checks that a call to a method of a stream failed with the provided message.
+func checkStreamError(t *testing.T, name, want string, got execute.Value, err error) {
+	t.Helper()
+	if got != nil {
+		t.Errorf("%s returned a value for an unsupported operation: %v", name, got)
+	}
+	if err == nil || err.Error() != want {
+		t.Errorf("%s returned an incorrect error: got %v, want %s", name, err, want)
+	}
+}
+
+func Test_sys_argv(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   *execute.Runtime
+		want execute.Value
+	}{
+		{
+			name: "repl",
+			rt:   &execute.Runtime{},
+			want: types.NewList(strs("")),
+		},
+		{
+			name: "no_args",
+			rt:   &execute.Runtime{Script: "main.slo"},
+			want: types.NewList(strs("main.slo")),
+		},
+		{
+			name: "args",
+			rt:   &execute.Runtime{Script: "main.slo", Args: []string{"a", "-b"}},
+			want: types.NewList(strs("main.slo", "a", "-b")),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env, _, _ := importSys(t, tc.rt, "")
+			if diff := cmp.Diff(tc.want, getSys(t, env, "argv"), slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("sys.argv has a diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_sys_stdin(t *testing.T) {
+	env, _, _ := importSys(t, &execute.Runtime{}, "first\r\nsecond\nthird")
+	stdin := getSys(t, env, "stdin")
+
+	got, err := callMethod(t, stdin, "readline")
+	checkCall(t, "stdin.readline()", types.NewStr("first"), nil, got, err)
+
+	iter, err := stdin.ToIterator()
+	if err != nil {
+		t.Fatalf("ToIterator() returned an unexpected error: %v", err)
+	}
+	var lines []execute.Value
+	for iter.HasNext() {
+		line, err := iter.Next()
+		if err != nil {
+			t.Fatalf("Next() returned an unexpected error: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	if diff := cmp.Diff(strs("second", "third"), lines, slowcmpopts.AllowUnexported()); diff != "" {
+		t.Errorf("stdin has a diff (-want +got):\n%s", diff)
+	}
+
+	got, err = callMethod(t, stdin, "readline")
+	checkCall(t, "stdin.readline()", types.Null, nil, got, err)
+
+	got, err = callMethod(t, stdin, "write", types.NewStr("foo"))
+	checkStreamError(t, "stdin.write()", `FileError: file "<stdin>": stream is not writable`, got, err)
+}
+
+func Test_sys_stdoutAndStderr(t *testing.T) {
+	env, stdout, stderr := importSys(t, &execute.Runtime{}, "")
+
+	got, err := callMethod(t, getSys(t, env, "stdout"), "write", types.NewStr("out\n"))
+	checkCall(t, "stdout.write()", types.NewInt(4), nil, got, err)
+	got, err = callMethod(t, getSys(t, env, "stderr"), "write", types.NewBytes([]byte("err")))
+	checkCall(t, "stderr.write()", types.NewInt(3), nil, got, err)
+	if got, want := stdout.String(), "out\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if got, want := stderr.String(), "err"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+
+	got, err = callMethod(t, getSys(t, env, "stderr"), "read")
+	checkStreamError(t, "stderr.read()", `FileError: file "<stderr>": stream is not readable`, got, err)
+	got, err = callMethod(t, getSys(t, env, "stdout"), "seek", types.NewInt(0))
+	checkStreamError(t, "stdout.seek()", `FileError: file "<stdout>": stream is not seekable`, got, err)
+
+	// Closing a stream stops it being used by the script without closing the runtime's writer.
+	got, err = callMethod(t, getSys(t, env, "stdout"), "close")
+	checkCall(t, "stdout.close()", types.Null, nil, got, err)
+	got, err = callMethod(t, getSys(t, env, "stdout"), "write", types.NewStr("foo"))
+	checkCall(t, "stdout.write()", nil, errors.NewValueError(`file "<stdout>" is closed`), got, err)
+}
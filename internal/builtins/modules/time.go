@@ -0,0 +1,215 @@
+package modules
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// timeModule is the time module. The values returned by monotonic are measured from the time the
+// module was imported.
+type timeModule struct {
+	start time.Time
+}
+
+// zoneArg returns the time zone named by an argument to a time function.
+func zoneArg(v execute.Value) (*time.Location, error) {
+	s, ok := v.(*types.Str)
+	if !ok {
+		return nil, errors.NewTypeError(v.Type(), types.StrType)
+	}
+	return types.LoadZone(s.Value())
+}
+
+func time_now(args ...execute.Value) (execute.Value, error) {
+	if len(args) > 1 {
+		return nil, errors.CallError("time.now", len(args), 1)
+	}
+	// Datetimes are compared by their wall clock readings, so the monotonic clock reading is removed.
+	// Durations should be measured with time.monotonic instead.
+	now := time.Now().Round(0)
+	if len(args) == 1 {
+		loc, err := zoneArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		now = now.In(loc)
+	}
+	return types.NewDatetime(now), nil
+}
+
+func (m *timeModule) monotonic(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 0 {
+		return nil, errors.CallError("time.monotonic", len(args), 0)
+	}
+	return types.NewDuration(time.Since(m.start)), nil
+}
+
+func time_sleep(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("time.sleep", len(args), 1)
+	}
+	if args[0].Type() != types.DurationType && !isNumber(args[0]) {
+		return nil, errors.NewTypeError(args[0].Type(), types.DurationType)
+	}
+	v, err := types.DurationType.New(args[0])
+	if err != nil {
+		return nil, err
+	}
+	d := v.(*types.Duration)
+	if d.Value() < 0 {
+		return nil, errors.NewValueError(fmt.Sprintf("cannot sleep for a negative duration, got %s", d))
+	}
+	time.Sleep(d.Value())
+	return types.Null, nil
+}
+
+// datetimeFields are the names and ranges of the fields that can be passed to time.datetime, in
+// order.
+var datetimeFields = []struct {
+	name     string
+	min, max int64
+}{
+	{"year", 0, 9999},
+	{"month", 1, 12},
+	{"day", 1, 31},
+	{"hour", 0, 23},
+	{"minute", 0, 59},
+	{"second", 0, 59},
+	{"nanosecond", 0, 999_999_999},
+}
+
+func time_datetime(args ...execute.Value) (execute.Value, error) {
+	// The time zone is an optional str after the fields.
+	fields := args
+	if len(args) > 0 {
+		if _, ok := args[len(args)-1].(*types.Str); ok {
+			fields = args[:len(args)-1]
+		}
+	}
+	if len(fields) < 3 || len(fields) > len(datetimeFields) {
+		want := 3
+		if len(fields) > len(datetimeFields) {
+			want = len(datetimeFields) + len(args) - len(fields)
+		}
+		return nil, errors.CallError("time.datetime", len(args), want)
+	}
+	loc := time.UTC
+	if len(fields) < len(args) {
+		var err error
+		if loc, err = zoneArg(args[len(args)-1]); err != nil {
+			return nil, err
+		}
+	}
+	ns := make([]int, len(datetimeFields))
+	for i, f := range fields {
+		n, err := integerArg(f)
+		if err != nil {
+			return nil, err
+		}
+		if field := datetimeFields[i]; !n.IsInt64() || n.Int64() < field.min || n.Int64() > field.max {
+			return nil, errors.NewValueError(fmt.Sprintf("%s %s is out of range", field.name, n))
+		}
+		ns[i] = int(n.Int64())
+	}
+	year, month, day := ns[0], time.Month(ns[1]), ns[2]
+	// time.Date normalizes days past the end of the month into the next month, so they are checked
+	// here. The last day of the month is the day before the first day of the next month.
+	if last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+		return nil, errors.NewValueError(fmt.Sprintf("day %d is out of range for %s %d", day, month, year))
+	}
+	return types.NewDatetime(time.Date(year, month, day, ns[3], ns[4], ns[5], ns[6], loc)), nil
+}
+
+func time_parse(args ...execute.Value) (execute.Value, error) {
+	if len(args) < 2 || len(args) > 3 {
+		want := 2
+		if len(args) > 3 {
+			want = 3
+		}
+		return nil, errors.CallError("time.parse", len(args), want)
+	}
+	var text, layout string
+	for i, a := range args[:2] {
+		s, ok := a.(*types.Str)
+		if !ok {
+			return nil, errors.NewTypeError(a.Type(), types.StrType)
+		}
+		if i == 0 {
+			text = s.Value()
+		} else {
+			layout = s.Value()
+		}
+	}
+	loc := time.UTC
+	if len(args) == 3 {
+		var err error
+		if loc, err = zoneArg(args[2]); err != nil {
+			return nil, err
+		}
+	}
+	t, err := time.ParseInLocation(layout, text, loc)
+	if err != nil {
+		return nil, errors.WrapValueError(text, types.DatetimeType, err)
+	}
+	return types.NewDatetime(t), nil
+}
+
+func time_unix(args ...execute.Value) (execute.Value, error) {
+	if len(args) != 1 {
+		return nil, errors.CallError("time.unix", len(args), 1)
+	}
+	if !isNumber(args[0]) {
+		return nil, errors.NewTypeError(args[0].Type(), types.FloatType)
+	}
+	secs, err := types.ToDecimal(args[0])
+	if err != nil {
+		return nil, errors.ConversionOverflowError(args[0].String(), types.DatetimeType)
+	}
+	whole := secs.Int()
+	if !whole.IsInt64() {
+		return nil, errors.ConversionOverflowError(args[0].String(), types.DatetimeType)
+	}
+	frac := secs.Sub(decimal.FromInt(whole)).Mul(decimal.FromInt(big.NewInt(int64(time.Second))))
+	return types.NewDatetime(time.Unix(whole.Int64(), frac.Round(0, decimal.HalfEven).Int().Int64()).UTC()), nil
+}
+
+var timeFunctions = map[string]types.FuncImpl{
+	"datetime": time_datetime,
+	"now":      time_now,
+	"parse":    time_parse,
+	"sleep":    time_sleep,
+	"unix":     time_unix,
+}
+
+func (m *timeModule) Name() string {
+	return "time"
+}
+
+func (m *timeModule) Import() (*execute.Environment, error) {
+	m.start = time.Now()
+	vs := map[string]execute.Value{
+		"nanosecond":  types.NewDuration(time.Nanosecond),
+		"microsecond": types.NewDuration(time.Microsecond),
+		"millisecond": types.NewDuration(time.Millisecond),
+		"second":      types.NewDuration(time.Second),
+		"minute":      types.NewDuration(time.Minute),
+		"hour":        types.NewDuration(time.Hour),
+		// Layouts for datetime.format and time.parse.
+		"rfc3339":   types.NewStr(time.RFC3339Nano),
+		"rfc1123":   types.NewStr(time.RFC1123),
+		"date_time": types.NewStr(time.DateTime),
+		"date_only": types.NewStr(time.DateOnly),
+		"time_only": types.NewStr(time.TimeOnly),
+		"monotonic": types.NewGoFunc("monotonic", m.monotonic),
+	}
+	for name, impl := range timeFunctions {
+		vs[name] = types.NewGoFunc(name, impl)
+	}
+	return execute.FromMap(vs), nil
+}
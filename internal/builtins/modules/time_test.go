@@ -0,0 +1,144 @@
+package modules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func Test_time_Name(t *testing.T) {
+	m := &timeModule{}
+	if got, want := m.Name(), "time"; got != want {
+		t.Errorf("m.Name() = %q, want %q", got, want)
+	}
+}
+
+func Test_time(t *testing.T) {
+	ny, err := types.LoadZone("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, parseErr := time.Parse(time.DateOnly, "2024-13-01")
+	date := func(year, month, day, hour, min, sec, nsec int, loc *time.Location) execute.Value {
+		return types.NewDatetime(time.Date(year, time.Month(month), day, hour, min, sec, nsec, loc))
+	}
+	tests := []struct {
+		fn string
+		testCase
+	}{
+		{fn: "datetime", testCase: testCase{name: "date", args: nums(2024, 2, 29), want: date(2024, 2, 29, 0, 0, 0, 0, time.UTC)}},
+		{
+			fn: "datetime",
+			testCase: testCase{
+				name: "all_fields",
+				args: append(nums(2024, 7, 4, 12, 30, 15, 500), types.NewStr("America/New_York")),
+				want: date(2024, 7, 4, 12, 30, 15, 500, ny),
+			},
+		},
+		{
+			fn: "datetime",
+			testCase: testCase{
+				name: "zone",
+				args: append(nums(2024, 7, 4, types.NewUint(12)), types.NewStr("America/New_York")),
+				want: date(2024, 7, 4, 12, 0, 0, 0, ny),
+			},
+		},
+		{fn: "datetime", testCase: testCase{name: "month_out_of_range", args: nums(2024, 13, 1), wantErr: errors.NewValueError("month 13 is out of range")}},
+		{fn: "datetime", testCase: testCase{name: "year_out_of_range", args: nums(10000, 1, 1), wantErr: errors.NewValueError("year 10000 is out of range")}},
+		{fn: "datetime", testCase: testCase{name: "day_out_of_range", args: nums(2023, 2, 29), wantErr: errors.NewValueError("day 29 is out of range for February 2023")}},
+		{fn: "datetime", testCase: testCase{name: "hour_out_of_range", args: nums(2023, 2, 1, 24), wantErr: errors.NewValueError("hour 24 is out of range")}},
+		{fn: "datetime", testCase: testCase{name: "float", args: nums(2024, 1.0, 1), wantErr: errors.NewTypeError(types.FloatType, types.IntType)}},
+		{fn: "datetime", testCase: testCase{name: "unknown_zone", args: append(nums(2024, 1, 1), types.NewStr("Nowhere")), wantErr: errors.NewValueError(`unknown time zone "Nowhere"`)}},
+		{fn: "datetime", testCase: testCase{name: "too_few_args", args: nums(2024, 1), wantErr: errors.CallError("time.datetime", 2, 3)}},
+		{fn: "datetime", testCase: testCase{name: "too_many_args", args: nums(1, 2, 3, 4, 5, 6, 7, 8), wantErr: errors.CallError("time.datetime", 8, 7)}},
+		{fn: "parse", testCase: testCase{name: "date", args: strs("2024-02-29", time.DateOnly), want: date(2024, 2, 29, 0, 0, 0, 0, time.UTC)}},
+		{
+			fn: "parse",
+			testCase: testCase{
+				name: "zone",
+				args: strs("2024-02-29 08:15:00", time.DateTime, "America/New_York"),
+				want: date(2024, 2, 29, 8, 15, 0, 0, ny),
+			},
+		},
+		{
+			fn: "parse",
+			testCase: testCase{
+				name: "offset",
+				args: strs("2024-02-29T08:15:00+01:00", time.RFC3339Nano),
+				want: date(2024, 2, 29, 7, 15, 0, 0, time.UTC),
+			},
+		},
+		{fn: "parse", testCase: testCase{name: "invalid", args: strs("2024-13-01", time.DateOnly), wantErr: errors.WrapValueError("2024-13-01", types.DatetimeType, parseErr)}},
+		{fn: "parse", testCase: testCase{name: "int", args: nums(2024, 1), wantErr: errors.NewTypeError(types.IntType, types.StrType)}},
+		{fn: "parse", testCase: testCase{name: "one_arg", args: strs("2024-02-29"), wantErr: errors.CallError("time.parse", 1, 2)}},
+		{fn: "unix", testCase: testCase{name: "int", args: nums(86400), want: date(1970, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		{fn: "unix", testCase: testCase{name: "float", args: nums(-0.5), want: date(1969, 12, 31, 23, 59, 59, 500_000_000, time.UTC)}},
+		{fn: "unix", testCase: testCase{name: "str", args: strs("0"), wantErr: errors.NewTypeError(types.StrType, types.FloatType)}},
+		{fn: "unix", testCase: testCase{name: "too_large", args: nums(1e300), wantErr: errors.ConversionOverflowError("1e+300", types.DatetimeType)}},
+		{fn: "sleep", testCase: testCase{name: "duration", args: []execute.Value{types.NewDuration(time.Millisecond)}, want: types.Null}},
+		{fn: "sleep", testCase: testCase{name: "number", args: nums(0.001), want: types.Null}},
+		{
+			fn: "sleep",
+			testCase: testCase{
+				name:    "negative",
+				args:    nums(-1),
+				wantErr: errors.NewValueError("cannot sleep for a negative duration, got -1s"),
+			},
+		},
+		{fn: "sleep", testCase: testCase{name: "str", args: strs("1s"), wantErr: errors.NewTypeError(types.StrType, types.DurationType)}},
+		{fn: "now", testCase: testCase{name: "unknown_zone", args: strs("Nowhere"), wantErr: errors.NewValueError(`unknown time zone "Nowhere"`)}},
+		{fn: "monotonic", testCase: testCase{name: "args", args: nums(1), wantErr: errors.CallError("time.monotonic", 1, 0)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.fn+"_"+tc.name, makeModuleTestCallback(&timeModule{}, tc.fn, tc.testCase))
+	}
+}
+
+func Test_time_now(t *testing.T) {
+	env, err := (&timeModule{}).Import()
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := func(fn string, args ...execute.Value) execute.Value {
+		t.Helper()
+		f, err := env.Get(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fc, err := f.ToCallable()
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := fc.Call(env, args...)
+		if err != nil {
+			t.Fatalf("time.%s() returned an unexpected error: %v", fn, err)
+		}
+		return v
+	}
+
+	before := time.Now()
+	now := call("now").(*types.Datetime).Value()
+	if now.Before(before.Add(-time.Second)) || now.After(time.Now()) {
+		t.Errorf("time.now() = %v, want a time close to %v", now, before)
+	}
+	if got := call("now", types.NewStr("Asia/Tokyo")).(*types.Datetime).Value().Location().String(); got != "Asia/Tokyo" {
+		t.Errorf("time.now(\"Asia/Tokyo\") returned a datetime in %s", got)
+	}
+
+	first := call("monotonic").(*types.Duration).Value()
+	call("sleep", types.NewDuration(time.Millisecond))
+	if second := call("monotonic").(*types.Duration).Value(); second-first < time.Millisecond {
+		t.Errorf("time.monotonic() advanced by %v after sleeping for 1ms", second-first)
+	}
+}
+
+func Test_time_parseErrorMessage(t *testing.T) {
+	_, err := time_parse(types.NewStr("2024-02-30"), types.NewStr(time.DateOnly))
+	want := `ValueError: error converting "2024-02-30" to type "datetime": parsing time "2024-02-30": day out of range`
+	if err == nil || err.Error() != want {
+		t.Errorf("time.parse() returned an incorrect error: got %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,26 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	slowtesting "github.com/chrispyles/slow/internal/testing"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+func TestBuiltins_modules(t *testing.T) {
+	doBuiltinTest(t, []builtinTest{
+		{
+			name: "builtin_modules",
+			fn:   "modules",
+			want: types.NewList([]execute.Value{types.NewStr("csv"), types.NewStr("fs"), types.NewStr("json"), types.NewStr("math"), types.NewStr("os"), types.NewStr("path"), types.NewStr("random"), types.NewStr("re"), types.NewStr("sys"), types.NewStr("time")}),
+		},
+		{
+			name:    "too_many_args",
+			fn:      "modules",
+			args:    []execute.Value{&slowtesting.MockValue{}},
+			wantErr: errors.CallError("modules", 1, 0),
+		},
+	})
+}
@@ -17,7 +17,7 @@ func TestBuiltins_print(t *testing.T) {
 				types.NewStr("foo"),
 			},
 			want:       types.Null,
-			wantPrints: []string{"foo\n"},
+			wantPrints: "foo\n",
 		},
 		{
 			name: "value",
@@ -26,7 +26,7 @@ func TestBuiltins_print(t *testing.T) {
 				&slowtesting.MockValue{StringRet: "MOCK_VALUE"},
 			},
 			want:       types.Null,
-			wantPrints: []string{"MOCK_VALUE\n"},
+			wantPrints: "MOCK_VALUE\n",
 		},
 		{
 			name: "many",
@@ -37,7 +37,7 @@ func TestBuiltins_print(t *testing.T) {
 				&slowtesting.MockValue{StringRet: "MV3"},
 			},
 			want:       types.Null,
-			wantPrints: []string{"MV1MV2MV3\n"},
+			wantPrints: "MV1MV2MV3\n",
 		},
 	})
 }
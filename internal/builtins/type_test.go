@@ -25,7 +25,7 @@ func TestBuiltins_type(t *testing.T) {
 		{
 			name: "func",
 			fn:   "type",
-			args: []execute.Value{types.NewFunc("", nil, nil)},
+			args: []execute.Value{types.NewFunc("", nil, nil, nil, "")},
 			want: types.NewStr("func"),
 		},
 		{
@@ -0,0 +1,394 @@
+// Package decimal implements exact decimal arithmetic. A Decimal is an arbitrary-precision integer
+// coefficient scaled by a power of ten, so values like 0.1 are represented exactly and addition,
+// subtraction, and multiplication never lose precision. Operations whose results can't always be
+// represented exactly, like division, are rounded according to a Context.
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultPrecision is the number of significant digits that inexact results are rounded to when a
+// Context doesn't specify a precision.
+const DefaultPrecision = 28
+
+// maxScale bounds the exponents of parsed decimals so that they can't require huge amounts of memory
+// to print.
+const maxScale = 1 << 20
+
+// RoundingMode determines how a Decimal is rounded when digits are removed from it. It implements
+// flag.Value.
+type RoundingMode int
+
+const (
+	// HalfEven rounds to the nearest neighbour, or to the even neighbour if both are equidistant.
+	HalfEven RoundingMode = iota
+	// HalfUp rounds to the nearest neighbour, or away from zero if both are equidistant.
+	HalfUp
+	// HalfDown rounds to the nearest neighbour, or towards zero if both are equidistant.
+	HalfDown
+	// Up rounds away from zero.
+	Up
+	// Down rounds towards zero (truncation).
+	Down
+	// Ceiling rounds towards positive infinity.
+	Ceiling
+	// Floor rounds towards negative infinity.
+	Floor
+)
+
+var roundingModeNames = map[RoundingMode]string{
+	HalfEven: "half_even",
+	HalfUp:   "half_up",
+	HalfDown: "half_down",
+	Up:       "up",
+	Down:     "down",
+	Ceiling:  "ceiling",
+	Floor:    "floor",
+}
+
+func (m RoundingMode) String() string {
+	if s, ok := roundingModeNames[m]; ok {
+		return s
+	}
+	return fmt.Sprintf("RoundingMode(%d)", int(m))
+}
+
+func (m *RoundingMode) Set(s string) error {
+	mm, err := ParseRoundingMode(s)
+	if err != nil {
+		return err
+	}
+	*m = mm
+	return nil
+}
+
+// ParseRoundingMode returns the rounding mode with the provided name, e.g. "half_up".
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	for m, name := range roundingModeNames {
+		if name == s {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rounding mode %q", s)
+}
+
+// Context determines how inexact results are rounded. The zero value rounds to DefaultPrecision
+// significant digits using HalfEven.
+type Context struct {
+	// Precision is the number of significant digits that inexact results are rounded to. Values less
+	// than one mean DefaultPrecision.
+	Precision int
+	Rounding  RoundingMode
+}
+
+func (c Context) precision() int {
+	if c.Precision < 1 {
+		return DefaultPrecision
+	}
+	return c.Precision
+}
+
+// Decimal is an exact decimal number with the value coef × 10^-scale. Decimals are immutable.
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+// New returns the decimal coef × 10^-scale.
+func New(coef *big.Int, scale int) Decimal {
+	return Decimal{new(big.Int).Set(coef), scale}
+}
+
+// FromInt returns the decimal with the value of i.
+func FromInt(i *big.Int) Decimal {
+	return New(i, 0)
+}
+
+// FromFloat returns the decimal with the shortest representation that converts back to f, so that
+// e.g. 0.1 becomes exactly 0.1. It returns false if f is infinite or NaN.
+func FromFloat(f float64) (Decimal, bool) {
+	d, err := Parse(strconv.FormatFloat(f, 'e', -1, 64))
+	return d, err == nil
+}
+
+// Parse parses a decimal number in plain or scientific notation, e.g. "-1.25" or "1.25e-3".
+func Parse(s string) (Decimal, error) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > maxScale || e < -maxScale {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		exp = e
+	}
+	sign := ""
+	if strings.HasPrefix(mantissa, "-") || strings.HasPrefix(mantissa, "+") {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	whole, frac, _ := strings.Cut(mantissa, ".")
+	digits := whole + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	coef, _ := new(big.Int).SetString(sign+digits, 10)
+	return Decimal{coef, len(frac) - exp}, nil
+}
+
+// MustParse is like Parse but panics if s is not a valid decimal.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Coefficient returns the integer coefficient of d, which is d × 10^scale.
+func (d Decimal) Coefficient() *big.Int {
+	return new(big.Int).Set(d.coefficient())
+}
+
+// Sign returns -1, 0, or 1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+// Scale returns the number of digits after the decimal point in d's representation. It is negative
+// if d is a whole number represented with an exponent.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Cmp returns -1, 0, or 1 depending on whether d is less than, equal to, or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	x, y := align(d, e)
+	return x.Cmp(y)
+}
+
+// Equal returns whether d and e have the same coefficient and scale, so e.g. 1.5 and 1.50 are not
+// equal. Use Cmp to compare their values.
+func (d Decimal) Equal(e Decimal) bool {
+	return d.scale == e.scale && d.coefficient().Cmp(e.coefficient()) == 0
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	x, y := align(d, e)
+	return Decimal{x.Add(x, y), max(d.scale, e.scale)}
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	x, y := align(d, e)
+	return Decimal{x.Sub(x, y), max(d.scale, e.scale)}
+}
+
+// Mul returns d × e.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{new(big.Int).Mul(d.coefficient(), e.coefficient()), d.scale + e.scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{new(big.Int).Neg(d.coefficient()), d.scale}
+}
+
+// Quo returns d / e rounded to the context's precision. If the quotient is exact, its trailing zeros
+// are removed down to the scale of d minus the scale of e. e must not be zero.
+func (d Decimal) Quo(e Decimal, ctx Context) Decimal {
+	prec := ctx.precision()
+	a, b := d.coefficient(), e.coefficient()
+	// Shift the dividend so that the integer quotient has more digits than the precision, so that it
+	// can be rounded correctly.
+	shift := max(0, prec+numDigits(b)-numDigits(a)+1)
+	q, r := new(big.Int).QuoRem(new(big.Int).Mul(a, pow10(shift)), b, new(big.Int))
+	result := Decimal{q, d.scale - e.scale + shift}
+	exact := r.Sign() == 0
+	if excess := numDigits(q) - prec; excess > 0 {
+		exact = exact && new(big.Int).Rem(q, pow10(excess)).Sign() == 0
+		result = result.round(result.scale-excess, ctx.Rounding, r.Sign() != 0)
+	}
+	if exact {
+		result = result.reduce(max(0, d.scale-e.scale))
+	}
+	return result
+}
+
+// QuoRem returns the quotient of d and e truncated to an integer, and the remainder d - e × q, which
+// has the same sign as d. e must not be zero.
+func (d Decimal) QuoRem(e Decimal) (Decimal, Decimal) {
+	x, y := align(d, e)
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	return Decimal{q, 0}, Decimal{r, max(d.scale, e.scale)}
+}
+
+// Pow returns d raised to the integer power n. Negative powers are computed by division and rounded
+// to the context's precision; d must not be zero if n is negative.
+func (d Decimal) Pow(n int64, ctx Context) Decimal {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	coef := new(big.Int).Exp(d.coefficient(), big.NewInt(abs), nil)
+	p := Decimal{coef, d.scale * int(abs)}
+	if n >= 0 {
+		return p
+	}
+	return Decimal{big.NewInt(1), 0}.Quo(p, ctx)
+}
+
+// Round returns d rounded to the provided number of digits after the decimal point. Negative places
+// round to the left of the decimal point.
+func (d Decimal) Round(places int, mode RoundingMode) Decimal {
+	if places >= d.scale {
+		return Decimal{new(big.Int).Mul(d.coefficient(), pow10(places-d.scale)), places}
+	}
+	return d.round(places, mode, false)
+}
+
+// RoundToPrecision returns d rounded to the context's number of significant digits.
+func (d Decimal) RoundToPrecision(ctx Context) Decimal {
+	if excess := numDigits(d.coefficient()) - ctx.precision(); excess > 0 {
+		return d.round(d.scale-excess, ctx.Rounding, false)
+	}
+	return d
+}
+
+// round returns d rounded to a smaller scale. sticky indicates whether there are nonzero digits
+// beyond those in d, which breaks ties when rounding to the nearest neighbour.
+func (d Decimal) round(scale int, mode RoundingMode, sticky bool) Decimal {
+	q, r := new(big.Int).QuoRem(d.coefficient(), pow10(d.scale-scale), new(big.Int))
+	// The sign of the exact value, which is needed even if the quotient is zero.
+	sign := d.Sign()
+	if r.Sign() == 0 && !sticky {
+		return Decimal{q, scale}
+	}
+	// Compare twice the remainder to the divisor to find which neighbour is closer.
+	half := new(big.Int).Abs(r)
+	cmpHalf := half.Lsh(half, 1).Cmp(pow10(d.scale - scale))
+	if cmpHalf == 0 && sticky {
+		cmpHalf = 1
+	}
+	var away bool
+	switch mode {
+	case HalfEven:
+		away = cmpHalf > 0 || cmpHalf == 0 && q.Bit(0) == 1
+	case HalfUp:
+		away = cmpHalf >= 0
+	case HalfDown:
+		away = cmpHalf > 0
+	case Up:
+		away = true
+	case Down:
+		away = false
+	case Ceiling:
+		away = sign > 0
+	case Floor:
+		away = sign < 0
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return Decimal{q, scale}
+}
+
+// reduce removes trailing zeros from d's coefficient until its scale is minScale.
+func (d Decimal) reduce(minScale int) Decimal {
+	coef, scale := new(big.Int).Set(d.coefficient()), d.scale
+	if coef.Sign() == 0 {
+		return Decimal{coef, min(scale, max(minScale, 0))}
+	}
+	ten, r := big.NewInt(10), new(big.Int)
+	for scale > minScale {
+		q, _ := new(big.Int).QuoRem(coef, ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		coef, scale = q, scale-1
+	}
+	return Decimal{coef, scale}
+}
+
+// Normalize returns d with every trailing zero removed from its coefficient, so that equal decimals
+// have identical representations.
+func (d Decimal) Normalize() Decimal {
+	if d.Sign() == 0 {
+		return Decimal{new(big.Int), 0}
+	}
+	return d.reduce(math.MinInt)
+}
+
+// IsInteger returns whether d has no fractional part.
+func (d Decimal) IsInteger() bool {
+	return d.Normalize().scale <= 0
+}
+
+// Int returns d truncated to an integer.
+func (d Decimal) Int() *big.Int {
+	if d.scale <= 0 {
+		return new(big.Int).Mul(d.coefficient(), pow10(-d.scale))
+	}
+	return new(big.Int).Quo(d.coefficient(), pow10(d.scale))
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(fmt.Sprintf("%se%d", d.coefficient(), -d.scale), 64)
+	return f
+}
+
+// String returns d in plain notation, e.g. "-0.0015" or "1200".
+func (d Decimal) String() string {
+	coef := d.coefficient()
+	if d.scale <= 0 {
+		if coef.Sign() == 0 {
+			return "0"
+		}
+		return coef.String() + strings.Repeat("0", -d.scale)
+	}
+	digits := new(big.Int).Abs(coef).String()
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	s := digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	if coef.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// align returns the coefficients of d and e scaled to the larger of their scales.
+func align(d, e Decimal) (*big.Int, *big.Int) {
+	x, y := new(big.Int).Set(d.coefficient()), new(big.Int).Set(e.coefficient())
+	if d.scale < e.scale {
+		x.Mul(x, pow10(e.scale-d.scale))
+	} else if e.scale < d.scale {
+		y.Mul(y, pow10(d.scale-e.scale))
+	}
+	return x, y
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// numDigits returns the number of decimal digits in the absolute value of i.
+func numDigits(i *big.Int) int {
+	if i.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(i).String())
+}
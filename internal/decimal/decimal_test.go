@@ -0,0 +1,230 @@
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in        string
+		want      string
+		wantScale int
+		wantErr   bool
+	}{
+		{in: "0", want: "0"},
+		{in: "1.25", want: "1.25", wantScale: 2},
+		{in: "-0.0015", want: "-0.0015", wantScale: 4},
+		{in: "+3", want: "3"},
+		{in: ".5", want: "0.5", wantScale: 1},
+		{in: "1.", want: "1"},
+		{in: "1.5e3", want: "1500", wantScale: -2},
+		{in: "1.5E-3", want: "0.0015", wantScale: 4},
+		{in: "100.00", want: "100.00", wantScale: 2},
+		{in: "", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "1e", wantErr: true},
+		{in: "0x10", wantErr: true},
+		{in: "1e9999999", wantErr: true},
+	} {
+		got, err := Parse(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("Parse(%q) returned error %v, want error: %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr {
+			continue
+		}
+		if got.String() != tc.want || got.Scale() != tc.wantScale {
+			t.Errorf("Parse(%q) = %s (scale %d), want %s (scale %d)", tc.in, got, got.Scale(), tc.want, tc.wantScale)
+		}
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	for _, tc := range []struct {
+		in     float64
+		want   string
+		wantOk bool
+	}{
+		{in: 0.1, want: "0.1", wantOk: true},
+		{in: -2.5e-7, want: "-0.00000025", wantOk: true},
+		{in: 1e21, want: "1000000000000000000000", wantOk: true},
+		{in: math.Inf(-1)},
+		{in: math.NaN()},
+	} {
+		got, ok := FromFloat(tc.in)
+		if ok != tc.wantOk || ok && got.String() != tc.want {
+			t.Errorf("FromFloat(%v) = (%s, %v), want (%s, %v)", tc.in, got, ok, tc.want, tc.wantOk)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{name: "add", got: MustParse("0.1").Add(MustParse("0.2")), want: "0.3"},
+		{name: "add_scales", got: MustParse("1.5").Add(MustParse("2.25")), want: "3.75"},
+		{name: "sub", got: MustParse("1").Sub(MustParse("1.01")), want: "-0.01"},
+		{name: "mul", got: MustParse("1.10").Mul(MustParse("3")), want: "3.30"},
+		{name: "neg", got: MustParse("2.5").Neg(), want: "-2.5"},
+		{name: "pow", got: MustParse("1.1").Pow(3, Context{}), want: "1.331"},
+		{name: "pow_negative", got: MustParse("2").Pow(-2, Context{}), want: "0.25"},
+		{name: "pow_zero", got: MustParse("0").Pow(0, Context{}), want: "1"},
+	} {
+		if s := tc.got.String(); s != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, s, tc.want)
+		}
+	}
+}
+
+func TestQuo(t *testing.T) {
+	for _, tc := range []struct {
+		x, y string
+		ctx  Context
+		want string
+	}{
+		{x: "1", y: "4", want: "0.25"},
+		{x: "1.00", y: "4", want: "0.25"},
+		{x: "10.00", y: "2", want: "5.00"},
+		{x: "6", y: "2", want: "3"},
+		{x: "0", y: "3", want: "0"},
+		{x: "1", y: "3", want: "0.3333333333333333333333333333"},
+		{x: "2", y: "3", want: "0.6666666666666666666666666667"},
+		{x: "-2", y: "3", ctx: Context{Precision: 5}, want: "-0.66667"},
+		{x: "2", y: "3", ctx: Context{Precision: 5, Rounding: Down}, want: "0.66666"},
+		{x: "1", y: "8", ctx: Context{Precision: 2}, want: "0.12"},
+		{x: "1", y: "8", ctx: Context{Precision: 2, Rounding: HalfUp}, want: "0.13"},
+		{x: "123456", y: "1", ctx: Context{Precision: 3}, want: "123000"},
+		{x: "100", y: "0.01", want: "10000"},
+	} {
+		got := MustParse(tc.x).Quo(MustParse(tc.y), tc.ctx)
+		if got.String() != tc.want {
+			t.Errorf("%s.Quo(%s, %+v) = %s, want %s", tc.x, tc.y, tc.ctx, got, tc.want)
+		}
+	}
+}
+
+func TestQuoRem(t *testing.T) {
+	for _, tc := range []struct {
+		x, y    string
+		wantQ   string
+		wantRem string
+	}{
+		{x: "7.5", y: "2", wantQ: "3", wantRem: "1.5"},
+		{x: "-7.5", y: "2", wantQ: "-3", wantRem: "-1.5"},
+		{x: "1", y: "0.3", wantQ: "3", wantRem: "0.1"},
+	} {
+		q, r := MustParse(tc.x).QuoRem(MustParse(tc.y))
+		if q.String() != tc.wantQ || r.String() != tc.wantRem {
+			t.Errorf("%s.QuoRem(%s) = (%s, %s), want (%s, %s)", tc.x, tc.y, q, r, tc.wantQ, tc.wantRem)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		places int
+		mode   RoundingMode
+		want   string
+	}{
+		{in: "2.345", places: 2, mode: HalfEven, want: "2.34"},
+		{in: "2.355", places: 2, mode: HalfEven, want: "2.36"},
+		{in: "2.345", places: 2, mode: HalfUp, want: "2.35"},
+		{in: "2.345", places: 2, mode: HalfDown, want: "2.34"},
+		{in: "2.3451", places: 2, mode: HalfDown, want: "2.35"},
+		{in: "2.341", places: 2, mode: Up, want: "2.35"},
+		{in: "-2.341", places: 2, mode: Up, want: "-2.35"},
+		{in: "2.349", places: 2, mode: Down, want: "2.34"},
+		{in: "-2.341", places: 2, mode: Ceiling, want: "-2.34"},
+		{in: "-2.341", places: 2, mode: Floor, want: "-2.35"},
+		{in: "0.001", places: 0, mode: Ceiling, want: "1"},
+		{in: "-0.5", places: 0, mode: HalfEven, want: "0"},
+		{in: "1.5", places: 3, mode: HalfEven, want: "1.500"},
+		{in: "1250", places: -2, mode: HalfEven, want: "1200"},
+	} {
+		if got := MustParse(tc.in).Round(tc.places, tc.mode); got.String() != tc.want {
+			t.Errorf("%s.Round(%d, %v) = %s, want %s", tc.in, tc.places, tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestRoundToPrecision(t *testing.T) {
+	got := MustParse("123.456").RoundToPrecision(Context{Precision: 4})
+	if want := "123.5"; got.String() != want {
+		t.Errorf("RoundToPrecision() = %s, want %s", got, want)
+	}
+}
+
+func TestCmp(t *testing.T) {
+	for _, tc := range []struct {
+		x, y string
+		want int
+	}{
+		{x: "1.0", y: "1", want: 0},
+		{x: "0.1", y: "0.09", want: 1},
+		{x: "-1", y: "1e-9", want: -1},
+		{x: "1e3", y: "1000.000", want: 0},
+	} {
+		if got := MustParse(tc.x).Cmp(MustParse(tc.y)); got != tc.want {
+			t.Errorf("%s.Cmp(%s) = %d, want %d", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, tc := range []struct {
+		in        string
+		wantCoef  int64
+		wantScale int
+	}{
+		{in: "1.500", wantCoef: 15, wantScale: 1},
+		{in: "1500", wantCoef: 15, wantScale: -2},
+		{in: "0.000", wantCoef: 0, wantScale: 0},
+	} {
+		got := MustParse(tc.in).Normalize()
+		if got.coef.Cmp(big.NewInt(tc.wantCoef)) != 0 || got.scale != tc.wantScale {
+			t.Errorf("%s.Normalize() = (%s, %d), want (%d, %d)", tc.in, got.coef, got.scale, tc.wantCoef, tc.wantScale)
+		}
+	}
+}
+
+func TestConversions(t *testing.T) {
+	d := MustParse("-12.75")
+	if got, want := d.Int(), big.NewInt(-12); got.Cmp(want) != 0 {
+		t.Errorf("Int() = %s, want %s", got, want)
+	}
+	if got, want := MustParse("1.2e3").Int(), big.NewInt(1200); got.Cmp(want) != 0 {
+		t.Errorf("Int() = %s, want %s", got, want)
+	}
+	if got, want := d.Float64(), -12.75; got != want {
+		t.Errorf("Float64() = %v, want %v", got, want)
+	}
+	if d.IsInteger() || !MustParse("3.000").IsInteger() {
+		t.Errorf("IsInteger() returned incorrect results")
+	}
+	if got := (Decimal{}).String(); got != "0" {
+		t.Errorf("zero Decimal String() = %q, want %q", got, "0")
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for m, name := range roundingModeNames {
+		got, err := ParseRoundingMode(name)
+		if err != nil || got != m {
+			t.Errorf("ParseRoundingMode(%q) = (%v, %v), want (%v, nil)", name, got, err, m)
+		}
+		if m.String() != name {
+			t.Errorf("%d.String() = %q, want %q", int(m), m.String(), name)
+		}
+	}
+	if _, err := ParseRoundingMode("nearest"); err == nil {
+		t.Errorf("ParseRoundingMode(%q) did not return an error", "nearest")
+	}
+}
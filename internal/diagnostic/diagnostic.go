@@ -0,0 +1,235 @@
+// Package diagnostic formats errors for display, with the source code at their location and hints for
+// fixing them.
+package diagnostic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/source"
+)
+
+var readFile = os.ReadFile
+
+// Format is a format in which diagnostics can be written. It implements flag.Value.
+type Format int
+
+const (
+	// Text formats diagnostics for people, with the source line at the error's location and a caret
+	// under it.
+	Text Format = iota
+	// JSON formats each diagnostic as a single-line JSON object for tools.
+	JSON
+)
+
+var formatNames = map[Format]string{
+	Text: "text",
+	JSON: "json",
+}
+
+func (f Format) String() string {
+	return formatNames[f]
+}
+
+func (f *Format) Set(s string) error {
+	for ff, n := range formatNames {
+		if n == s {
+			*f = ff
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown error format %q", s)
+}
+
+// Options configure how diagnostics are written.
+type Options struct {
+	Format Format
+	// Color indicates whether text diagnostics are coloured using ANSI escape codes.
+	Color bool
+}
+
+// Diagnostic describes an error.
+type Diagnostic struct {
+	Type     string    `json:"type"`
+	Message  string    `json:"message"`
+	Location *Location `json:"location,omitempty"`
+	// Source is the line of source code containing the start of the error's location.
+	Source    string  `json:"source,omitempty"`
+	Hint      string  `json:"hint,omitempty"`
+	Traceback []Frame `json:"traceback,omitempty"`
+
+	err error
+}
+
+// Location is a range of source code. Lines and columns are 1-indexed and the end is exclusive.
+type Location struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+}
+
+func newLocation(s source.Span) *Location {
+	if !s.IsValid() {
+		return nil
+	}
+	return &Location{
+		File:      s.FileName(),
+		Line:      s.Start.Line,
+		Column:    s.Start.Column,
+		EndLine:   s.End.Line,
+		EndColumn: s.End.Column,
+	}
+}
+
+// Frame is a stack frame that an error propagated out of.
+type Frame struct {
+	Func     string    `json:"func"`
+	Location *Location `json:"location,omitempty"`
+}
+
+// New returns a diagnostic describing err. input is the code that was being evaluated when the error
+// occurred; it is used as the source of locations that aren't in a file, and other sources are read
+// from disk.
+func New(err error, input string) *Diagnostic {
+	se, ok := err.(*errors.SlowError)
+	if !ok {
+		return &Diagnostic{Type: "Error", Message: err.Error(), err: err}
+	}
+	d := &Diagnostic{
+		Type:     se.Type(),
+		Message:  se.Message(),
+		Location: newLocation(se.Span()),
+		Hint:     se.Hint(),
+		err:      err,
+	}
+	for _, f := range se.Frames() {
+		d.Traceback = append(d.Traceback, Frame{Func: f.Func, Location: newLocation(f.Span)})
+	}
+	if span := se.Span(); span.IsValid() {
+		d.Source = sourceLine(span, input)
+	}
+	return d
+}
+
+// sourceLine returns the line of code containing the start of the provided span, or an empty string
+// if the source is not available.
+func sourceLine(span source.Span, input string) string {
+	src := input
+	if span.File != "" {
+		b, err := readFile(span.File)
+		if err != nil {
+			return ""
+		}
+		src = string(b)
+	}
+	lines := strings.Split(src, "\n")
+	if span.Start.Line > len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[span.Start.Line-1], "\r")
+}
+
+// Write writes a diagnostic describing err to w. See New for a description of input. If err is an
+// errors.ErrorList, a diagnostic is written for each error in it; JSON diagnostics are written one per
+// line.
+func Write(w io.Writer, err error, input string, opts Options) error {
+	for _, e := range errors.Errors(err) {
+		if werr := write(w, New(e, input), opts); werr != nil {
+			return werr
+		}
+	}
+	return nil
+}
+
+func write(w io.Writer, d *Diagnostic, opts Options) error {
+	if opts.Format == JSON {
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return enc.Encode(d)
+	}
+	_, err := io.WriteString(w, d.text(opts.Color))
+	return err
+}
+
+// ANSI escape codes used to colour text diagnostics.
+const (
+	colorReset = "\x1b[0m"
+	colorError = "\x1b[1;31m"
+	colorInfo  = "\x1b[1;34m"
+	colorHint  = "\x1b[1;36m"
+)
+
+// text formats the diagnostic for people: the error's traceback and message, followed by the line of
+// code where the error occurred with a caret under its location, and then any hint.
+func (d *Diagnostic) text(color bool) string {
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + colorReset
+	}
+
+	var sb strings.Builder
+	msg := d.err.Error()
+	if se, ok := d.err.(*errors.SlowError); ok {
+		msg = se.Traceback()
+	}
+	// The message is the last line of the traceback.
+	if i := strings.LastIndex(msg, "\n"); i >= 0 {
+		sb.WriteString(msg[:i+1])
+		msg = msg[i+1:]
+	}
+	sb.WriteString(paint(colorError, msg))
+	sb.WriteString("\n")
+
+	gutter := ""
+	if l := d.Location; l != nil && d.Source != "" {
+		ln := fmt.Sprint(l.Line)
+		gutter = strings.Repeat(" ", len(ln)+1)
+		fmt.Fprintf(&sb, "%s%s %s:%d:%d\n", gutter[1:], paint(colorInfo, "-->"), l.File, l.Line, l.Column)
+		fmt.Fprintf(&sb, "%s%s\n", gutter, paint(colorInfo, "|"))
+		fmt.Fprintf(&sb, "%s %s %s\n", paint(colorInfo, ln), paint(colorInfo, "|"), d.Source)
+		fmt.Fprintf(&sb, "%s%s %s%s\n", gutter, paint(colorInfo, "|"), padding(d.Source, l.Column), paint(colorError, carets(d.Source, l)))
+	}
+	if d.Hint != "" {
+		if gutter == "" {
+			gutter = " "
+		}
+		fmt.Fprintf(&sb, "%s%s %s\n", gutter, paint(colorHint, "= hint:"), d.Hint)
+	}
+	return sb.String()
+}
+
+// padding returns the whitespace that aligns text printed under a line of code with the provided
+// column, preserving tabs so that it lines up however they are displayed.
+func padding(line string, column int) string {
+	var sb strings.Builder
+	for i, r := range []rune(line) {
+		if i >= column-1 {
+			break
+		}
+		if r == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	return sb.String()
+}
+
+// carets returns the carets that underline a location in a line of code. Locations that span
+// multiple lines are underlined to the end of the first line.
+func carets(line string, l *Location) string {
+	end := l.EndColumn
+	if l.EndLine != l.Line {
+		end = utf8.RuneCountInString(line) + 1
+	}
+	return strings.Repeat("^", max(end-l.Column, 1))
+}
@@ -0,0 +1,183 @@
+package diagnostic
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/source"
+	slowtesting "github.com/chrispyles/slow/internal/testing"
+	"github.com/google/go-cmp/cmp"
+)
+
+func span(file string, line, col, endLine, endCol int) source.Span {
+	return source.Span{
+		File:  file,
+		Start: source.Pos{Line: line, Column: col},
+		End:   source.Pos{Line: endLine, Column: endCol},
+	}
+}
+
+// nameError returns a NameError that occurred in a function f called from the top level of main.slo.
+func nameError() error {
+	err := errors.NewNameError("lenght")
+	errors.WithHint(err, `did you mean "length"?`)
+	errors.Locate(err, span("main.slo", 3, 8, 3, 14))
+	errors.AddFrame(err, "f")
+	errors.Locate(err, span("main.slo", 5, 1, 5, 4))
+	errors.AddFrame(err, "<module>")
+	return err
+}
+
+func TestWrite(t *testing.T) {
+	origReadFile := readFile
+	t.Cleanup(func() { readFile = origReadFile })
+	readFile = func(name string) ([]byte, error) {
+		if name != "main.slo" {
+			return nil, os.ErrNotExist
+		}
+		return []byte("var length = 3\nfunc f() {\n\tprint(lenght + 1)\n}\nf()\n"), nil
+	}
+
+	syntaxErr := errors.WithHint(
+		errors.NewSyntaxError(&slowtesting.MockBuffer{LineNumberRet: 2, CurrentSpanRet: span("", 2, 10, 2, 11)}, "expression is not assignable", ""),
+		"did you mean `==`?")
+
+	tests := []struct {
+		name  string
+		err   error
+		input string
+		opts  Options
+		want  string
+	}{
+		{
+			name: "text",
+			err:  nameError(),
+			want: `Traceback (most recent call last):
+  File "main.slo", line 5, column 1, in <module>
+  File "main.slo", line 3, column 8, in f
+NameError: no variable "lenght" has been declared
+ --> main.slo:3:8
+  |
+3 | 	print(lenght + 1)
+  | 	      ^^^^^^
+  = hint: did you mean "length"?
+`,
+		},
+		{
+			name: "color",
+			err:  nameError(),
+			opts: Options{Color: true},
+			want: `Traceback (most recent call last):
+  File "main.slo", line 5, column 1, in <module>
+  File "main.slo", line 3, column 8, in f
+` + "\x1b[1;31m" + `NameError: no variable "lenght" has been declared` + "\x1b[0m" + `
+ ` + "\x1b[1;34m-->\x1b[0m" + ` main.slo:3:8
+  ` + "\x1b[1;34m|\x1b[0m" + `
+` + "\x1b[1;34m3\x1b[0m \x1b[1;34m|\x1b[0m" + ` 	print(lenght + 1)
+  ` + "\x1b[1;34m|\x1b[0m" + ` 	      ` + "\x1b[1;31m^^^^^^\x1b[0m" + `
+  ` + "\x1b[1;36m= hint:\x1b[0m" + ` did you mean "length"?
+`,
+		},
+		{
+			name:  "input",
+			err:   syntaxErr,
+			input: "var x = 1\nif x + 1 = 2 {\n}",
+			want: "SyntaxError: expression is not assignable on line 2\n" +
+				" --> <input>:2:10\n" +
+				"  |\n" +
+				"2 | if x + 1 = 2 {\n" +
+				"  |          ^\n" +
+				"  = hint: did you mean `==`?\n",
+		},
+		{
+			name: "multiline_span",
+			err:  errors.Locate(errors.NewRuntimeError("oops"), span("", 1, 5, 3, 2)),
+			input: "var x = [\n" +
+				"  1,\n" +
+				"]",
+			want: "RuntimeError: oops\n" +
+				" --> <input>:1:5\n" +
+				"  |\n" +
+				"1 | var x = [\n" +
+				"  |     ^^^^^\n",
+		},
+		{
+			name: "no_source",
+			err:  errors.WithHint(errors.Locate(errors.NewNameError("x"), span("missing.slo", 1, 1, 1, 2)), "hint"),
+			want: "NameError: no variable \"x\" has been declared\n" +
+				" = hint: hint\n",
+		},
+		{
+			name: "not_slow_error",
+			err:  os.ErrNotExist,
+			want: "file does not exist\n",
+		},
+		{
+			name: "error_list",
+			err: errors.ErrorList{
+				errors.SyntaxErrorAt(span("", 1, 9, 1, 10), "unexpected symbol", ")"),
+				errors.SyntaxErrorAt(span("", 2, 1, 2, 2), "unexpected symbol", "}"),
+			},
+			input: "var x = )\n}",
+			want: "SyntaxError: unexpected symbol on line 1: \")\"\n" +
+				" --> <input>:1:9\n" +
+				"  |\n" +
+				"1 | var x = )\n" +
+				"  |         ^\n" +
+				"SyntaxError: unexpected symbol on line 2: \"}\"\n" +
+				" --> <input>:2:1\n" +
+				"  |\n" +
+				"2 | }\n" +
+				"  | ^\n",
+		},
+		{
+			name: "json_error_list",
+			err:  errors.ErrorList{errors.NewNameError("x"), os.ErrNotExist},
+			opts: Options{Format: JSON},
+			want: `{"type":"NameError","message":"no variable \"x\" has been declared"}` + "\n" +
+				`{"type":"Error","message":"file does not exist"}` + "\n",
+		},
+		{
+			name: "json",
+			err:  nameError(),
+			opts: Options{Format: JSON},
+			want: `{"type":"NameError","message":"no variable \"lenght\" has been declared",` +
+				`"location":{"file":"main.slo","line":3,"column":8,"endLine":3,"endColumn":14},` +
+				`"source":"\tprint(lenght + 1)","hint":"did you mean \"length\"?","traceback":[` +
+				`{"func":"<module>","location":{"file":"main.slo","line":5,"column":1,"endLine":5,"endColumn":4}},` +
+				`{"func":"f","location":{"file":"main.slo","line":3,"column":8,"endLine":3,"endColumn":14}}]}` + "\n",
+		},
+		{
+			name: "json_not_slow_error",
+			err:  os.ErrNotExist,
+			opts: Options{Format: JSON},
+			want: `{"type":"Error","message":"file does not exist"}` + "\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := Write(&sb, tc.err, tc.input, tc.opts); err != nil {
+				t.Fatalf("Write() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, sb.String()); diff != "" {
+				t.Errorf("Write() wrote unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var f Format
+	if err := f.Set("json"); err != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", err)
+	}
+	if f != JSON || f.String() != "json" {
+		t.Errorf("Set(%q) set the format to %v", "json", f)
+	}
+	if err := f.Set("xml"); err == nil {
+		t.Errorf("Set(%q) did not return an error", "xml")
+	}
+}
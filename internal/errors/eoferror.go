@@ -5,5 +5,5 @@ import (
 )
 
 func NewEOFError(buf Buffer) error {
-	return newError("EOFError", fmt.Sprintf("ran out of input on line %d", buf.LineNumber()))
+	return atBuffer(newError("EOFError", fmt.Sprintf("ran out of input on line %d", buf.LineNumber())), buf)
 }
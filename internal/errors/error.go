@@ -1,24 +1,143 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chrispyles/slow/internal/source"
+)
 
 type SlowError struct {
 	errType string
 	msg     string
 	wrapped error
+	// span is the location of the code that was executing when the error occurred in the innermost
+	// stack frame that the error has not yet propagated out of.
+	span source.Span
+	// frames are the stack frames that the error has propagated out of, outermost first.
+	frames []Frame
+	// hint is a suggestion for how to fix the error, if any.
+	hint string
+}
+
+// maxRepeatedFrames is the number of identical consecutive frames that are shown in a traceback.
+const maxRepeatedFrames = 3
+
+// Frame is a single frame in the traceback of an error.
+type Frame struct {
+	// Func is the name of the function that was executing, or "<module>" for the top level of a
+	// file.
+	Func string
+	// Span is the location of the code that was executing in the frame.
+	Span source.Span
+}
+
+func (f Frame) String() string {
+	if !f.Span.IsValid() {
+		return fmt.Sprintf("File %q, in %s", f.Span.FileName(), f.Func)
+	}
+	return fmt.Sprintf(
+		"File %q, line %d, column %d, in %s", f.Span.FileName(), f.Span.Start.Line, f.Span.Start.Column, f.Func)
 }
 
 func newError(t, m string) *SlowError {
-	return &SlowError{t, m, nil}
+	return &SlowError{errType: t, msg: m}
 }
 
 func wrapError(t string, m string, err error) error {
 	if err == nil {
 		return nil
 	}
-	return &SlowError{t, fmt.Sprintf("%s: %+v", m, err), err}
+	return &SlowError{errType: t, msg: fmt.Sprintf("%s: %+v", m, err), wrapped: err}
 }
 
 func (e *SlowError) Error() string {
 	return fmt.Sprintf("%s: %s", e.errType, e.msg)
 }
+
+// Type returns the type of the error, e.g. "NameError".
+func (e *SlowError) Type() string {
+	return e.errType
+}
+
+// Message returns the error message without its type.
+func (e *SlowError) Message() string {
+	return e.msg
+}
+
+// Hint returns a suggestion for how to fix the error, or an empty string if there is none.
+func (e *SlowError) Hint() string {
+	return e.hint
+}
+
+// Span returns the location at which the error occurred, if it is known.
+func (e *SlowError) Span() source.Span {
+	if len(e.frames) > 0 {
+		return e.frames[len(e.frames)-1].Span
+	}
+	return e.span
+}
+
+// Frames returns the stack frames that the error has propagated out of, outermost first.
+func (e *SlowError) Frames() []Frame {
+	return e.frames
+}
+
+// Traceback returns the error message preceded by the stack frames that the error propagated out of,
+// with the most recent call last.
+func (e *SlowError) Traceback() string {
+	if len(e.frames) == 0 {
+		return e.Error()
+	}
+	var sb strings.Builder
+	sb.WriteString("Traceback (most recent call last):\n")
+	for i := 0; i < len(e.frames); {
+		// Runs of identical frames (e.g. from infinite recursion) are collapsed after a few lines.
+		n := 1
+		for i+n < len(e.frames) && e.frames[i+n] == e.frames[i] {
+			n++
+		}
+		for j := 0; j < min(n, maxRepeatedFrames); j++ {
+			fmt.Fprintf(&sb, "  %s\n", e.frames[i])
+		}
+		if n > maxRepeatedFrames {
+			fmt.Fprintf(&sb, "  [Previous line repeated %d more times]\n", n-maxRepeatedFrames)
+		}
+		i += n
+	}
+	sb.WriteString(e.Error())
+	return sb.String()
+}
+
+// Format implements fmt.Formatter. The %+v verb formats the error with its traceback; all other verbs
+// format the error message.
+func (e *SlowError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprint(f, e.Traceback())
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		fmt.Fprint(f, e.Error())
+	}
+}
+
+// Locate records that err occurred while executing the code at the provided span. It has no effect if
+// err is not a Slow error or if its location in the current stack frame is already known, so that the
+// innermost expression that produced an error determines its location. The error is returned.
+func Locate(err error, span source.Span) error {
+	if e, ok := err.(*SlowError); ok && !e.span.IsValid() && span.IsValid() {
+		e.span = span
+	}
+	return err
+}
+
+// AddFrame records that err propagated out of a stack frame executing the function with the provided
+// name. It has no effect if err is not a Slow error. The error is returned.
+func AddFrame(err error, fn string) error {
+	if e, ok := err.(*SlowError); ok {
+		e.frames = append([]Frame{{Func: fn, Span: e.span}}, e.frames...)
+		e.span = source.Span{}
+	}
+	return err
+}
@@ -2,7 +2,11 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/chrispyles/slow/internal/source"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestSlowError(t *testing.T) {
@@ -28,3 +32,71 @@ func TestWrapNilError(t *testing.T) {
 		t.Errorf("wrapError() returned non-nil error: %v", got)
 	}
 }
+
+func span(file string, line, col int) source.Span {
+	start := source.Pos{Line: line, Column: col}
+	return source.Span{File: file, Start: start, End: start}
+}
+
+func TestLocateAndAddFrame(t *testing.T) {
+	e := newError("FooError", "a message")
+
+	Locate(e, span("b.slo", 2, 3))
+	// The innermost location is kept.
+	Locate(e, span("b.slo", 2, 1))
+	AddFrame(e, "f")
+	// The location is not updated with an invalid span.
+	Locate(e, source.Span{})
+	Locate(e, span("a.slo", 5, 1))
+	AddFrame(e, "<module>")
+
+	want := []Frame{
+		{Func: "<module>", Span: span("a.slo", 5, 1)},
+		{Func: "f", Span: span("b.slo", 2, 3)},
+	}
+	if diff := cmp.Diff(want, e.Frames()); diff != "" {
+		t.Errorf("Frames() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(span("b.slo", 2, 3), e.Span()); diff != "" {
+		t.Errorf("Span() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// Errors that aren't Slow errors are returned unchanged.
+	err := errors.New("foobar")
+	if got := AddFrame(Locate(err, span("a.slo", 1, 1)), "f"); got != err {
+		t.Errorf("AddFrame(Locate()) returned %v, want %v", got, err)
+	}
+}
+
+func TestTraceback(t *testing.T) {
+	e := newError("FooError", "a message")
+	if got, want := e.Traceback(), "FooError: a message"; got != want {
+		t.Errorf("Traceback() without frames returned %q, want %q", got, want)
+	}
+
+	Locate(e, span("", 1, 4))
+	for range 5 {
+		AddFrame(e, "f")
+		Locate(e, span("", 1, 4))
+	}
+	AddFrame(e, "g")
+	AddFrame(e, "<module>")
+
+	want := `Traceback (most recent call last):
+  File "<input>", in <module>
+  File "<input>", line 1, column 4, in g
+  File "<input>", line 1, column 4, in f
+  File "<input>", line 1, column 4, in f
+  File "<input>", line 1, column 4, in f
+  [Previous line repeated 2 more times]
+FooError: a message`
+	if got := e.Traceback(); got != want {
+		t.Errorf("Traceback() returned:\n%s\nwant:\n%s", got, want)
+	}
+	if got := fmt.Sprintf("%+v", e); got != want {
+		t.Errorf("Sprintf(%%+v) returned:\n%s\nwant:\n%s", got, want)
+	}
+	if got, want := fmt.Sprintf("%v", e), "FooError: a message"; got != want {
+		t.Errorf("Sprintf(%%v) returned %q, want %q", got, want)
+	}
+}
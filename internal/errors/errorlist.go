@@ -0,0 +1,51 @@
+package errors
+
+import "strings"
+
+// ErrorList is a list of errors that were all found at once, such as every syntax error in a file.
+type ErrorList []error
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, err := range l {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors in the list.
+func (l ErrorList) Unwrap() []error {
+	return l
+}
+
+// Join returns an error containing the provided errors. nil errors are discarded and nested lists are
+// flattened. If there are no errors, nil is returned, and if there is only one, it is returned as-is.
+func Join(errs ...error) error {
+	var l ErrorList
+	for _, err := range errs {
+		if nested, ok := err.(ErrorList); ok {
+			l = append(l, nested...)
+		} else if err != nil {
+			l = append(l, err)
+		}
+	}
+	switch len(l) {
+	case 0:
+		return nil
+	case 1:
+		return l[0]
+	default:
+		return l
+	}
+}
+
+// Errors returns the errors contained in err: the contents of an ErrorList, or err itself.
+func Errors(err error) []error {
+	if l, ok := err.(ErrorList); ok {
+		return l
+	}
+	if err == nil {
+		return nil
+	}
+	return []error{err}
+}
@@ -0,0 +1,67 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJoin(t *testing.T) {
+	e1 := errors.NewNameError("a")
+	e2 := errors.NewNameError("b")
+	e3 := errors.NewNameError("c")
+	tests := []struct {
+		name string
+		errs []error
+		want error
+	}{
+		{
+			name: "none",
+			want: nil,
+		},
+		{
+			name: "only_nil",
+			errs: []error{nil, nil},
+			want: nil,
+		},
+		{
+			name: "one",
+			errs: []error{nil, e1},
+			want: e1,
+		},
+		{
+			name: "many",
+			errs: []error{e1, nil, e2},
+			want: errors.ErrorList{e1, e2},
+		},
+		{
+			name: "nested",
+			errs: []error{errors.ErrorList{e1, e2}, e3},
+			want: errors.ErrorList{e1, e2, e3},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := errors.Join(tc.errs...)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+				t.Errorf("Join() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestErrorList(t *testing.T) {
+	l := errors.ErrorList{errors.NewNameError("a"), errors.NewNameError("b")}
+
+	want := "NameError: no variable \"a\" has been declared\nNameError: no variable \"b\" has been declared"
+	if got := l.Error(); got != want {
+		t.Errorf("Error() returned incorrect value: got %q, want %q", got, want)
+	}
+	if got := len(errors.Errors(l)); got != 2 {
+		t.Errorf("Errors() returned %d errors, want 2", got)
+	}
+	if got := errors.Errors(nil); got != nil {
+		t.Errorf("Errors(nil) returned %v, want nil", got)
+	}
+}
@@ -0,0 +1,60 @@
+package errors
+
+import "fmt"
+
+// WithHint attaches a suggestion for how to fix err to it. It has no effect if err is not a Slow error
+// or if it already has a hint. The error is returned.
+func WithHint(err error, hint string) error {
+	if e, ok := err.(*SlowError); ok && e.hint == "" {
+		e.hint = hint
+	}
+	return err
+}
+
+// SuggestName attaches a hint to err suggesting the candidate that is most similar to name, if err is
+// a NameError and any candidate is similar enough to be a likely misspelling. The error is returned.
+func SuggestName(err error, name string, candidates []string) error {
+	if e, ok := err.(*SlowError); !ok || e.errType != "NameError" {
+		return err
+	}
+	var best string
+	// A candidate is only suggested if at most a third of the name would need to be edited.
+	bestDist := len([]rune(name))/3 + 1
+	for _, c := range candidates {
+		if d := editDistance(name, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if best == "" {
+		return err
+	}
+	return WithHint(err, fmt.Sprintf("did you mean %q?", best))
+}
+
+// editDistance returns the number of insertions, deletions, substitutions, and transpositions of
+// adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// d[i][j] is the distance between the first i runes of a and the first j runes of b.
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
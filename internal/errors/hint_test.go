@@ -0,0 +1,40 @@
+package errors
+
+import "testing"
+
+func TestSuggestName(t *testing.T) {
+	candidates := []string{"length", "print", "x", "range"}
+	tests := []struct {
+		name     string
+		in       string
+		err      error
+		wantHint string
+	}{
+		{name: "transposition", in: "lenght", wantHint: `did you mean "length"?`},
+		{name: "deletion", in: "prnt", wantHint: `did you mean "print"?`},
+		{name: "substitution", in: "rangg", wantHint: `did you mean "range"?`},
+		{name: "too_short", in: "y"},
+		{name: "too_different", in: "foo"},
+		{name: "not_name_error", in: "lenght", err: NewValueError(`variable "lenght" is uninitialized`)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err
+			if err == nil {
+				err = NewNameError(tc.in)
+			}
+			got := SuggestName(err, tc.in, candidates).(*SlowError).Hint()
+			if got != tc.wantHint {
+				t.Errorf("SuggestName() set hint %q, want %q", got, tc.wantHint)
+			}
+		})
+	}
+}
+
+func TestWithHint(t *testing.T) {
+	err := WithHint(NewNameError("x"), "first")
+	WithHint(err, "second")
+	if got, want := err.(*SlowError).Hint(), "first"; got != want {
+		t.Errorf("Hint() = %q, want %q", got, want)
+	}
+}
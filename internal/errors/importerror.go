@@ -1,7 +1,14 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 func NewImportError(name string) error {
 	return newError("ImportError", fmt.Sprintf("no such module %q", name))
 }
+
+func CyclicImportError(chain []string) error {
+	return newError("ImportError", fmt.Sprintf("cyclic import: %s", strings.Join(chain, " -> ")))
+}
@@ -13,3 +13,11 @@ func TestNewImportError(t *testing.T) {
 		t.Errorf("Error() = %q, want %q", got, want)
 	}
 }
+
+func TestCyclicImportError(t *testing.T) {
+	e := errors.CyclicImportError([]string{"a.slo", "b.slo", "a.slo"})
+	want := "ImportError: cyclic import: a.slo -> b.slo -> a.slo"
+	if got := e.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
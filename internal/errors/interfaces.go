@@ -1,7 +1,10 @@
 package errors
 
+import "github.com/chrispyles/slow/internal/source"
+
 type Buffer interface {
 	LineNumber() int
+	CurrentSpan() source.Span
 }
 
 type Type interface {
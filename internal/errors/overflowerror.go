@@ -0,0 +1,15 @@
+package errors
+
+import "fmt"
+
+// NewOverflowError returns an error for an arithmetic operation whose result does not fit in its
+// type.
+func NewOverflowError(t Type, op string) error {
+	return newError("OverflowError", fmt.Sprintf("result of operator %q does not fit in type %q", op, t.String()))
+}
+
+// ConversionOverflowError returns an error for a value that is out of range for the type it is being
+// converted to.
+func ConversionOverflowError(val string, t Type) error {
+	return newError("OverflowError", fmt.Sprintf("%s is out of range for type %q", val, t.String()))
+}
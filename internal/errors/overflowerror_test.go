@@ -0,0 +1,26 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	slowtesting "github.com/chrispyles/slow/internal/testing"
+)
+
+func TestNewOverflowError(t *testing.T) {
+	e := errors.NewOverflowError(&slowtesting.MockType{StringRet: "int"}, "+")
+
+	got, want := e.Error(), `OverflowError: result of operator "+" does not fit in type "int"`
+	if got != want {
+		t.Errorf("Error() returned incorrect value: got %q, want %q", got, want)
+	}
+}
+
+func TestConversionOverflowError(t *testing.T) {
+	e := errors.ConversionOverflowError("-1", &slowtesting.MockType{StringRet: "uint"})
+
+	got, want := e.Error(), `OverflowError: -1 is out of range for type "uint"`
+	if got != want {
+		t.Errorf("Error() returned incorrect value: got %q, want %q", got, want)
+	}
+}
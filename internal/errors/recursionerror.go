@@ -0,0 +1,8 @@
+package errors
+
+import "fmt"
+
+// NewRecursionError returns an error indicating that a call would exceed the maximum call depth.
+func NewRecursionError(maxDepth int) error {
+	return newError("RecursionError", fmt.Sprintf("maximum call depth of %d exceeded", maxDepth))
+}
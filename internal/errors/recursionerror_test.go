@@ -0,0 +1,16 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+)
+
+func TestNewRecursionError(t *testing.T) {
+	e := errors.NewRecursionError(10)
+
+	got, want := e.Error(), "RecursionError: maximum call depth of 10 exceeded"
+	if got != want {
+		t.Errorf("Error() returned incorrect value: got %q, want %q", got, want)
+	}
+}
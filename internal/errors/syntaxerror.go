@@ -1,13 +1,29 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/chrispyles/slow/internal/source"
+)
 
 func NewSyntaxError(buf Buffer, message string, symbol string) error {
 	var end string
 	if symbol != "" {
 		end = fmt.Sprintf(": %q", symbol)
 	}
-	return newError("SyntaxError", fmt.Sprintf("%s on line %d%s", message, buf.LineNumber(), end))
+	return atBuffer(newError("SyntaxError", fmt.Sprintf("%s on line %d%s", message, buf.LineNumber(), end)), buf)
+}
+
+// SyntaxErrorAt returns a SyntaxError for the code at the provided span, for errors that are found
+// before the code is split into tokens.
+func SyntaxErrorAt(span source.Span, message string, symbol string) error {
+	var end string
+	if symbol != "" {
+		end = fmt.Sprintf(": %q", symbol)
+	}
+	e := newError("SyntaxError", fmt.Sprintf("%s on line %d%s", message, span.Start.Line, end))
+	e.span = span
+	return e
 }
 
 func UnexpectedSymbolError(buf Buffer, got, want string) error {
@@ -20,7 +36,13 @@ func UnexpectedSymbolError(buf Buffer, got, want string) error {
 	if got != "" {
 		msg += fmt.Sprintf(": %q", got)
 	}
-	return newError("SyntaxError", msg)
+	return atBuffer(newError("SyntaxError", msg), buf)
+}
+
+// atBuffer sets the location of an error to the current token of a buffer.
+func atBuffer(e *SlowError, buf Buffer) *SlowError {
+	e.span = buf.CurrentSpan()
+	return e
 }
 
 func InterpreterSyntaxError(msg string) error {
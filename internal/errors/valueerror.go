@@ -7,5 +7,6 @@ func NewValueError(msg string) error {
 }
 
 func WrapValueError(val string, toType Type, err error) error {
-	return wrapError("ValueError", fmt.Sprintf("error converting %q to type %q: %+v", val, toType.String(), err), err)
+	// wrapError adds the message of err.
+	return wrapError("ValueError", fmt.Sprintf("error converting %q to type %q", val, toType.String()), err)
 }
@@ -20,7 +20,7 @@ func TestValueError(t *testing.T) {
 func TestWrapValueError(t *testing.T) {
 	e := errors.WrapValueError("foo", slowtesting.NewMockType(), goerrors.New("doh"))
 
-	got, want := e.Error(), "ValueError: error converting \"foo\" to type \"MockType\": doh: doh"
+	got, want := e.Error(), "ValueError: error converting \"foo\" to type \"MockType\": doh"
 	if got != want {
 		t.Errorf("Error() returned incorrect value: got %q, want %q", got, want)
 	}
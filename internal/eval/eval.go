@@ -1,37 +1,34 @@
 package eval
 
 import (
-	"fmt"
-
-	"github.com/chrispyles/slow/internal/config"
+	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/parser"
-	"github.com/chrispyles/slow/internal/printer"
-	"github.com/chrispyles/slow/internal/types"
 	"github.com/sanity-io/litter"
 )
 
 var (
-	makeAST = parser.Parse
-	println = printer.Println
+	makeAST = parser.ParseFile
 )
 
-func Eval(s string, env *execute.Environment, printExpr bool) {
-	ast, err := makeAST(s)
+// Eval parses and executes the provided code in env, returning the value of the last statement. The
+// code is assumed to come from the runtime's current file. If executing the code fails, the top level
+// of the file is added to the error's traceback.
+func Eval(s string, env *execute.Environment) (execute.Value, error) {
+	rt := env.Runtime()
+
+	ast, err := makeAST(rt.CurrentFile(), s)
 	if err != nil {
-		printError(err)
-		return
+		return nil, err
 	}
 
-	if *config.Debug {
-		astString := ast.String()
-		fmt.Println("<AST> ", astString)
+	if rt.IsDebug() {
+		rt.Println("<AST> " + ast.String())
 	}
 
 	val, err := ast.Execute(env)
 	if err != nil {
-		printError(err)
-		return
+		return nil, errors.AddFrame(err, "<module>")
 	}
 
 	// A nil value should never be returned by evaluating an expression unless err is non-nil.
@@ -39,16 +36,9 @@ func Eval(s string, env *execute.Environment, printExpr bool) {
 		panic("ast.Execute returned nil")
 	}
 
-	if printExpr && val != types.Null {
-		println(val.String())
+	if rt.IsDebug() {
+		rt.Print("\nAST evaluated to: " + litter.Sdump(val))
 	}
 
-	if *config.Debug {
-		fmt.Print("\nAST evaluated to: ")
-		litter.Dump(val)
-	}
-}
-
-func printError(err error) {
-	printer.Printlnf("%+v", err)
+	return val, nil
 }
@@ -3,8 +3,10 @@ package eval
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
+	slowerrors "github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	slowtesting "github.com/chrispyles/slow/internal/testing"
 	"github.com/chrispyles/slow/internal/types"
@@ -15,90 +17,118 @@ var mockValueStringRet = "mockASTMockValue"
 
 func TestEval(t *testing.T) {
 	origMakeAST := makeAST
-	origPrintln := println
 	makeMakeAST := func(err error) ([]string, *mockAST) {
 		calls := make([]string, 1)
 		mast := &mockAST{}
 		i := 0
-		makeAST = func(s string) (execute.AST, error) {
+		makeAST = func(_, s string) (execute.AST, error) {
 			calls[i] = s
 			i++
 			return mast, err
 		}
 		return calls, mast
 	}
-	makeMockPrintln := func() []*string {
-		calls := make([]*string, 1)
-		i := 0
-		println = func(s string) {
-			calls[i] = &s
-			i++
-		}
-		return calls
-	}
 	tests := []struct {
-		name             string
-		in               string
-		env              *execute.Environment
-		printExprValue   bool
-		astExecRet       execute.Value
-		astExecErr       error
-		wantPrintlnCalls []*string
+		name       string
+		in         string
+		env        *execute.Environment
+		makeASTErr error
+		astExecRet execute.Value
+		astExecErr error
+		want       execute.Value
+		wantErr    error
+		wantCalls  int
 	}{
 		{
-			name:             "success",
-			in:               "some code",
-			env:              execute.NewEnvironment(),
-			wantPrintlnCalls: make([]*string, 1),
+			name:      "success",
+			in:        "some code",
+			env:       execute.NewEnvironment(),
+			want:      &slowtesting.MockValue{StringRet: mockValueStringRet},
+			wantCalls: 1,
 		},
 		{
-			name:             "success_with_print",
-			in:               "some code",
-			env:              execute.NewEnvironment(),
-			printExprValue:   true,
-			wantPrintlnCalls: []*string{&mockValueStringRet},
+			name:       "null",
+			in:         "some code",
+			env:        execute.NewEnvironment(),
+			astExecRet: types.Null,
+			want:       types.Null,
+			wantCalls:  1,
 		},
 		{
-			name:             "no_print_null",
-			in:               "some code",
-			env:              execute.NewEnvironment(),
-			printExprValue:   true,
-			astExecRet:       types.Null,
-			wantPrintlnCalls: make([]*string, 1),
+			name:       "parse_error",
+			in:         "some code",
+			env:        execute.NewEnvironment(),
+			makeASTErr: errNuhUh,
+			wantErr:    errNuhUh,
 		},
 		{
-			name:             "ast_exec_error",
-			in:               "some code",
-			env:              execute.NewEnvironment(),
-			printExprValue:   true,
-			astExecErr:       errors.New("nuh-uh"),
-			wantPrintlnCalls: make([]*string, 1),
+			name:       "ast_exec_error",
+			in:         "some code",
+			env:        execute.NewEnvironment(),
+			astExecErr: errNuhUh,
+			wantErr:    errNuhUh,
+			wantCalls:  1,
 		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Cleanup(func() {
 				makeAST = origMakeAST
-				println = origPrintln
 			})
-			makeASTCalls, mast := makeMakeAST(nil)
+			makeASTCalls, mast := makeMakeAST(tc.makeASTErr)
 			mast.ret = tc.astExecRet
 			mast.err = tc.astExecErr
-			printlnCalls := makeMockPrintln()
-			Eval(tc.in, tc.env, tc.printExprValue)
+			got, err := Eval(tc.in, tc.env)
+			if err != tc.wantErr {
+				t.Errorf("Eval() returned incorrect error: got %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Eval() returned incorrect value (-want +got):\n%s", diff)
+			}
 			if diff := cmp.Diff([]string{tc.in}, makeASTCalls); diff != "" {
 				t.Errorf("Eval() called makeAST incorrectly (-want +got):\n%s", diff)
 			}
-			if diff := cmp.Diff([]uintptr{reflect.ValueOf(tc.env).Pointer()}, mast.calls); diff != "" {
-				t.Errorf("Eval() called ast.Execute incorrectly (-want +got):\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.wantPrintlnCalls, printlnCalls); diff != "" {
-				t.Errorf("Eval() called println incorrectly (-want +got):\n%s", diff)
+			if got := len(mast.calls); got != tc.wantCalls {
+				t.Errorf("Eval() called ast.Execute %d times, want %d", got, tc.wantCalls)
 			}
 		})
 	}
 }
 
+func TestEval_debug(t *testing.T) {
+	var out strings.Builder
+	env := execute.NewRootEnvironment(&execute.Runtime{Stdout: &out, Debug: true})
+	if _, err := Eval("1", env); err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "<AST> ") || !strings.Contains(got, "AST evaluated to: ") {
+		t.Errorf("Eval() printed incorrect debug output: %q", got)
+	}
+}
+
+func TestEval_traceback(t *testing.T) {
+	code := "func f(x) {\n  return g(x)\n}\nfunc g(x) {\n  return x + y\n}\nf(1)\n"
+	env := execute.NewRootEnvironment(&execute.Runtime{Script: "main.slo"})
+	_, err := Eval(code, env)
+	se, ok := err.(*slowerrors.SlowError)
+	if !ok {
+		t.Fatalf("Eval() returned %v, want a Slow error", err)
+	}
+	want := `Traceback (most recent call last):
+  File "main.slo", line 7, column 1, in <module>
+  File "main.slo", line 2, column 10, in f
+  File "main.slo", line 5, column 14, in g
+NameError: no variable "y" has been declared`
+	if got := se.Traceback(); got != want {
+		t.Errorf("Traceback() returned:\n%s\nwant:\n%s", got, want)
+	}
+	if got := len(env.Runtime().CallStack()); got != 0 {
+		t.Errorf("CallStack() has %d functions after Eval() returned, want 0", got)
+	}
+}
+
+var errNuhUh = errors.New("nuh-uh")
+
 type mockAST struct {
 	calls []uintptr
 	ret   execute.Value
@@ -3,6 +3,7 @@ package execute
 import (
 	"fmt"
 	"maps"
+	"slices"
 
 	"github.com/chrispyles/slow/internal/errors"
 )
@@ -12,12 +13,24 @@ type Environment struct {
 	consts map[string]bool
 	parent *Environment
 	frozen bool
+	// exports is the set of variables that have been explicitly exported from this frame.
+	exports map[string]bool
+	// runtime is only set on an interpreter's root environment; frames find it through their parents.
+	runtime *Runtime
 }
 
 func NewEnvironment() *Environment {
 	return &Environment{values: make(map[string]Value), consts: make(map[string]bool)}
 }
 
+// NewRootEnvironment returns a new Environment that is attached to the provided Runtime. Every
+// frame created from the returned environment shares the same Runtime.
+func NewRootEnvironment(rt *Runtime) *Environment {
+	e := NewEnvironment()
+	e.runtime = rt
+	return e
+}
+
 // FromMap returns a frozen Environment from the provided map.
 func FromMap(values map[string]Value) *Environment {
 	e := &Environment{values: values}
@@ -31,10 +44,12 @@ func (e *Environment) Copy() *Environment {
 		return nil
 	}
 	return &Environment{
-		values: maps.Clone(e.values),
-		consts: maps.Clone(e.consts),
-		parent: e.parent,
-		frozen: e.frozen,
+		values:  maps.Clone(e.values),
+		consts:  maps.Clone(e.consts),
+		parent:  e.parent,
+		frozen:  e.frozen,
+		exports: maps.Clone(e.exports),
+		runtime: e.runtime,
 	}
 }
 
@@ -61,6 +76,28 @@ func (e *Environment) DeclareConst(n string, v Value) (Value, error) {
 	return v, nil
 }
 
+// Export marks the variable with the provided name, which must be declared in this frame, as
+// exported.
+func (e *Environment) Export(n string) error {
+	if !e.Has(n) {
+		return errors.NewNameError(n)
+	}
+	if e.exports == nil {
+		e.exports = make(map[string]bool)
+	}
+	e.exports[n] = true
+	return nil
+}
+
+// Exports returns the sorted names of the variables that have been explicitly exported from this
+// frame and whether any have been.
+func (e *Environment) Exports() ([]string, bool) {
+	if len(e.exports) == 0 {
+		return nil, false
+	}
+	return slices.Sorted(maps.Keys(e.exports)), true
+}
+
 func (e *Environment) Get(n string) (Value, error) {
 	v, ok := e.values[n]
 	if !ok {
@@ -80,12 +117,39 @@ func (e *Environment) Has(n string) bool {
 	return ok
 }
 
+// Names returns the sorted names of the variables declared in this frame.
+func (e *Environment) Names() []string {
+	return slices.Sorted(maps.Keys(e.values))
+}
+
+// VisibleNames returns the sorted names of the variables declared in this frame and its ancestors.
+func (e *Environment) VisibleNames() []string {
+	names := make(map[string]bool)
+	for f := e; f != nil; f = f.parent {
+		for n := range f.values {
+			names[n] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
 func (e *Environment) NewFrame() *Environment {
 	c := NewEnvironment()
 	c.parent = e
 	return c
 }
 
+// Runtime returns the Runtime of the interpreter this environment belongs to, or nil if it does not
+// belong to one.
+func (e *Environment) Runtime() *Runtime {
+	for f := e; f != nil; f = f.parent {
+		if f.runtime != nil {
+			return f.runtime
+		}
+	}
+	return nil
+}
+
 func (e *Environment) Set(n string, v Value) (Value, error) {
 	if e.frozen {
 		return nil, errors.NewRuntimeError("cannot assign variables in a frozen environment")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/chrispyles/slow/internal/execute"
 	slowtesting "github.com/chrispyles/slow/internal/testing"
+	"github.com/google/go-cmp/cmp"
 )
 
 // TODO: should failure scenario tests check that the returned errors have the correct message?
@@ -219,3 +220,46 @@ func TestEnvironment_Freeze(t *testing.T) {
 	}()
 	e.Declare(n2)
 }
+
+func TestEnvironment_Names(t *testing.T) {
+	e := execute.NewEnvironment()
+	for _, n := range []string{"foo", "bar", "_baz"} {
+		if err := e.Declare(n); err != nil {
+			t.Fatalf("Declare() returned an unexpected error: %v", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"_baz", "bar", "foo"}, e.Names()); diff != "" {
+		t.Errorf("Names() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := e.NewFrame().Names(); len(got) != 0 {
+		t.Errorf("Names() returned the names of the parent frame: %v", got)
+	}
+}
+
+func TestEnvironment_Export(t *testing.T) {
+	e := execute.NewEnvironment()
+	if _, ok := e.Exports(); ok {
+		t.Errorf("Exports() returned ok = true before any names were exported")
+	}
+	for _, n := range []string{"foo", "bar", "baz"} {
+		e.Declare(n)
+	}
+	for _, n := range []string{"foo", "bar"} {
+		if err := e.Export(n); err != nil {
+			t.Fatalf("Export(%q) returned an unexpected error: %v", n, err)
+		}
+	}
+	if err := e.Export("qux"); err == nil {
+		t.Errorf("Export() did not return an error for an undeclared name")
+	}
+	if err := e.NewFrame().Export("foo"); err == nil {
+		t.Errorf("Export() did not return an error for a name declared in a parent frame")
+	}
+	got, ok := e.Exports()
+	if !ok {
+		t.Errorf("Exports() returned ok = false")
+	}
+	if diff := cmp.Diff([]string{"bar", "foo"}, got); diff != "" {
+		t.Errorf("Exports() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
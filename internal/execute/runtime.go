@@ -0,0 +1,185 @@
+package execute
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+)
+
+// MaxCallDepth is the maximum number of calls to user-defined functions that can be executing at
+// once.
+const MaxCallDepth = 1000
+
+// Runtime holds the state belonging to a single interpreter instance. It is attached to the
+// interpreter's root environment, and every frame descended from that environment shares it, so
+// that no interpreter state needs to be kept in package-level variables.
+type Runtime struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	Stdin  io.Reader
+	// Debug indicates whether ASTs and values should be dumped as they are evaluated.
+	Debug bool
+	// CheckedArithmetic indicates whether int and uint arithmetic and casts should return an
+	// OverflowError instead of wrapping around when a result doesn't fit in its type.
+	CheckedArithmetic bool
+	// Decimal determines how the results of decimal arithmetic that can't be represented exactly,
+	// like division, are rounded.
+	Decimal decimal.Context
+	// Script is the path to the file containing the main program, if any.
+	Script string
+	// Args are the arguments passed to the main script, not including its path.
+	Args []string
+	// SearchPath is the list of directories that imported files are searched for in after the
+	// directory containing the importing file.
+	SearchPath []string
+
+	// files is the stack of files currently being imported, innermost last.
+	files []string
+	// calls is the stack of names of the user-defined functions currently executing, innermost last.
+	calls []string
+}
+
+// NewRuntime returns a Runtime that uses the process's standard streams.
+func NewRuntime() *Runtime {
+	return &Runtime{Stdout: os.Stdout, Stderr: os.Stderr, Stdin: os.Stdin}
+}
+
+func (r *Runtime) stdout() io.Writer {
+	if r == nil || r.Stdout == nil {
+		return os.Stdout
+	}
+	return r.Stdout
+}
+
+func (r *Runtime) stderr() io.Writer {
+	if r == nil || r.Stderr == nil {
+		return os.Stderr
+	}
+	return r.Stderr
+}
+
+// IsDebug returns whether debug output is enabled. It is safe to call on a nil Runtime.
+func (r *Runtime) IsDebug() bool {
+	return r != nil && r.Debug
+}
+
+// IsCheckedArithmetic returns whether checked arithmetic is enabled. It is safe to call on a nil
+// Runtime.
+func (r *Runtime) IsCheckedArithmetic() bool {
+	return r != nil && r.CheckedArithmetic
+}
+
+// DecimalContext returns the context that inexact decimal results are rounded with. It is safe to
+// call on a nil Runtime.
+func (r *Runtime) DecimalContext() decimal.Context {
+	if r == nil {
+		return decimal.Context{}
+	}
+	return r.Decimal
+}
+
+// PushFile records that the file at the provided path has started executing, either because it is
+// being imported or because a function declared in it has been called. It is safe to call on a nil
+// Runtime, which does not track files.
+func (r *Runtime) PushFile(path string) {
+	if r == nil {
+		return
+	}
+	r.files = append(r.files, path)
+}
+
+// PopFile records that the innermost executing file has finished executing.
+func (r *Runtime) PopFile() {
+	if r == nil {
+		return
+	}
+	r.files = r.files[:len(r.files)-1]
+}
+
+// Files returns the paths of the files that are currently executing, outermost first. The main
+// script, if any, is always the first file.
+func (r *Runtime) Files() []string {
+	if r == nil {
+		return nil
+	}
+	var fs []string
+	if r.Script != "" {
+		fs = append(fs, r.Script)
+	}
+	return append(fs, r.files...)
+}
+
+// CurrentFile returns the path of the innermost executing file, or an empty string if no file is
+// executing (e.g. in the REPL).
+func (r *Runtime) CurrentFile() string {
+	fs := r.Files()
+	if len(fs) == 0 {
+		return ""
+	}
+	return fs[len(fs)-1]
+}
+
+// CurrentDir returns the directory containing the innermost executing file, which relative imports
+// are resolved against, or "." if no file is executing.
+func (r *Runtime) CurrentDir() string {
+	if cf := r.CurrentFile(); cf != "" {
+		return filepath.Dir(cf)
+	}
+	return "."
+}
+
+// PushCall records that the user-defined function with the provided name has been called. A
+// RecursionError is returned if the call would exceed MaxCallDepth. It is safe to call on a nil
+// Runtime, which does not track calls.
+func (r *Runtime) PushCall(fn string) error {
+	if r == nil {
+		return nil
+	}
+	if len(r.calls) >= MaxCallDepth {
+		return errors.NewRecursionError(MaxCallDepth)
+	}
+	r.calls = append(r.calls, fn)
+	return nil
+}
+
+// PopCall records that the innermost executing function has returned.
+func (r *Runtime) PopCall() {
+	if r == nil {
+		return
+	}
+	r.calls = r.calls[:len(r.calls)-1]
+}
+
+// CallStack returns the names of the user-defined functions that are currently executing, outermost
+// first.
+func (r *Runtime) CallStack() []string {
+	if r == nil {
+		return nil
+	}
+	return r.calls
+}
+
+func (r *Runtime) Print(s string) {
+	io.WriteString(r.stdout(), s)
+}
+
+func (r *Runtime) Println(s string) {
+	r.Print(s + "\n")
+}
+
+func (r *Runtime) Printf(s string, args ...any) {
+	r.Print(fmt.Sprintf(s, args...))
+}
+
+func (r *Runtime) Printlnf(s string, args ...any) {
+	r.Printf(s+"\n", args...)
+}
+
+// PrintError writes the provided error to the runtime's stderr.
+func (r *Runtime) PrintError(err error) {
+	fmt.Fprintf(r.stderr(), "%+v\n", err)
+}
@@ -0,0 +1,55 @@
+package execute
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRuntime_CallStack(t *testing.T) {
+	rt := &Runtime{}
+	for _, fn := range []string{"f", "g"} {
+		if err := rt.PushCall(fn); err != nil {
+			t.Fatalf("PushCall(%q) returned an unexpected error: %v", fn, err)
+		}
+	}
+	if diff := cmp.Diff([]string{"f", "g"}, rt.CallStack()); diff != "" {
+		t.Errorf("CallStack() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	rt.PopCall()
+	if diff := cmp.Diff([]string{"f"}, rt.CallStack()); diff != "" {
+		t.Errorf("CallStack() after PopCall() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	for len(rt.CallStack()) < MaxCallDepth {
+		if err := rt.PushCall("f"); err != nil {
+			t.Fatalf("PushCall() returned an unexpected error at depth %d: %v", len(rt.CallStack()), err)
+		}
+	}
+	want := errors.NewRecursionError(MaxCallDepth)
+	if diff := cmp.Diff(want, rt.PushCall("f"), cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+		t.Errorf("PushCall() at the maximum depth returned unexpected error (-want +got):\n%s", diff)
+	}
+
+	var nilRuntime *Runtime
+	if err := nilRuntime.PushCall("f"); err != nil {
+		t.Errorf("PushCall() on a nil Runtime returned an unexpected error: %v", err)
+	}
+	nilRuntime.PopCall()
+}
+
+func TestRuntime_CurrentDir(t *testing.T) {
+	var nilRuntime *Runtime
+	if got, want := nilRuntime.CurrentDir(), "."; got != want {
+		t.Errorf("CurrentDir() on a nil Runtime = %q, want %q", got, want)
+	}
+	rt := &Runtime{Script: "scripts/main.slo"}
+	if got, want := rt.CurrentDir(), "scripts"; got != want {
+		t.Errorf("CurrentDir() = %q, want %q", got, want)
+	}
+	rt.PushFile("/lib/util/index.slo")
+	if got, want := rt.CurrentDir(), "/lib/util"; got != want {
+		t.Errorf("CurrentDir() while importing = %q, want %q", got, want)
+	}
+}
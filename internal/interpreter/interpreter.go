@@ -2,45 +2,88 @@ package interpreter
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
 	"io"
 
-	"github.com/chrispyles/slow/internal/builtins"
-	evallib "github.com/chrispyles/slow/internal/eval"
-	"github.com/chrispyles/slow/internal/execute"
-	"github.com/chrispyles/slow/internal/printer"
+	"github.com/chrispyles/slow"
+	"github.com/chrispyles/slow/internal/diagnostic"
 	"github.com/chrispyles/slow/internal/reader"
+	"github.com/chrispyles/slow/internal/types"
 )
 
 var (
 	read = reader.Read
-	eval = evallib.Eval
 )
 
-func Run(code string, interactiveReader io.Reader) *execute.Environment {
-	env := builtins.RootEnvironment.NewFrame()
+// Interpreter is the subset of *slow.Interpreter that Run uses.
+type Interpreter interface {
+	Eval(string) (slow.Value, error)
+	Stdout() io.Writer
+	Stderr() io.Writer
+}
+
+// ErrUncaught is returned by Run when the code it is passed raises an error that isn't caught and
+// there is no interactive session to continue with. The error has already been written to stderr.
+var ErrUncaught = errors.New("uncaught error")
+
+// Run executes code in interp and then, if interactiveReader is non-nil, reads and executes
+// statements from it until the program exits. Errors are written to interp's stderr as diagnostics
+// formatted according to opts. If the program calls exit, the *slow.ExitError is returned; if code
+// raises an uncaught error and interactiveReader is nil, ErrUncaught is returned.
+func Run(interp Interpreter, code string, interactiveReader io.Reader, opts diagnostic.Options) error {
 	if code != "" {
-		eval(code, env, false)
+		if _, err := interp.Eval(code); err != nil {
+			if _, ok := err.(*slow.ExitError); ok {
+				return err
+			}
+			printError(interp, err, code, opts)
+			if interactiveReader == nil {
+				return ErrUncaught
+			}
+		}
 	}
 
 	if interactiveReader == nil {
-		return env
+		return nil
 	}
 
 	rdr := bufio.NewReader(interactiveReader)
 	for {
-		stmt, err := read(rdr)
+		stmt, err := read(rdr, interp.Stdout())
 		if err != nil {
-			printError(err)
+			printError(interp, err, "", opts)
 			continue
 		}
 		if stmt == "\n" {
 			// Don't attempt to execute an empty line
 			continue
 		}
-		eval(stmt, env, true)
+		if err := EvalAndPrint(interp, stmt, true, opts); err != nil {
+			return err
+		}
+	}
+}
+
+// EvalAndPrint executes code in interp, printing any error that occurs as a diagnostic formatted
+// according to opts and, if printExpr is true, the value of the last statement. Only errors that
+// should end the program are returned.
+func EvalAndPrint(interp Interpreter, code string, printExpr bool, opts diagnostic.Options) error {
+	val, err := interp.Eval(code)
+	if err != nil {
+		if _, ok := err.(*slow.ExitError); ok {
+			return err
+		}
+		printError(interp, err, code, opts)
+		return nil
+	}
+	if printExpr && val != types.Null {
+		fmt.Fprintln(interp.Stdout(), val.String())
 	}
+	return nil
 }
 
-func printError(err error) {
-	printer.Printlnf("%+v", err)
+// printError writes a diagnostic for an error that occurred while evaluating code to interp's stderr.
+func printError(interp Interpreter, err error, code string, opts diagnostic.Options) {
+	diagnostic.Write(interp.Stderr(), err, code, opts)
 }
@@ -2,26 +2,38 @@ package interpreter
 
 import (
 	"bufio"
+	"errors"
+	"io"
 	"strings"
 	"testing"
 
-	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow"
+	"github.com/chrispyles/slow/internal/diagnostic"
+	slowtesting "github.com/chrispyles/slow/internal/testing"
+	"github.com/chrispyles/slow/internal/types"
 	"github.com/google/go-cmp/cmp"
 )
 
 func TestRun(t *testing.T) {
 	t.Run("noninteractive", func(t *testing.T) {
-		evalCalls := setup(t)
+		setup(t)
+		interp := &mockInterpreter{}
 
-		Run("foo", nil)
+		if err := Run(interp, "foo", nil, diagnostic.Options{}); err != nil {
+			t.Errorf("Run() returned an unexpected error: %v", err)
+		}
 
-		if diff := cmp.Diff([]string{"foo"}, *evalCalls); diff != "" {
+		if diff := cmp.Diff([]string{"foo"}, interp.evalCalls); diff != "" {
 			t.Errorf("Run() called eval incorrectly (-want +got):\n%s", diff)
 		}
+		if got := interp.stdout.String(); got != "" {
+			t.Errorf("Run() printed the value of a noninteractive program: %q", got)
+		}
 	})
 
 	t.Run("interactive", func(t *testing.T) {
-		evalCalls := setup(t)
+		setup(t)
+		interp := &mockInterpreter{}
 
 		input := strings.NewReader("bar\nbaz\n\n")
 
@@ -29,22 +41,141 @@ func TestRun(t *testing.T) {
 			if err := recover(); err == nil {
 				t.Errorf("Run() did not run forever")
 			}
-			if diff := cmp.Diff([]string{"foo", "bar\n", "baz\n"}, *evalCalls); diff != "" {
+			if diff := cmp.Diff([]string{"foo", "bar\n", "baz\n"}, interp.evalCalls); diff != "" {
 				t.Errorf("Run() called eval incorrectly (-want +got):\n%s", diff)
 			}
+			if diff := cmp.Diff("MOCK\nMOCK\n", interp.stdout.String()); diff != "" {
+				t.Errorf("Run() printed incorrectly (-want +got):\n%s", diff)
+			}
 		}()
-		Run("foo", input)
+		Run(interp, "foo", input, diagnostic.Options{})
+	})
+
+	t.Run("uncaught", func(t *testing.T) {
+		setup(t)
+		interp := &mockInterpreter{evalErr: errors.New("nuh-uh")}
+		if err := Run(interp, "foo", nil, diagnostic.Options{}); err != ErrUncaught {
+			t.Errorf("Run() returned incorrect error: got %v, want %v", err, ErrUncaught)
+		}
+		if diff := cmp.Diff("nuh-uh\n", interp.stderr.String()); diff != "" {
+			t.Errorf("Run() printed incorrectly to stderr (-want +got):\n%s", diff)
+		}
 	})
+
+	t.Run("uncaught_interactive", func(t *testing.T) {
+		setup(t)
+		interp := &mockInterpreter{evalErr: errors.New("nuh-uh")}
+		defer func() {
+			if err := recover(); err == nil {
+				t.Errorf("Run() did not continue to the interactive session")
+			}
+			if diff := cmp.Diff([]string{"foo", "bar\n"}, interp.evalCalls); diff != "" {
+				t.Errorf("Run() called eval incorrectly (-want +got):\n%s", diff)
+			}
+		}()
+		Run(interp, "foo", strings.NewReader("bar\n"), diagnostic.Options{})
+	})
+
+	t.Run("exit", func(t *testing.T) {
+		setup(t)
+		exitErr := &slow.ExitError{Code: 2}
+		interp := &mockInterpreter{evalErr: exitErr}
+
+		if err := Run(interp, "foo", strings.NewReader("bar\n"), diagnostic.Options{}); err != exitErr {
+			t.Errorf("Run() returned incorrect error: got %v, want %v", err, exitErr)
+		}
+		if diff := cmp.Diff([]string{"foo"}, interp.evalCalls); diff != "" {
+			t.Errorf("Run() called eval incorrectly (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestEvalAndPrint(t *testing.T) {
+	tests := []struct {
+		name       string
+		printExpr  bool
+		evalRet    slow.Value
+		evalErr    error
+		opts       diagnostic.Options
+		wantErr    error
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "print",
+			printExpr:  true,
+			evalRet:    &slowtesting.MockValue{StringRet: "MOCK"},
+			wantStdout: "MOCK\n",
+		},
+		{
+			name:    "no_print",
+			evalRet: &slowtesting.MockValue{StringRet: "MOCK"},
+		},
+		{
+			name:      "no_print_null",
+			printExpr: true,
+			evalRet:   types.Null,
+		},
+		{
+			name:       "error",
+			printExpr:  true,
+			evalErr:    errors.New("nuh-uh"),
+			wantStderr: "nuh-uh\n",
+		},
+		{
+			name:       "error_json",
+			printExpr:  true,
+			opts:       diagnostic.Options{Format: diagnostic.JSON},
+			evalErr:    errors.New("nuh-uh"),
+			wantStderr: `{"type":"Error","message":"nuh-uh"}` + "\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			interp := &mockInterpreter{evalRet: tc.evalRet, evalErr: tc.evalErr}
+			if err := EvalAndPrint(interp, "foo", tc.printExpr, tc.opts); err != tc.wantErr {
+				t.Errorf("EvalAndPrint() returned incorrect error: got %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantStdout, interp.stdout.String()); diff != "" {
+				t.Errorf("EvalAndPrint() printed incorrectly to stdout (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStderr, interp.stderr.String()); diff != "" {
+				t.Errorf("EvalAndPrint() printed incorrectly to stderr (-want +got):\n%s", diff)
+			}
+		})
+	}
 }
 
-func setup(t *testing.T) *[]string {
-	origEval := eval
-	evalCalls := &[]string{}
-	eval = func(c string, env *execute.Environment, print bool) {
-		*evalCalls = append(*evalCalls, c)
+type mockInterpreter struct {
+	evalCalls []string
+	evalRet   slow.Value
+	evalErr   error
+	stdout    strings.Builder
+	stderr    strings.Builder
+}
+
+func (m *mockInterpreter) Eval(code string) (slow.Value, error) {
+	m.evalCalls = append(m.evalCalls, code)
+	if m.evalErr != nil {
+		return nil, m.evalErr
+	}
+	if m.evalRet != nil {
+		return m.evalRet, nil
 	}
+	return &slowtesting.MockValue{StringRet: "MOCK"}, nil
+}
+
+func (m *mockInterpreter) Stdout() io.Writer {
+	return &m.stdout
+}
+
+func (m *mockInterpreter) Stderr() io.Writer {
+	return &m.stderr
+}
+
+func setup(t *testing.T) {
 	origRead := read
-	read = func(r *bufio.Reader) (string, error) {
+	read = func(r *bufio.Reader, _ io.Writer) (string, error) {
 		s, err := r.ReadString('\n')
 		if err != nil {
 			// Call panic to kill the Run function when out of input since it runs indefinitely. The
@@ -54,8 +185,6 @@ func setup(t *testing.T) *[]string {
 		return s, nil
 	}
 	t.Cleanup(func() {
-		eval = origEval
 		read = origRead
 	})
-	return evalCalls
 }
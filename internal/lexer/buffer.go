@@ -1,12 +1,25 @@
 package lexer
 
+import "github.com/chrispyles/slow/internal/source"
+
 type Buffer struct {
 	index  int
 	tokens []Token
 }
 
-func NewBuffer(s string) *Buffer {
-	return &Buffer{tokens: tokenize(s)}
+// NewBuffer returns a buffer of the tokens in s. A SyntaxError is returned if s can't be tokenized.
+func NewBuffer(s string) (*Buffer, error) {
+	return NewFileBuffer("", s)
+}
+
+// NewFileBuffer returns a buffer of the tokens in s, which was read from the provided file. The file
+// is recorded in the span of each token.
+func NewFileBuffer(file, s string) (*Buffer, error) {
+	tokens, err := tokenize(file, s)
+	if err != nil {
+		return nil, err
+	}
+	return &Buffer{tokens: tokens}, nil
 }
 
 // func (b *Buffer) MoreOnLine() bool {
@@ -23,22 +36,35 @@ func (b *Buffer) MoveBack() {
 	b.index--
 }
 
-// Current returns the current token. If the index of the buffer has reached the end of the current
-// line, the current line and index are moved to the next token. Returns the empty string once all
-// tokens have been exhausted.
+// Current returns the current token. Once all tokens have been exhausted, the final EOF token is
+// returned, even if it has already been popped.
 func (b *Buffer) Current() Token {
+	if b.index >= len(b.tokens) {
+		return b.tokens[len(b.tokens)-1]
+	}
 	return b.tokens[b.index]
 }
 
-// LineNumber returns the line number of the next token in the buffer.
-func (b *Buffer) LineNumber() int {
-	ln := 1
-	for i := 0; i <= b.index; i++ {
-		if b.tokens[i].Type == EOL {
-			ln++
+// Previous returns the last token before the current token that is not a newline. If there is no
+// such token, the current token is returned.
+func (b *Buffer) Previous() Token {
+	for i := b.index - 1; i >= 0; i-- {
+		if b.tokens[i].Type != EOL {
+			return b.tokens[i]
 		}
 	}
-	return ln
+	return b.Current()
+}
+
+// CurrentSpan returns the span of the current token.
+func (b *Buffer) CurrentSpan() source.Span {
+	return b.Current().Span
+}
+
+// LineNumber returns the line number of the next token in the buffer. A newline token is on the line
+// that it ends.
+func (b *Buffer) LineNumber() int {
+	return b.Current().Span.Start.Line
 }
 
 // type Buffer struct {
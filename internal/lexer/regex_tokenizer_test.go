@@ -0,0 +1,189 @@
+package lexer
+
+// This file contains the regex-based tokenizer that the scanner in tokenizer.go replaced. It is only
+// kept to check that the two agree and to benchmark them against each other.
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/source"
+)
+
+type regexHandler func(*lexer, *regexp.Regexp) error
+
+type regexMatcher struct {
+	regex   *regexp.Regexp
+	handler regexHandler
+}
+
+var regexMatchers = []regexMatcher{
+	// IMPORTANT: Order here matters. If one regex has another as a prefix, it must go first to ensure
+	// that it is correctly caught; for example, "//" must go before "/", otherwise the string "//"
+	// will be incorrectly parsed as {"/", "/"}.
+	{regexp.MustCompile(`\n`), regexDefaultHandler(EOL, "\n")},
+	{regexp.MustCompile(`\s+`), regexSkipHandler},
+	{regexp.MustCompile(`#.*`), regexCommentHandler},
+	// Strings must be on a single line; the closing quote is optional so that unterminated strings
+	// can be reported.
+	{regexp.MustCompile(`"(?:[^"\\\n]|\\.)*(")?`), regexStringHandler},
+	{regexp.MustCompile(`0x[\dA-Fa-f]+`), regexBytesHandler},
+	{regexp.MustCompile(`[0-9]+(\.[0-9]*)?u?`), regexNumberHandler},
+	// Numbers starting with a "." need a different regex to ensure that they are followed by a digit.
+	{regexp.MustCompile(`\.[0-9]+([0-9]+)?`), regexNumberHandler},
+	{regexp.MustCompile(`[a-zA-Z_][a-zA-Z0-9_]*`), regexSymbolHandler},
+	{regexp.MustCompile(`\[`), regexDefaultHandler(OpenBracket, "[")},
+	{regexp.MustCompile(`\]`), regexDefaultHandler(CloseBracket, "]")},
+	{regexp.MustCompile(`\{`), regexDefaultHandler(OpenCurlyBracket, "{")},
+	{regexp.MustCompile(`\}`), regexDefaultHandler(CloseCurlyBracket, "}")},
+	{regexp.MustCompile(`\(`), regexDefaultHandler(OpenParen, "(")},
+	{regexp.MustCompile(`\)`), regexDefaultHandler(CloseParen, ")")},
+	{regexp.MustCompile(`==`), regexDefaultHandler(Equals, "==")},
+	{regexp.MustCompile(`!=`), regexDefaultHandler(NotEquals, "!=")},
+	{regexp.MustCompile(`=`), regexDefaultHandler(Assignment, "=")},
+	{regexp.MustCompile(`!`), regexDefaultHandler(Not, "!")},
+	{regexp.MustCompile(`<=`), regexDefaultHandler(LessEqual, "<=")},
+	{regexp.MustCompile(`<`), regexDefaultHandler(Less, "<")},
+	{regexp.MustCompile(`>=`), regexDefaultHandler(GreaterEqual, ">=")},
+	{regexp.MustCompile(`>`), regexDefaultHandler(Greater, ">")},
+	{regexp.MustCompile(`\|\|`), regexDefaultHandler(Or, "||")},
+	{regexp.MustCompile(`&&`), regexDefaultHandler(And, "&&")},
+	{regexp.MustCompile(`\.`), regexDefaultHandler(Dot, ".")},
+	{regexp.MustCompile(`:`), regexDefaultHandler(Colon, ":")},
+	{regexp.MustCompile(`,`), regexDefaultHandler(Comma, ",")},
+	{regexp.MustCompile(`\+\+`), regexDefaultHandler(PlusPlus, "++")},
+	{regexp.MustCompile(`--`), regexDefaultHandler(MinusMinus, "--")},
+	{regexp.MustCompile(`\+=`), regexDefaultHandler(PlusEqual, "+=")},
+	{regexp.MustCompile(`-=`), regexDefaultHandler(MinusEqual, "-=")},
+	{regexp.MustCompile(`\*=`), regexDefaultHandler(TimesEqual, "*=")},
+	{regexp.MustCompile(`//=`), regexDefaultHandler(FloorDivEqual, "//=")},
+	{regexp.MustCompile(`/=`), regexDefaultHandler(DivideEqual, "/=")},
+	{regexp.MustCompile(`%=`), regexDefaultHandler(ModEqual, "%=")},
+	{regexp.MustCompile(`\+`), regexDefaultHandler(Plus, "+")},
+	{regexp.MustCompile(`-`), regexDefaultHandler(Minus, "-")},
+	{regexp.MustCompile(`//`), regexDefaultHandler(FloorDiv, "//")},
+	{regexp.MustCompile(`/`), regexDefaultHandler(DividedBy, "/")},
+	{regexp.MustCompile(`\*`), regexDefaultHandler(Times, "*")},
+	{regexp.MustCompile(`%`), regexDefaultHandler(Mod, "%")},
+}
+
+func regexTokenize(file, s string) ([]Token, error) {
+	lex := &lexer{file: file, input: s, position: source.Start}
+	for !lex.atEOF() {
+		var matched bool
+		for _, m := range regexMatchers {
+			loc := m.regex.FindStringIndex(lex.remainder())
+			if loc != nil && loc[0] == 0 {
+				if err := m.handler(lex, m.regex); err != nil {
+					return nil, err
+				}
+				matched = true
+				break // Exit the loop after the first match
+			}
+		}
+		if !matched {
+			r, _ := utf8.DecodeRuneInString(lex.remainder())
+			return nil, lex.errorAt(string(r), "unexpected character")
+		}
+	}
+	lex.emit(EOF, "")
+	return lex.tokens, nil
+}
+
+func regexDefaultHandler(kind TokenType, value string) regexHandler {
+	return func(lex *lexer, _ *regexp.Regexp) error {
+		lex.emit(kind, value)
+		return nil
+	}
+}
+
+const regexEscapeCharacters = `\nrt"`
+
+func regexInvalidEscapeError(lex *lexer, stringLiteral string, i int) error {
+	_, size := utf8.DecodeRuneInString(stringLiteral[i+1:])
+	escape := stringLiteral[i : i+1+size]
+	start := lex.position.Advance(stringLiteral[:i])
+	span := source.Span{File: lex.file, Start: start, End: start.Advance(escape)}
+	return errors.SyntaxErrorAt(span, "invalid escape sequence", escape)
+}
+
+func regexStringHandler(lex *lexer, regex *regexp.Regexp) error {
+	match := regex.FindStringSubmatchIndex(lex.remainder())
+	stringLiteral := lex.remainder()[match[0]:match[1]]
+	if match[2] < 0 {
+		return lex.errorAt(stringLiteral, "unterminated string")
+	}
+	for i := 1; i < len(stringLiteral)-1; i++ {
+		if stringLiteral[i] != '\\' {
+			continue
+		}
+		if c := stringLiteral[i+1]; !strings.ContainsRune(regexEscapeCharacters, rune(c)) {
+			return regexInvalidEscapeError(lex, stringLiteral, i)
+		}
+		i++
+	}
+	lex.emit(String, stringLiteral)
+	return nil
+}
+
+func regexBytesHandler(lex *lexer, regex *regexp.Regexp) error {
+	match := regex.FindStringIndex(lex.remainder())
+	bytesLiteral := lex.remainder()[match[0]:match[1]]
+	if err := regexCheckNumberEnd(lex, bytesLiteral); err != nil {
+		return err
+	}
+	lex.emit(Bytes, bytesLiteral)
+	return nil
+}
+
+func regexNumberHandler(lex *lexer, regex *regexp.Regexp) error {
+	match := regex.FindString(lex.remainder())
+	if err := regexCheckNumberEnd(lex, match); err != nil {
+		return err
+	}
+	if strings.Contains(match, ".") && strings.HasSuffix(match, "u") {
+		return lex.errorAt(match, "malformed number")
+	}
+	lex.emit(Number, match)
+	return nil
+}
+
+func regexCheckNumberEnd(lex *lexer, literal string) error {
+	rest := lex.remainder()[len(literal):]
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if end < 0 {
+		end = len(rest)
+	}
+	tail := strings.TrimRight(rest[:end], ".")
+	if tail == "" {
+		return nil
+	}
+	return lex.errorAt(literal+tail, "malformed number")
+}
+
+func regexSymbolHandler(lex *lexer, regex *regexp.Regexp) error {
+	match := regex.FindString(lex.remainder())
+	if kind, ok := keywords[match]; ok {
+		lex.emit(kind, match)
+	} else {
+		lex.emit(Symbol, match)
+	}
+	return nil
+}
+
+func regexSkipHandler(lex *lexer, regex *regexp.Regexp) error {
+	match := regex.FindStringIndex(lex.remainder())
+	lex.advance(match[1])
+	return nil
+}
+
+func regexCommentHandler(lex *lexer, regex *regexp.Regexp) error {
+	match := regex.FindStringIndex(lex.remainder())
+	lex.advance(match[1])
+	return nil
+}
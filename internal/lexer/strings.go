@@ -0,0 +1,248 @@
+package lexer
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/source"
+)
+
+// stringEscapes maps the characters that can follow a backslash in a string literal to the text that
+// the escape sequence represents. "\x" and "\u" escapes are handled separately.
+var stringEscapes = map[byte]string{
+	'\\': "\\",
+	'n':  "\n",
+	'r':  "\r",
+	't':  "\t",
+	'"':  "\"",
+	'\'': "'",
+}
+
+// isStringStart returns whether a string literal starts at the beginning of s. String literals are
+// delimited by single or double quotes, and raw strings are prefixed with "r".
+func isStringStart(s string) bool {
+	if strings.HasPrefix(s, "r") {
+		s = s[1:]
+	}
+	return strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'")
+}
+
+// stringDelimiter returns the quotes that delimit the string literal at the start of s, which must
+// not include the "r" prefix of a raw string.
+func stringDelimiter(s string) string {
+	if triple := strings.Repeat(s[:1], 3); strings.HasPrefix(s, triple) {
+		return triple
+	}
+	return s[:1]
+}
+
+// scanString consumes a string literal. Strings delimited by a single quote character must be on a
+// single line, but triple-quoted strings can span multiple lines. The escape sequences in strings
+// that aren't raw are checked so that invalid ones are reported as syntax errors.
+func (l *lexer) scanString() error {
+	rest := l.remainder()
+	var prefix int
+	raw := rest[0] == 'r'
+	if raw {
+		prefix = 1
+	}
+	delim := stringDelimiter(rest[prefix:])
+	multiline := len(delim) == 3
+	start := prefix + len(delim)
+	end := -1
+loop:
+	for i := start; i < len(rest); i++ {
+		switch {
+		case strings.HasPrefix(rest[i:], delim):
+			end = i
+			break loop
+		case rest[i] == '\n' && !multiline:
+			return l.errorAt(rest[:i], "unterminated string")
+		case rest[i] == '\\' && !raw:
+			if i+1 == len(rest) || rest[i+1] == '\n' && !multiline {
+				return l.errorAt(rest[:i], "unterminated string")
+			}
+			// Skip the escaped character so that an escaped quote doesn't end the string.
+			i++
+		}
+	}
+	if end < 0 {
+		if multiline {
+			// Only report the opening quotes, since the rest of the file is part of the string.
+			return l.errorAt(rest[:start], "unterminated string")
+		}
+		return l.errorAt(rest, "unterminated string")
+	}
+	literal := rest[:end+len(delim)]
+	if !raw {
+		if _, err := unescape(rest[start:end]); err != nil {
+			begin := l.position.Advance(literal[:start+err.offset])
+			escape := literal[start+err.offset : start+err.offset+err.length]
+			span := source.Span{File: l.file, Start: begin, End: begin.Advance(escape)}
+			return errors.SyntaxErrorAt(span, err.message, escape)
+		}
+	}
+	l.emit(String, literal)
+	return nil
+}
+
+// Unquote returns the value of a string literal produced by the lexer: the text between its quotes,
+// with its escape sequences replaced unless it is a raw string. Triple-quoted strings whose opening
+// quotes are followed by a newline are dedented.
+func Unquote(literal string) string {
+	raw := literal[0] == 'r'
+	if raw {
+		literal = literal[1:]
+	}
+	delim := stringDelimiter(literal)
+	content := literal[len(delim) : len(literal)-len(delim)]
+	if len(delim) == 3 {
+		content = dedent(content)
+	}
+	if raw {
+		return content
+	}
+	// The lexer has already checked that the escape sequences are valid.
+	s, _ := unescape(content)
+	return s
+}
+
+// escapeError describes an invalid escape sequence in the contents of a string literal.
+type escapeError struct {
+	// offset is the index of the escape sequence in the string's contents.
+	offset int
+	// length is the number of bytes in the escape sequence.
+	length  int
+	message string
+}
+
+// unescape returns s with its escape sequences replaced by the characters they represent. In addition
+// to the escapes in stringEscapes, "\xHH" and "\u{H...}" represent the Unicode code point with the
+// provided hexadecimal value; "\x" escapes can only represent the first 256 code points.
+func unescape(s string) (string, *escapeError) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+1 == len(s) {
+			return "", &escapeError{offset: i, length: 1, message: "invalid escape sequence"}
+		}
+		if e, ok := stringEscapes[s[i+1]]; ok {
+			sb.WriteString(e)
+			i++
+			continue
+		}
+		var r rune
+		var n int
+		var err *escapeError
+		switch s[i+1] {
+		case 'x':
+			r, n, err = unescapeHex(s, i)
+		case 'u':
+			r, n, err = unescapeUnicode(s, i)
+		default:
+			_, size := utf8.DecodeRuneInString(s[i+1:])
+			err = &escapeError{offset: i, length: 1 + size, message: "invalid escape sequence"}
+		}
+		if err != nil {
+			return "", err
+		}
+		sb.WriteRune(r)
+		i += n - 1
+	}
+	return sb.String(), nil
+}
+
+// unescapeHex returns the code point represented by the "\xHH" escape sequence at index i of s and the
+// length of the escape sequence.
+func unescapeHex(s string, i int) (rune, int, *escapeError) {
+	end := min(i+4, len(s))
+	digits := s[i+2 : end]
+	v, err := strconv.ParseUint(digits, 16, 8)
+	if len(digits) != 2 || err != nil {
+		return 0, 0, &escapeError{offset: i, length: 2 + hexPrefixLength(digits), message: "invalid escape sequence"}
+	}
+	return rune(v), 4, nil
+}
+
+// unescapeUnicode returns the code point represented by the "\u{H...}" escape sequence at index i of
+// s and the length of the escape sequence. The code point must have between one and six hexadecimal
+// digits.
+func unescapeUnicode(s string, i int) (rune, int, *escapeError) {
+	rest := s[i+2:]
+	if !strings.HasPrefix(rest, "{") {
+		return 0, 0, &escapeError{offset: i, length: 2, message: "invalid escape sequence"}
+	}
+	digits := hexPrefixLength(rest[1:])
+	if digits == 0 || digits > 6 || !strings.HasPrefix(rest[1+digits:], "}") {
+		return 0, 0, &escapeError{offset: i, length: 3 + digits, message: "invalid escape sequence"}
+	}
+	length := 4 + digits
+	v, _ := strconv.ParseUint(rest[1:1+digits], 16, 32)
+	if r := rune(v); utf8.ValidRune(r) {
+		return r, length, nil
+	}
+	return 0, 0, &escapeError{offset: i, length: length, message: "escape sequence is not a valid Unicode code point"}
+}
+
+// hexPrefixLength returns the number of hexadecimal digits at the start of s.
+func hexPrefixLength(s string) int {
+	n := 0
+	for n < len(s) && isHexDigit(s[n]) {
+		n++
+	}
+	return n
+}
+
+// dedent removes the indentation from the contents of a triple-quoted string whose opening quotes are
+// followed by a newline, so that multiline strings can be indented with the code around them. That
+// newline is removed, as is the last line if it only contains whitespace (so that the closing quotes
+// can be on their own line), and then the indentation that all of the other non-blank lines share is
+// removed from each of them. Contents that don't start with a newline are returned unchanged.
+func dedent(s string) string {
+	s, ok := strings.CutPrefix(s, "\n")
+	if !ok {
+		s, ok = strings.CutPrefix(s, "\r\n")
+	}
+	if !ok {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	if isBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	indent, found := "", false
+	for _, line := range lines {
+		if isBlank(line) {
+			continue
+		}
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !found {
+			indent, found = lead, true
+			continue
+		}
+		for !strings.HasPrefix(lead, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	}
+	for i, line := range lines {
+		if isBlank(line) {
+			lines[i] = ""
+		} else {
+			lines[i] = line[len(indent):]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isBlank returns whether a line only contains whitespace.
+func isBlank(line string) bool {
+	return strings.TrimLeft(line, " \t\r") == ""
+}
@@ -0,0 +1,202 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/source"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnquote(t *testing.T) {
+	tests := []struct {
+		name    string
+		literal string
+		want    string
+	}{
+		{name: "empty", literal: `""`, want: ""},
+		{name: "double_quotes", literal: `"a 'b' \"c\""`, want: `a 'b' "c"`},
+		{name: "single_quotes", literal: `'a "b" \'c\''`, want: `a "b" 'c'`},
+		{name: "escapes", literal: `"\\ \n \r \t"`, want: "\\ \n \r \t"},
+		{name: "hex_escape", literal: `"\x41\xe9"`, want: "Aé"},
+		{name: "unicode_escape", literal: `"\u{1F600} \u{e9} \u{00041}"`, want: "😀 é A"},
+		{name: "multibyte", literal: `"héllo, 世界"`, want: "héllo, 世界"},
+		{name: "raw", literal: `r"C:\new\table"`, want: `C:\new\table`},
+		{name: "raw_single_quotes", literal: `r'\d+\.\d*'`, want: `\d+\.\d*`},
+		{name: "triple_quotes", literal: `"""a "quoted" word"""`, want: `a "quoted" word`},
+		{name: "triple_single_quotes", literal: `'''it's'''`, want: "it's"},
+		{name: "triple_empty", literal: `""""""`, want: ""},
+		{name: "multiline", literal: "\"\"\"a\n  b\n\"\"\"", want: "a\n  b\n"},
+		{
+			name:    "dedent",
+			literal: "\"\"\"\n    SELECT *\n      FROM t\n\n    WHERE x\n    \"\"\"",
+			want:    "SELECT *\n  FROM t\n\nWHERE x",
+		},
+		{
+			name:    "dedent_mixed_indentation",
+			literal: "\"\"\"\n\t\t a\n\t\tb\n\t\"\"\"",
+			want:    " a\nb",
+		},
+		{
+			name:    "dedent_escapes",
+			literal: "\"\"\"\n  \\ta\\n\n  b\"\"\"",
+			want:    "\ta\n\nb",
+		},
+		{
+			name:    "dedent_raw",
+			literal: "r'''\n  \\d\n    \\w\n'''",
+			want:    "\\d\n  \\w",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens, err := tokenize("", tc.literal)
+			if err != nil {
+				t.Fatalf("tokenize() returned an unexpected error: %v", err)
+			}
+			if len(tokens) != 2 || tokens[0].Type != String || tokens[0].Value != tc.literal {
+				t.Fatalf("tokenize() did not return the literal as a single string token: %v", tokens)
+			}
+			if got := Unquote(tc.literal); got != tc.want {
+				t.Errorf("Unquote(%q) = %q, want %q", tc.literal, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeMultilineString(t *testing.T) {
+	code := "var s = '''\n  a\n  ''' + x"
+	buf, err := NewFileBuffer("a.slo", code)
+	if err != nil {
+		t.Fatalf("NewFileBuffer() returned an unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		buf.Pop()
+	}
+	wantString := Token{
+		Type:  String,
+		Value: "'''\n  a\n  '''",
+		Span: source.Span{
+			File:  "a.slo",
+			Start: source.Pos{Offset: 8, Line: 1, Column: 9},
+			End:   source.Pos{Offset: 21, Line: 3, Column: 6},
+		},
+	}
+	if diff := cmp.Diff(wantString, buf.Pop()); diff != "" {
+		t.Errorf("Pop() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := buf.LineNumber(); got != 3 {
+		t.Errorf("LineNumber() after the string = %d, want 3", got)
+	}
+}
+
+func TestTokenizeStringErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		wantErr   string
+		wantStart int
+		wantEnd   int
+	}{
+		{
+			name:      "unterminated_single_quotes",
+			code:      `x = 'abc`,
+			wantErr:   `SyntaxError: unterminated string on line 1: "'abc"`,
+			wantStart: 4,
+			wantEnd:   8,
+		},
+		{
+			name:      "mismatched_quotes",
+			code:      `"abc'`,
+			wantErr:   `SyntaxError: unterminated string on line 1: "\"abc'"`,
+			wantStart: 0,
+			wantEnd:   5,
+		},
+		{
+			name:      "unterminated_triple_quotes",
+			code:      "x = \"\"\"abc\ndef\"\"",
+			wantErr:   `SyntaxError: unterminated string on line 1: "\"\"\""`,
+			wantStart: 4,
+			wantEnd:   7,
+		},
+		{
+			name:      "unterminated_raw",
+			code:      `r"abc`,
+			wantErr:   `SyntaxError: unterminated string on line 1: "r\"abc"`,
+			wantStart: 0,
+			wantEnd:   5,
+		},
+		{
+			name:      "short_hex_escape",
+			code:      `"a\x4"`,
+			wantErr:   `SyntaxError: invalid escape sequence on line 1: "\\x4"`,
+			wantStart: 2,
+			wantEnd:   5,
+		},
+		{
+			name:      "invalid_hex_escape",
+			code:      `"\xZZ"`,
+			wantErr:   `SyntaxError: invalid escape sequence on line 1: "\\x"`,
+			wantStart: 1,
+			wantEnd:   3,
+		},
+		{
+			name:      "unicode_escape_without_braces",
+			code:      `"\u0041"`,
+			wantErr:   `SyntaxError: invalid escape sequence on line 1: "\\u"`,
+			wantStart: 1,
+			wantEnd:   3,
+		},
+		{
+			name:      "empty_unicode_escape",
+			code:      `"\u{}"`,
+			wantErr:   `SyntaxError: invalid escape sequence on line 1: "\\u{"`,
+			wantStart: 1,
+			wantEnd:   4,
+		},
+		{
+			name:      "unclosed_unicode_escape",
+			code:      `"\u{41"`,
+			wantErr:   `SyntaxError: invalid escape sequence on line 1: "\\u{41"`,
+			wantStart: 1,
+			wantEnd:   6,
+		},
+		{
+			name:      "long_unicode_escape",
+			code:      `"\u{0000041}"`,
+			wantErr:   `SyntaxError: invalid escape sequence on line 1: "\\u{0000041"`,
+			wantStart: 1,
+			wantEnd:   11,
+		},
+		{
+			name:      "invalid_code_point",
+			code:      `"\u{D800}"`,
+			wantErr:   `SyntaxError: escape sequence is not a valid Unicode code point on line 1: "\\u{D800}"`,
+			wantStart: 1,
+			wantEnd:   9,
+		},
+		{
+			name:      "invalid_escape_in_triple_quotes",
+			code:      "'''\nab\\q'''",
+			wantErr:   `SyntaxError: invalid escape sequence on line 2: "\\q"`,
+			wantStart: 6,
+			wantEnd:   8,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewFileBuffer("a.slo", tc.code)
+			if err == nil {
+				t.Fatalf("NewFileBuffer() did not return an error")
+			}
+			if got := err.Error(); got != tc.wantErr {
+				t.Errorf("NewFileBuffer() returned incorrect error: got %q, want %q", got, tc.wantErr)
+			}
+			span := err.(*errors.SlowError).Span()
+			if span.Start.Offset != tc.wantStart || span.End.Offset != tc.wantEnd {
+				t.Errorf("NewFileBuffer() returned an error at offsets %d-%d, want %d-%d",
+					span.Start.Offset, span.End.Offset, tc.wantStart, tc.wantEnd)
+			}
+		})
+	}
+}
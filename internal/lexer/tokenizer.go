@@ -2,8 +2,11 @@
 package lexer
 
 import (
-	"fmt"
-	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/source"
 )
 
 var tokenTypeStrings = map[TokenType]string{
@@ -45,6 +48,7 @@ const (
 	// declarations
 	Var
 	Const
+	Export
 
 	// Grouping
 	OpenParen
@@ -77,6 +81,13 @@ const (
 	Or
 	Xor
 
+	BitwiseNot
+	BitwiseAnd
+	BitwiseOr
+	BitwiseXor
+	LeftShift
+	RightShift
+
 	As
 
 	Assignment
@@ -86,6 +97,15 @@ const (
 	DivideEqual
 	ModEqual
 	FloorDivEqual
+	ExponentiateEqual
+	AndEqual
+	OrEqual
+	XorEqual
+	BitwiseAndEqual
+	BitwiseOrEqual
+	BitwiseXorEqual
+	LeftShiftEqual
+	RightShiftEqual
 
 	Dot
 	Colon
@@ -140,6 +160,7 @@ func init() {
 	// declarations
 	registerKeyword("var", Var)
 	registerKeyword("const", Const)
+	registerKeyword("export", Export)
 
 	// type casts
 	registerKeyword("as", As)
@@ -160,80 +181,94 @@ func IsReservedKeyword(s string) bool {
 	return ok
 }
 
-type handler func(*lexer, *regexp.Regexp)
-
-type matcher struct {
-	regex   *regexp.Regexp
-	handler handler
-}
-
-var matchers = []matcher{
-	// IMPORTANT: Order here matters. If one regex has another as a prefix, it must go first to ensure
-	// that it is correctly caught; for example, "//" must go before "/", otherwise the string "//"
-	// will be incorrectly parsed as {"/", "/"}.
-	{regexp.MustCompile(`\n`), defaultHandler(EOL, "\n")},
-	{regexp.MustCompile(`\s+`), skipHandler},
-	{regexp.MustCompile(`#.*`), commentHandler},
-	{regexp.MustCompile(`"[^"]*"`), stringHandler},
-	{regexp.MustCompile(`0x[\dA-Fa-f]+`), bytesHandler},
-	{regexp.MustCompile(`[0-9]+(\.[0-9]*)?u?`), numberHandler},
-	// Numbers starting with a "." need a different regex to ensure that they are followed by a digit.
-	{regexp.MustCompile(`\.[0-9]+([0-9]+)?`), numberHandler},
-	{regexp.MustCompile(`[a-zA-Z_][a-zA-Z0-9_]*`), symbolHandler},
-	{regexp.MustCompile(`\[`), defaultHandler(OpenBracket, "[")},
-	{regexp.MustCompile(`\]`), defaultHandler(CloseBracket, "]")},
-	{regexp.MustCompile(`\{`), defaultHandler(OpenCurlyBracket, "{")},
-	{regexp.MustCompile(`\}`), defaultHandler(CloseCurlyBracket, "}")},
-	{regexp.MustCompile(`\(`), defaultHandler(OpenParen, "(")},
-	{regexp.MustCompile(`\)`), defaultHandler(CloseParen, ")")},
-	{regexp.MustCompile(`==`), defaultHandler(Equals, "==")},
-	{regexp.MustCompile(`!=`), defaultHandler(NotEquals, "!=")},
-	{regexp.MustCompile(`=`), defaultHandler(Assignment, "=")},
-	{regexp.MustCompile(`!`), defaultHandler(Not, "!")},
-	{regexp.MustCompile(`<=`), defaultHandler(LessEqual, "<=")},
-	{regexp.MustCompile(`<`), defaultHandler(Less, "<")},
-	{regexp.MustCompile(`>=`), defaultHandler(GreaterEqual, ">=")},
-	{regexp.MustCompile(`>`), defaultHandler(Greater, ">")},
-	{regexp.MustCompile(`\|\|`), defaultHandler(Or, "||")},
-	{regexp.MustCompile(`&&`), defaultHandler(And, "&&")},
-	// {regexp.MustCompile(`\.\.`), defaultHandler(DOT_DOT, "..")},
-	{regexp.MustCompile(`\.`), defaultHandler(Dot, ".")},
-	// {regexp.MustCompile(`;`), defaultHandler(SEMI_COLON, ";")},
-	{regexp.MustCompile(`:`), defaultHandler(Colon, ":")},
-	// {regexp.MustCompile(`\?\?=`), defaultHandler(NULLISH_ASSIGNMENT, "??=")},
-	// {regexp.MustCompile(`\?`), defaultHandler(QUESTION, "?")},
-	{regexp.MustCompile(`,`), defaultHandler(Comma, ",")},
-	{regexp.MustCompile(`\+\+`), defaultHandler(PlusPlus, "++")},
-	{regexp.MustCompile(`--`), defaultHandler(MinusMinus, "--")},
-	// TODO: should these not be parsed as operators?
-	{regexp.MustCompile(`\+=`), defaultHandler(PlusEqual, "+=")},
-	{regexp.MustCompile(`-=`), defaultHandler(MinusEqual, "-=")},
-	{regexp.MustCompile(`\*=`), defaultHandler(TimesEqual, "*=")},
-	{regexp.MustCompile(`//=`), defaultHandler(FloorDivEqual, "//=")},
-	{regexp.MustCompile(`/=`), defaultHandler(DivideEqual, "/=")},
-	{regexp.MustCompile(`%=`), defaultHandler(ModEqual, "%=")},
-	{regexp.MustCompile(`\+`), defaultHandler(Plus, "+")},
-	{regexp.MustCompile(`-`), defaultHandler(Minus, "-")},
-	{regexp.MustCompile(`//`), defaultHandler(FloorDiv, "//")},
-	{regexp.MustCompile(`/`), defaultHandler(DividedBy, "/")},
-	{regexp.MustCompile(`\*`), defaultHandler(Times, "*")},
-	{regexp.MustCompile(`%`), defaultHandler(Mod, "%")},
-}
-
-// TODO: error on unclosed string somehow
+// punctuation maps each operator and punctuation mark to the type of its token. The scanner always
+// matches the longest one at the current position, so e.g. "**=" is never split into "**" and "=".
+var punctuation = map[string]TokenType{
+	"(": OpenParen,
+	")": CloseParen,
+	"[": OpenBracket,
+	"]": CloseBracket,
+	"{": OpenCurlyBracket,
+	"}": CloseCurlyBracket,
+
+	"!":  Not,
+	"++": PlusPlus,
+	"--": MinusMinus,
+
+	"+":  Plus,
+	"-":  Minus,
+	"*":  Times,
+	"/":  DividedBy,
+	"%":  Mod,
+	"//": FloorDiv,
+	"**": Exponentiate,
+
+	"==": Equals,
+	"!=": NotEquals,
+	"<":  Less,
+	"<=": LessEqual,
+	">":  Greater,
+	">=": GreaterEqual,
+
+	"&&": And,
+	"||": Or,
+	"^^": Xor,
+
+	"~":  BitwiseNot,
+	"&":  BitwiseAnd,
+	"|":  BitwiseOr,
+	"^":  BitwiseXor,
+	"<<": LeftShift,
+	">>": RightShift,
+
+	"=":   Assignment,
+	"+=":  PlusEqual,
+	"-=":  MinusEqual,
+	"*=":  TimesEqual,
+	"/=":  DivideEqual,
+	"%=":  ModEqual,
+	"//=": FloorDivEqual,
+	"**=": ExponentiateEqual,
+	"&&=": AndEqual,
+	"||=": OrEqual,
+	"^^=": XorEqual,
+	"&=":  BitwiseAndEqual,
+	"|=":  BitwiseOrEqual,
+	"^=":  BitwiseXorEqual,
+	"<<=": LeftShiftEqual,
+	">>=": RightShiftEqual,
+
+	".": Dot,
+	":": Colon,
+	",": Comma,
+}
+
+// maxPunctuationLength is the length of the longest string in punctuation.
+const maxPunctuationLength = 3
+
+func init() {
+	for value, kind := range punctuation {
+		tokenTypeStrings[kind] = value
+	}
+}
 
 type Token struct {
 	Type  TokenType
 	Value string
+	// Span is the location of the token in the source code.
+	Span source.Span
 }
 
 type lexer struct {
-	input  string
-	pos    int
-	tokens []Token
+	file     string
+	input    string
+	pos      int
+	position source.Pos
+	tokens   []Token
 }
 
 func (l *lexer) advance(n int) {
+	l.position = l.position.Advance(l.input[l.pos : l.pos+n])
 	l.pos += n
 }
 
@@ -241,86 +276,239 @@ func (l *lexer) remainder() string {
 	return l.input[l.pos:]
 }
 
-func (l *lexer) push(t Token) {
-	l.tokens = append(l.tokens, t)
+// emit adds a token with the provided value, which must be at the start of the remaining input, and
+// advances past it.
+func (l *lexer) emit(kind TokenType, value string) {
+	end := l.position.Advance(value)
+	l.tokens = append(l.tokens, Token{kind, value, source.Span{File: l.file, Start: l.position, End: end}})
+	l.position = end
+	l.pos += len(value)
 }
 
 func (l *lexer) atEOF() bool {
 	return l.pos >= len(l.input)
 }
 
-func tokenize(s string) []Token {
-	lex := &lexer{input: s}
+// spanOf returns the span of the provided text, which must be at the start of the remaining input.
+func (l *lexer) spanOf(text string) source.Span {
+	return source.Span{File: l.file, Start: l.position, End: l.position.Advance(text)}
+}
+
+// errorAt returns a SyntaxError for the provided text, which must be at the start of the remaining
+// input.
+func (l *lexer) errorAt(text, message string) error {
+	return errors.SyntaxErrorAt(l.spanOf(text), message, text)
+}
+
+// tokenize splits s into tokens in a single pass, returning a SyntaxError for the first piece of code
+// that isn't a valid token.
+func tokenize(file, s string) ([]Token, error) {
+	// Code averages roughly one token for every four bytes, so this avoids most of the cost of
+	// growing the slice of tokens.
+	lex := &lexer{file: file, input: s, position: source.Start, tokens: make([]Token, 0, len(s)/4+1)}
 	for !lex.atEOF() {
-		var matched bool
-		for _, m := range matchers {
-			loc := m.regex.FindStringIndex(lex.remainder())
-			if loc != nil && loc[0] == 0 {
-				m.handler(lex, m.regex)
-				matched = true
-				break // Exit the loop after the first match
+		if err := lex.scan(); err != nil {
+			return nil, err
+		}
+	}
+	lex.emit(EOF, "")
+	return lex.tokens, nil
+}
+
+// scan consumes the token or whitespace at the start of the remaining input.
+func (l *lexer) scan() error {
+	rest := l.remainder()
+	c := rest[0]
+	switch {
+	case c == '\n':
+		l.emit(EOL, "\n")
+	case isSpace(c):
+		l.advance(l.span(isSpace))
+	case c == '#':
+		// Comments run to the end of the line.
+		l.advance(l.span(func(c byte) bool { return c != '\n' }))
+	case isStringStart(rest):
+		return l.scanString()
+	case isDigit(c), c == '.' && len(rest) > 1 && isDigit(rest[1]):
+		return l.scanNumber()
+	case isLetter(c):
+		word := rest[:l.span(isWordChar)]
+		if kind, ok := keywords[word]; ok {
+			l.emit(kind, word)
+		} else {
+			l.emit(Symbol, word)
+		}
+	default:
+		for n := min(maxPunctuationLength, len(rest)); n > 0; n-- {
+			if kind, ok := punctuation[rest[:n]]; ok {
+				l.emit(kind, rest[:n])
+				return nil
+			}
+		}
+		r, _ := utf8.DecodeRuneInString(rest)
+		return l.errorAt(string(r), "unexpected character")
+	}
+	return nil
+}
+
+// span returns the number of bytes at the start of the remaining input that satisfy f.
+func (l *lexer) span(f func(byte) bool) int {
+	return l.spanFrom(0, f)
+}
+
+// spanFrom returns the index of the first byte at or after index i of the remaining input that does
+// not satisfy f, or the length of the remaining input if they all do.
+func (l *lexer) spanFrom(i int, f func(byte) bool) int {
+	rest := l.remainder()
+	for i < len(rest) && f(rest[i]) {
+		i++
+	}
+	return i
+}
+
+// prefixedDigits maps the prefixes of binary, octal, and hexadecimal literals to functions that
+// return whether a byte is a digit in that base.
+var prefixedDigits = map[string]func(byte) bool{
+	"0b": isBinaryDigit,
+	"0o": isOctalDigit,
+	"0x": isHexDigit,
+}
+
+// scanNumber consumes a numeric or bytes literal. Decimal literals may contain a fractional part and
+// an exponent, which make them floats, or end with "u" or "n", which make them uints or bigints. Any
+// decimal literal may end with "d", which makes it a decimal. Digits may be separated by underscores,
+// e.g. "1_000_000".
+func (l *lexer) scanNumber() error {
+	rest := l.remainder()
+	if len(rest) > 2 {
+		if isBaseDigit, ok := prefixedDigits[rest[:2]]; ok && isBaseDigit(rest[2]) {
+			return l.scanPrefixedNumber(rest[:2], isBaseDigit)
+		}
+	}
+	end := l.spanFrom(0, isDigitOrSeparator)
+	var isFloat bool
+	if end < len(rest) && rest[end] == '.' {
+		isFloat = true
+		end = l.spanFrom(end+1, isDigitOrSeparator)
+	}
+	if end < len(rest) && (rest[end] == 'e' || rest[end] == 'E') {
+		exp := end + 1
+		if exp < len(rest) && (rest[exp] == '+' || rest[exp] == '-') {
+			exp++
+		}
+		if exp < len(rest) && isDigit(rest[exp]) {
+			isFloat = true
+			end = l.spanFrom(exp, isDigitOrSeparator)
+		}
+	}
+	if end < len(rest) && rest[end] == 'd' {
+		end++
+	} else if end < len(rest) && (rest[end] == 'u' || rest[end] == 'n') {
+		end++
+		if isFloat {
+			if err := l.checkNumberEnd(rest[:end]); err != nil {
+				return err
 			}
+			return l.errorAt(rest[:end], "malformed number")
+		}
+	}
+	literal := rest[:end]
+	if err := l.checkNumberEnd(literal); err != nil {
+		return err
+	}
+	if !validSeparators(literal, isDigit) {
+		return l.errorAt(literal, "malformed number")
+	}
+	l.emit(Number, literal)
+	return nil
+}
+
+// scanPrefixedNumber consumes a binary, octal, or hexadecimal literal that starts with the provided
+// prefix. Binary and octal literals are ints unless they end with "u" or "n". Since "0x" also starts
+// bytes literals, hexadecimal literals are only numbers if they end with "i" (for ints), "u" (for
+// uints), or "n" (for bigints).
+func (l *lexer) scanPrefixedNumber(prefix string, isBaseDigit func(byte) bool) error {
+	rest := l.remainder()
+	end := l.spanFrom(len(prefix), func(c byte) bool { return isBaseDigit(c) || c == '_' })
+	digits := rest[len(prefix):end]
+	kind := Number
+	if end < len(rest) && (rest[end] == 'u' || rest[end] == 'n' || prefix == "0x" && rest[end] == 'i') {
+		end++
+	} else if prefix == "0x" {
+		kind = Bytes
+	}
+	literal := rest[:end]
+	if err := l.checkNumberEnd(literal); err != nil {
+		return err
+	}
+	if !validSeparators(digits, isBaseDigit) || kind == Bytes && strings.Contains(digits, "_") {
+		return l.errorAt(literal, "malformed number")
+	}
+	l.emit(kind, literal)
+	return nil
+}
+
+// validSeparators returns whether every underscore in the digits of a numeric literal is between two
+// digits.
+func validSeparators(digits string, isBaseDigit func(byte) bool) bool {
+	for i := 0; i < len(digits); i++ {
+		if digits[i] != '_' {
+			continue
 		}
-		if !matched {
-			// TODO: return an error
-			panic(fmt.Sprintf("lexer error: unrecognized token near '%v'", lex.remainder()))
+		if i == 0 || i == len(digits)-1 || !isBaseDigit(digits[i-1]) || !isBaseDigit(digits[i+1]) {
+			return false
 		}
 	}
-	lex.push(Token{EOF, ""})
-	return lex.tokens
+	return true
 }
 
-// Created a default handler which will simply create a token with the matched contents. This handler is used with most simple tokens.
-func defaultHandler(kind TokenType, value string) handler {
-	tokenTypeStrings[kind] = value
-	return func(lex *lexer, _ *regexp.Regexp) {
-		lex.advance(len(value))
-		lex.push(Token{kind, value})
+// checkNumberEnd returns a SyntaxError if the numeric literal at the start of the remaining input is
+// immediately followed by a letter, digit, underscore, or a decimal point and a digit (e.g. "12abc",
+// "0xZZ", or "1.2.3"). The error covers the entire malformed literal.
+func (l *lexer) checkNumberEnd(literal string) error {
+	end := l.spanFrom(len(literal), isNumberChar)
+	// A trailing "." that isn't followed by a digit isn't part of the literal, e.g. in "x[1.].y".
+	tail := strings.TrimRight(l.remainder()[len(literal):end], ".")
+	if tail == "" {
+		return nil
 	}
+	return l.errorAt(literal+tail, "malformed number")
 }
 
-func stringHandler(lex *lexer, regex *regexp.Regexp) {
-	match := regex.FindStringIndex(lex.remainder())
-	stringLiteral := lex.remainder()[match[0]:match[1]]
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\f'
+}
 
-	lex.push(Token{String, stringLiteral})
-	lex.advance(len(stringLiteral))
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
 }
 
-func bytesHandler(lex *lexer, regex *regexp.Regexp) {
-	match := regex.FindStringIndex(lex.remainder())
-	bytesLiteral := lex.remainder()[match[0]:match[1]]
+func isDigitOrSeparator(c byte) bool {
+	return isDigit(c) || c == '_'
+}
 
-	lex.push(Token{Bytes, bytesLiteral})
-	lex.advance(len(bytesLiteral))
+func isBinaryDigit(c byte) bool {
+	return c == '0' || c == '1'
 }
 
-func numberHandler(lex *lexer, regex *regexp.Regexp) {
-	match := regex.FindString(lex.remainder())
-	lex.push(Token{Number, match})
-	lex.advance(len(match))
+func isOctalDigit(c byte) bool {
+	return '0' <= c && c <= '7'
 }
 
-func symbolHandler(lex *lexer, regex *regexp.Regexp) {
-	match := regex.FindString(lex.remainder())
-	if kind, ok := keywords[match]; ok {
-		lex.push(Token{kind, match})
-	} else {
-		lex.push(Token{Symbol, match})
-	}
-	lex.advance(len(match))
+func isHexDigit(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
 }
 
-func skipHandler(lex *lexer, regex *regexp.Regexp) {
-	match := regex.FindStringIndex(lex.remainder())
-	lex.advance(match[1])
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
 }
 
-func commentHandler(lex *lexer, regex *regexp.Regexp) {
-	match := regex.FindStringIndex(lex.remainder())
-	if match != nil {
-		// Advance past the entire comment.
-		lex.advance(match[1])
-		// lex.line++
-	}
+func isWordChar(c byte) bool {
+	return isLetter(c) || isDigit(c)
+}
+
+// isNumberChar returns whether c could be part of a malformed numeric literal. Bytes of multibyte
+// runes are included so that letters from any alphabet are caught.
+func isNumberChar(c byte) bool {
+	return isWordChar(c) || c == '.' || c >= utf8.RuneSelf
 }
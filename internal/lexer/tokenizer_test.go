@@ -1,11 +1,24 @@
 package lexer
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/operators"
+	"github.com/chrispyles/slow/internal/source"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
+var ignoreSpans = cmpopts.IgnoreFields(Token{}, "Span")
+
+func withoutSpan(t Token) Token {
+	t.Span = source.Span{}
+	return t
+}
+
 func TestBuffer(t *testing.T) {
 	code := `func hailstone(x) {
 	var l = [x]
@@ -29,151 +42,154 @@ var y = .5
 
 	want := []Token{
 		// Line 1
-		{Func, "func"},
-		{Symbol, "hailstone"},
-		{OpenParen, "("},
-		{Symbol, "x"},
-		{CloseParen, ")"},
-		{OpenCurlyBracket, "{"},
-		{EOL, "\n"},
+		{Type: Func, Value: "func"},
+		{Type: Symbol, Value: "hailstone"},
+		{Type: OpenParen, Value: "("},
+		{Type: Symbol, Value: "x"},
+		{Type: CloseParen, Value: ")"},
+		{Type: OpenCurlyBracket, Value: "{"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 2
-		{Var, "var"},
-		{Symbol, "l"},
-		{Assignment, "="},
-		{OpenBracket, "["},
-		{Symbol, "x"},
-		{CloseBracket, "]"},
-		{EOL, "\n"},
+		{Type: Var, Value: "var"},
+		{Type: Symbol, Value: "l"},
+		{Type: Assignment, Value: "="},
+		{Type: OpenBracket, Value: "["},
+		{Type: Symbol, Value: "x"},
+		{Type: CloseBracket, Value: "]"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 3
-		{While, "while"},
-		{Symbol, "x"},
-		{NotEquals, "!="},
-		{Number, "1"},
-		{OpenCurlyBracket, "{"},
-		{EOL, "\n"},
+		{Type: While, Value: "while"},
+		{Type: Symbol, Value: "x"},
+		{Type: NotEquals, Value: "!="},
+		{Type: Number, Value: "1"},
+		{Type: OpenCurlyBracket, Value: "{"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 4
-		{If, "if"},
-		{Symbol, "x"},
-		{Mod, "%"},
-		{Number, "2"},
-		{Equals, "=="},
-		{Number, "0"},
-		{OpenCurlyBracket, "{"},
-		{EOL, "\n"},
+		{Type: If, Value: "if"},
+		{Type: Symbol, Value: "x"},
+		{Type: Mod, Value: "%"},
+		{Type: Number, Value: "2"},
+		{Type: Equals, Value: "=="},
+		{Type: Number, Value: "0"},
+		{Type: OpenCurlyBracket, Value: "{"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 5
-		{Symbol, "x"},
-		{FloorDivEqual, "//="},
-		{Number, "2"},
-		{EOL, "\n"},
+		{Type: Symbol, Value: "x"},
+		{Type: FloorDivEqual, Value: "//="},
+		{Type: Number, Value: "2"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 6
-		{CloseCurlyBracket, "}"},
-		{Else, "else"},
-		{OpenCurlyBracket, "{"},
-		{EOL, "\n"},
+		{Type: CloseCurlyBracket, Value: "}"},
+		{Type: Else, Value: "else"},
+		{Type: OpenCurlyBracket, Value: "{"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 7
-		{Symbol, "x"},
-		{Assignment, "="},
-		{Number, "3"},
-		{Times, "*"},
-		{Symbol, "x"},
-		{Plus, "+"},
-		{Number, "1"},
-		{EOL, "\n"},
+		{Type: Symbol, Value: "x"},
+		{Type: Assignment, Value: "="},
+		{Type: Number, Value: "3"},
+		{Type: Times, Value: "*"},
+		{Type: Symbol, Value: "x"},
+		{Type: Plus, Value: "+"},
+		{Type: Number, Value: "1"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 8
-		{CloseCurlyBracket, "}"},
-		{EOL, "\n"},
+		{Type: CloseCurlyBracket, Value: "}"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 9
-		{Symbol, "l"},
-		{Dot, "."},
-		{Symbol, "append"},
-		{OpenParen, "("},
-		{Symbol, "x"},
-		{CloseParen, ")"},
-		{EOL, "\n"},
+		{Type: Symbol, Value: "l"},
+		{Type: Dot, Value: "."},
+		{Type: Symbol, Value: "append"},
+		{Type: OpenParen, Value: "("},
+		{Type: Symbol, Value: "x"},
+		{Type: CloseParen, Value: ")"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 10
-		{CloseCurlyBracket, "}"},
-		{EOL, "\n"},
+		{Type: CloseCurlyBracket, Value: "}"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 11
-		{Return, "return"},
-		{Symbol, "l"},
-		{EOL, "\n"},
+		{Type: Return, Value: "return"},
+		{Type: Symbol, Value: "l"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 12
-		{CloseCurlyBracket, "}"},
-		{EOL, "\n"},
+		{Type: CloseCurlyBracket, Value: "}"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 13
-		{EOL, "\n"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 14
-		{For, "for"},
-		{Symbol, "x"},
-		{In, "in"},
-		{Symbol, "range"},
-		{OpenParen, "("},
-		{Number, "1"},
-		{Comma, ","},
-		{Number, "20"},
-		{CloseParen, ")"},
-		{OpenCurlyBracket, "{"},
-		{EOL, "\n"},
+		{Type: For, Value: "for"},
+		{Type: Symbol, Value: "x"},
+		{Type: In, Value: "in"},
+		{Type: Symbol, Value: "range"},
+		{Type: OpenParen, Value: "("},
+		{Type: Number, Value: "1"},
+		{Type: Comma, Value: ","},
+		{Type: Number, Value: "20"},
+		{Type: CloseParen, Value: ")"},
+		{Type: OpenCurlyBracket, Value: "{"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 15
-		{Symbol, "print"},
-		{OpenParen, "("},
-		{Symbol, "hailstone"},
-		{OpenParen, "("},
-		{Symbol, "x"},
-		{CloseParen, ")"},
-		{CloseParen, ")"},
-		{EOL, "\n"},
+		{Type: Symbol, Value: "print"},
+		{Type: OpenParen, Value: "("},
+		{Type: Symbol, Value: "hailstone"},
+		{Type: OpenParen, Value: "("},
+		{Type: Symbol, Value: "x"},
+		{Type: CloseParen, Value: ")"},
+		{Type: CloseParen, Value: ")"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 16
-		{CloseCurlyBracket, "}"},
-		{EOL, "\n"},
+		{Type: CloseCurlyBracket, Value: "}"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 17
-		{EOL, "\n"},
+		{Type: EOL, Value: "\n"},
 
 		// Line 18
-		{Var, "var"},
-		{Symbol, "y"},
-		{Assignment, "="},
-		{Number, ".5"},
-		{EOL, "\n"},
+		{Type: Var, Value: "var"},
+		{Type: Symbol, Value: "y"},
+		{Type: Assignment, Value: "="},
+		{Type: Number, Value: ".5"},
+		{Type: EOL, Value: "\n"},
 	}
 
-	buf := NewBuffer(code)
+	buf, err := NewBuffer(code)
+	if err != nil {
+		t.Fatalf("NewBuffer() returned an unexpected error: %v", err)
+	}
 
-	if got, want := buf.Current(), want[0]; got != want {
+	if got, want := withoutSpan(buf.Current()), want[0]; got != want {
 		t.Errorf("Current() returned incorrect value: got %q, want %q", got, want)
 	}
 
-	if got, want := buf.Pop(), want[0]; got != want {
+	if got, want := withoutSpan(buf.Pop()), want[0]; got != want {
 		t.Errorf("Pop() returned incorrect value: got %q, want %q", got, want)
 	}
 
-	if got, want := buf.Current(), want[1]; got != want {
+	if got, want := withoutSpan(buf.Current()), want[1]; got != want {
 		t.Errorf("Current() after Pop() returned incorrect value: got %q, want %q", got, want)
 	}
 
 	buf.MoveBack()
-	if got, want := buf.Current(), want[0]; got != want {
+	if got, want := withoutSpan(buf.Current()), want[0]; got != want {
 		t.Errorf("Current() after MoveBack() returned incorrect value: got %q, want %q", got, want)
 	}
 
 	for i := 0; i < 6; i++ {
-		if got, want := buf.Pop(), want[i]; got != want {
+		if got, want := withoutSpan(buf.Pop()), want[i]; got != want {
 			t.Errorf("Pop() in loop returned incorrect value: got %q, want %q", got, want)
 		}
 	}
@@ -183,12 +199,12 @@ var y = .5
 	}
 
 	buf.Pop()
-	if got, want := buf.Current(), want[7]; got != want {
+	if got, want := withoutSpan(buf.Current()), want[7]; got != want {
 		t.Errorf("Current() on code line 2 returned incorrect value: got %q, want %q", got, want)
 	}
 
 	buf.MoveBack()
-	if got, want := buf.Current(), want[6]; got != want {
+	if got, want := withoutSpan(buf.Current()), want[6]; got != want {
 		t.Errorf("Current() after second MoveBack() returned incorrect value: got %q, want %q", got, want)
 	}
 
@@ -197,7 +213,7 @@ var y = .5
 		popped = append(popped, buf.Pop())
 	}
 
-	if diff := cmp.Diff(want[6:], popped); diff != "" {
+	if diff := cmp.Diff(want[6:], popped, ignoreSpans); diff != "" {
 		t.Errorf("Buffer incorrectly tokenized contents (-want +got):\n%s", diff)
 	}
 }
@@ -206,20 +222,524 @@ func TestNoTrailingNewlineHandling(t *testing.T) {
 	code := "var y = .5"
 
 	want := []Token{
-		{Var, "var"},
-		{Symbol, "y"},
-		{Assignment, "="},
-		{Number, ".5"},
+		{Type: Var, Value: "var"},
+		{Type: Symbol, Value: "y"},
+		{Type: Assignment, Value: "="},
+		{Type: Number, Value: ".5"},
 	}
 
-	buf := NewBuffer(code)
+	buf, err := NewBuffer(code)
+	if err != nil {
+		t.Fatalf("NewBuffer() returned an unexpected error: %v", err)
+	}
 
 	var popped []Token
 	for buf.Current().Type != EOF {
 		popped = append(popped, buf.Pop())
 	}
 
+	if diff := cmp.Diff(want, popped, ignoreSpans); diff != "" {
+		t.Errorf("Buffer incorrectly tokenized contents (-want +got):\n%s", diff)
+	}
+}
+
+func TestSpans(t *testing.T) {
+	code := "var s = \"é\"\n  s.x"
+	pos := func(offset, line, col int) source.Pos {
+		return source.Pos{Offset: offset, Line: line, Column: col}
+	}
+	span := func(start, end source.Pos) source.Span {
+		return source.Span{File: "a.slo", Start: start, End: end}
+	}
+	want := []Token{
+		{Type: Var, Value: "var", Span: span(pos(0, 1, 1), pos(3, 1, 4))},
+		{Type: Symbol, Value: "s", Span: span(pos(4, 1, 5), pos(5, 1, 6))},
+		{Type: Assignment, Value: "=", Span: span(pos(6, 1, 7), pos(7, 1, 8))},
+		{Type: String, Value: "\"é\"", Span: span(pos(8, 1, 9), pos(12, 1, 12))},
+		{Type: EOL, Value: "\n", Span: span(pos(12, 1, 12), pos(13, 2, 1))},
+		{Type: Symbol, Value: "s", Span: span(pos(15, 2, 3), pos(16, 2, 4))},
+		{Type: Dot, Value: ".", Span: span(pos(16, 2, 4), pos(17, 2, 5))},
+		{Type: Symbol, Value: "x", Span: span(pos(17, 2, 5), pos(18, 2, 6))},
+		{Type: EOF, Value: "", Span: span(pos(18, 2, 6), pos(18, 2, 6))},
+	}
+
+	buf, err := NewFileBuffer("a.slo", code)
+	if err != nil {
+		t.Fatalf("NewFileBuffer() returned an unexpected error: %v", err)
+	}
+
+	var popped []Token
+	for {
+		popped = append(popped, buf.Pop())
+		if popped[len(popped)-1].Type == EOF {
+			break
+		}
+	}
+
 	if diff := cmp.Diff(want, popped); diff != "" {
+		t.Errorf("Buffer recorded incorrect spans (-want +got):\n%s", diff)
+	}
+}
+
+func TestBufferPastEOF(t *testing.T) {
+	buf, err := NewBuffer("a")
+	if err != nil {
+		t.Fatalf("NewBuffer() returned an unexpected error: %v", err)
+	}
+	buf.Pop()
+	buf.Pop()
+	buf.Pop()
+	if got := buf.Current().Type; got != EOF {
+		t.Errorf("Current() after popping past the end returned a %s token, want EOF", got)
+	}
+	buf.MoveBack()
+	buf.MoveBack()
+	if got := buf.Current().Type; got != EOF {
+		t.Errorf("Current() after moving back to the end returned a %s token, want EOF", got)
+	}
+	buf.MoveBack()
+	if got := buf.Current().Value; got != "a" {
+		t.Errorf("Current() after moving back before the end returned %q, want %q", got, "a")
+	}
+}
+
+func TestLineNumber(t *testing.T) {
+	buf, err := NewBuffer("a\n\nb")
+	if err != nil {
+		t.Fatalf("NewBuffer() returned an unexpected error: %v", err)
+	}
+	for i, want := range []int{1, 1, 2, 3, 3} {
+		if got := buf.LineNumber(); got != want {
+			t.Errorf("LineNumber() for token %d = %d, want %d", i, got, want)
+		}
+		if i < 4 {
+			buf.Pop()
+		}
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		wantErr   string
+		wantStart source.Pos
+		wantEnd   source.Pos
+	}{
+		{
+			name:      "unexpected_character",
+			code:      "var x = 1\nx @ 2",
+			wantErr:   `SyntaxError: unexpected character on line 2: "@"`,
+			wantStart: source.Pos{Offset: 12, Line: 2, Column: 3},
+			wantEnd:   source.Pos{Offset: 13, Line: 2, Column: 4},
+		},
+		{
+			name:      "unexpected_multibyte_character",
+			code:      "x = é",
+			wantErr:   `SyntaxError: unexpected character on line 1: "é"`,
+			wantStart: source.Pos{Offset: 4, Line: 1, Column: 5},
+			wantEnd:   source.Pos{Offset: 6, Line: 1, Column: 6},
+		},
+		{
+			name:      "unterminated_string",
+			code:      "print(\"abc)\nprint(1)",
+			wantErr:   `SyntaxError: unterminated string on line 1: "\"abc)"`,
+			wantStart: source.Pos{Offset: 6, Line: 1, Column: 7},
+			wantEnd:   source.Pos{Offset: 11, Line: 1, Column: 12},
+		},
+		{
+			name:      "string_with_newline",
+			code:      "\"abc\ndef\"",
+			wantErr:   `SyntaxError: unterminated string on line 1: "\"abc"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "unterminated_string_escaped_quote",
+			code:      `"abc\"`,
+			wantErr:   `SyntaxError: unterminated string on line 1: "\"abc\\\""`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 6, Line: 1, Column: 7},
+		},
+		{
+			name:      "invalid_escape",
+			code:      `x = "a\qb"`,
+			wantErr:   `SyntaxError: invalid escape sequence on line 1: "\\q"`,
+			wantStart: source.Pos{Offset: 6, Line: 1, Column: 7},
+			wantEnd:   source.Pos{Offset: 8, Line: 1, Column: 9},
+		},
+		{
+			name:      "number_followed_by_letters",
+			code:      "x = 12abc + 1",
+			wantErr:   `SyntaxError: malformed number on line 1: "12abc"`,
+			wantStart: source.Pos{Offset: 4, Line: 1, Column: 5},
+			wantEnd:   source.Pos{Offset: 9, Line: 1, Column: 10},
+		},
+		{
+			name:      "number_with_two_points",
+			code:      "1.2.3",
+			wantErr:   `SyntaxError: malformed number on line 1: "1.2.3"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 5, Line: 1, Column: 6},
+		},
+		{
+			name:      "float_uint",
+			code:      "1.5u",
+			wantErr:   `SyntaxError: malformed number on line 1: "1.5u"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "float_bigint",
+			code:      "1e3n",
+			wantErr:   `SyntaxError: malformed number on line 1: "1e3n"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "bytes_with_invalid_digits",
+			code:      "0xAG",
+			wantErr:   `SyntaxError: malformed number on line 1: "0xAG"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "trailing_separator",
+			code:      "1_ + 2",
+			wantErr:   `SyntaxError: malformed number on line 1: "1_"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 2, Line: 1, Column: 3},
+		},
+		{
+			name:      "double_separator",
+			code:      "1__000",
+			wantErr:   `SyntaxError: malformed number on line 1: "1__000"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 6, Line: 1, Column: 7},
+		},
+		{
+			name:      "separator_before_point",
+			code:      "1_.5",
+			wantErr:   `SyntaxError: malformed number on line 1: "1_.5"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "separator_before_exponent",
+			code:      "1_e5",
+			wantErr:   `SyntaxError: malformed number on line 1: "1_e5"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "separator_after_prefix",
+			code:      "0b_1",
+			wantErr:   `SyntaxError: malformed number on line 1: "0b_1"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "incomplete_exponent",
+			code:      "1e+",
+			wantErr:   `SyntaxError: malformed number on line 1: "1e"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 2, Line: 1, Column: 3},
+		},
+		{
+			name:      "float_exponent_uint",
+			code:      "1e5u",
+			wantErr:   `SyntaxError: malformed number on line 1: "1e5u"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "binary_with_invalid_digits",
+			code:      "0b102",
+			wantErr:   `SyntaxError: malformed number on line 1: "0b102"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 5, Line: 1, Column: 6},
+		},
+		{
+			name:      "octal_with_invalid_digits",
+			code:      "0o78",
+			wantErr:   `SyntaxError: malformed number on line 1: "0o78"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "int_suffix_on_octal",
+			code:      "0o7i",
+			wantErr:   `SyntaxError: malformed number on line 1: "0o7i"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "decimal_suffix_on_binary",
+			code:      "0b1d",
+			wantErr:   `SyntaxError: malformed number on line 1: "0b1d"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:      "decimal_with_uint_suffix",
+			code:      "1du",
+			wantErr:   `SyntaxError: malformed number on line 1: "1du"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 3, Line: 1, Column: 4},
+		},
+		{
+			name:      "bytes_with_separator",
+			code:      "0xAB_CD",
+			wantErr:   `SyntaxError: malformed number on line 1: "0xAB_CD"`,
+			wantStart: source.Pos{Offset: 0, Line: 1, Column: 1},
+			wantEnd:   source.Pos{Offset: 7, Line: 1, Column: 8},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewFileBuffer("a.slo", tc.code)
+			if err == nil {
+				t.Fatalf("NewFileBuffer() did not return an error")
+			}
+			if got := err.Error(); got != tc.wantErr {
+				t.Errorf("NewFileBuffer() returned incorrect error: got %q, want %q", got, tc.wantErr)
+			}
+			want := source.Span{File: "a.slo", Start: tc.wantStart, End: tc.wantEnd}
+			if diff := cmp.Diff(want, err.(*errors.SlowError).Span()); diff != "" {
+				t.Errorf("NewFileBuffer() returned an error with incorrect span (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTokenizeNumbers(t *testing.T) {
+	tests := []struct {
+		code string
+		want Token
+	}{
+		{code: "1_000_000", want: Token{Type: Number, Value: "1_000_000"}},
+		{code: "1_000u", want: Token{Type: Number, Value: "1_000u"}},
+		{code: "1e-9", want: Token{Type: Number, Value: "1e-9"}},
+		{code: "2.5E+10", want: Token{Type: Number, Value: "2.5E+10"}},
+		{code: "1_0.0_1e1_0", want: Token{Type: Number, Value: "1_0.0_1e1_0"}},
+		{code: ".5e3", want: Token{Type: Number, Value: ".5e3"}},
+		{code: "0b1010", want: Token{Type: Number, Value: "0b1010"}},
+		{code: "0b1010_1010u", want: Token{Type: Number, Value: "0b1010_1010u"}},
+		{code: "0o755", want: Token{Type: Number, Value: "0o755"}},
+		{code: "0xFFi", want: Token{Type: Number, Value: "0xFFi"}},
+		{code: "0xdead_beefu", want: Token{Type: Number, Value: "0xdead_beefu"}},
+		{code: "123_456n", want: Token{Type: Number, Value: "123_456n"}},
+		{code: "0b11n", want: Token{Type: Number, Value: "0b11n"}},
+		{code: "0xFFn", want: Token{Type: Number, Value: "0xFFn"}},
+		{code: "0xFF", want: Token{Type: Bytes, Value: "0xFF"}},
+		{code: "1_000.50d", want: Token{Type: Number, Value: "1_000.50d"}},
+		{code: "5d", want: Token{Type: Number, Value: "5d"}},
+		{code: "1.5e-3d", want: Token{Type: Number, Value: "1.5e-3d"}},
+		{code: "0x1d", want: Token{Type: Bytes, Value: "0x1d"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			tokens, err := tokenize("", tc.code)
+			if err != nil {
+				t.Fatalf("tokenize() returned an unexpected error: %v", err)
+			}
+			want := []Token{tc.want, {Type: EOF, Value: ""}}
+			if diff := cmp.Diff(want, tokens, ignoreSpans); diff != "" {
+				t.Errorf("tokenize() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTokenizeEscapedQuote(t *testing.T) {
+	buf, err := NewBuffer(`"a\"b" + "c"`)
+	if err != nil {
+		t.Fatalf("NewBuffer() returned an unexpected error: %v", err)
+	}
+	want := []Token{
+		{Type: String, Value: `"a\"b"`},
+		{Type: Plus, Value: "+"},
+		{Type: String, Value: `"c"`},
+	}
+	var popped []Token
+	for buf.Current().Type != EOF {
+		popped = append(popped, buf.Pop())
+	}
+	if diff := cmp.Diff(want, popped, ignoreSpans); diff != "" {
 		t.Errorf("Buffer incorrectly tokenized contents (-want +got):\n%s", diff)
 	}
 }
+
+func TestTokenizeOperators(t *testing.T) {
+	var ops []string
+	for op := range operators.AllBinaryOperators() {
+		ops = append(ops, op.String())
+	}
+	for op := range operators.AllUnaryOperators() {
+		ops = append(ops, op.String())
+	}
+	for _, op := range ops {
+		t.Run(op, func(t *testing.T) {
+			tokens, err := tokenize("", "a "+op+" b")
+			if err != nil {
+				t.Fatalf("tokenize() returned an unexpected error: %v", err)
+			}
+			if len(tokens) != 4 || tokens[1].Value != op {
+				t.Errorf("tokenize() did not return the operator as a single token: %v", tokens)
+			}
+			if got := tokens[1].Type.String(); got != op {
+				t.Errorf("String() of the operator's token type returned %q, want %q", got, op)
+			}
+		})
+	}
+}
+
+func TestTokenizeLongestMatch(t *testing.T) {
+	tokens, err := tokenize("", "x**=2**3//=4^^y&&=z||=!w")
+	if err != nil {
+		t.Fatalf("tokenize() returned an unexpected error: %v", err)
+	}
+	want := []Token{
+		{Type: Symbol, Value: "x"},
+		{Type: ExponentiateEqual, Value: "**="},
+		{Type: Number, Value: "2"},
+		{Type: Exponentiate, Value: "**"},
+		{Type: Number, Value: "3"},
+		{Type: FloorDivEqual, Value: "//="},
+		{Type: Number, Value: "4"},
+		{Type: Xor, Value: "^^"},
+		{Type: Symbol, Value: "y"},
+		{Type: AndEqual, Value: "&&="},
+		{Type: Symbol, Value: "z"},
+		{Type: OrEqual, Value: "||="},
+		{Type: Not, Value: "!"},
+		{Type: Symbol, Value: "w"},
+		{Type: EOF, Value: ""},
+	}
+	if diff := cmp.Diff(want, tokens, ignoreSpans); diff != "" {
+		t.Errorf("tokenize() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestTokenizeBitwiseOperators(t *testing.T) {
+	tokens, err := tokenize("", "a<<=b>>c&d&&e|=~f^g^^h>>=i")
+	if err != nil {
+		t.Fatalf("tokenize() returned an unexpected error: %v", err)
+	}
+	want := []Token{
+		{Type: Symbol, Value: "a"},
+		{Type: LeftShiftEqual, Value: "<<="},
+		{Type: Symbol, Value: "b"},
+		{Type: RightShift, Value: ">>"},
+		{Type: Symbol, Value: "c"},
+		{Type: BitwiseAnd, Value: "&"},
+		{Type: Symbol, Value: "d"},
+		{Type: And, Value: "&&"},
+		{Type: Symbol, Value: "e"},
+		{Type: BitwiseOrEqual, Value: "|="},
+		{Type: BitwiseNot, Value: "~"},
+		{Type: Symbol, Value: "f"},
+		{Type: BitwiseXor, Value: "^"},
+		{Type: Symbol, Value: "g"},
+		{Type: Xor, Value: "^^"},
+		{Type: Symbol, Value: "h"},
+		{Type: RightShiftEqual, Value: ">>="},
+		{Type: Symbol, Value: "i"},
+		{Type: EOF, Value: ""},
+	}
+	if diff := cmp.Diff(want, tokens, ignoreSpans); diff != "" {
+		t.Errorf("tokenize() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestTokenizeTrailingWhitespace(t *testing.T) {
+	tokens, err := tokenize("", "a  \t\nb")
+	if err != nil {
+		t.Fatalf("tokenize() returned an unexpected error: %v", err)
+	}
+	want := []Token{
+		{Type: Symbol, Value: "a"},
+		{Type: EOL, Value: "\n"},
+		{Type: Symbol, Value: "b"},
+		{Type: EOF, Value: ""},
+	}
+	if diff := cmp.Diff(want, tokens, ignoreSpans); diff != "" {
+		t.Errorf("tokenize() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+// TestTokenizeMatchesRegexTokenizer checks that the scanner tokenizes code that only uses the tokens
+// the regex-based tokenizer supported in the same way, and reports the same errors.
+func TestTokenizeMatchesRegexTokenizer(t *testing.T) {
+	codes := []string{
+		benchmarkCode,
+		"var s = \"é\\t\\\"x\\\"\"\n  s.x",
+		"x[1.].y + .5 - 1.5 * 2u // 0xFf # comment\n",
+		"a.0 + 1. + x.y.z",
+		"x @ 2",
+		"\"unterminated",
+		"\"unterminated\\",
+		"\"bad \\q escape\"",
+		"12abc",
+		"1.2.3",
+		"1.5u",
+		".5u",
+		"0x",
+		"0xZZ",
+		"1é",
+	}
+	for _, code := range codes {
+		t.Run(code, func(t *testing.T) {
+			want, wantErr := regexTokenize("a.slo", code)
+			got, err := tokenize("a.slo", code)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("tokenize() returned unexpected diff (-regex +scanner):\n%s", diff)
+			}
+			if diff := cmp.Diff(wantErr, err, cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+				t.Errorf("tokenize() returned unexpected error diff (-regex +scanner):\n%s", diff)
+			}
+		})
+	}
+}
+
+const benchmarkCode = `# Computes hailstone sequences.
+func hailstone(x) {
+	var l = [x]
+	while x != 1 {
+		if x % 2 == 0 {
+			x //= 2
+		} else {
+			x = 3 * x + 1
+		}
+		l.append(x)
+	}
+	return l
+}
+
+for x in range(1, 20) {
+	print("hailstone", x, hailstone(x), 0xFF, 1.5, 2u)
+}
+`
+
+func BenchmarkTokenize(b *testing.B) {
+	tokenizers := []struct {
+		name     string
+		tokenize func(file, s string) ([]Token, error)
+	}{
+		{name: "scanner", tokenize: tokenize},
+		{name: "regex", tokenize: regexTokenize},
+	}
+	for _, copies := range []int{1, 10, 100} {
+		code := strings.Repeat(benchmarkCode, copies)
+		for _, tk := range tokenizers {
+			b.Run(fmt.Sprintf("%s/copies=%d", tk.name, copies), func(b *testing.B) {
+				b.SetBytes(int64(len(code)))
+				for i := 0; i < b.N; i++ {
+					if _, err := tk.tokenize("", code); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
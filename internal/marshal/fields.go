@@ -0,0 +1,66 @@
+package marshal
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// field is an exported struct field that is visible to Slow.
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields returns the fields of the provided struct type that are visible to Slow. A field's
+// name is its name with the first word lowercased (e.g. "FirstName" becomes "firstName"), unless
+// it is overridden by a `slow:"name"` struct tag. Fields tagged with `slow:"-"` are skipped, and
+// fields whose tag contains the "omitempty" option are skipped when converting a zero value to
+// Slow. The fields of embedded structs are promoted.
+func structFields(t reflect.Type) []field {
+	var fs []field
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || (sf.Anonymous && indirect(sf.Type).Kind() == reflect.Struct) {
+			continue
+		}
+		f := field{name: AttributeName(sf.Name), index: sf.Index}
+		if tag, ok := sf.Tag.Lookup("slow"); ok {
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if name != "" {
+				f.name = name
+			}
+			f.omitEmpty = opts == "omitempty"
+		}
+		fs = append(fs, f)
+	}
+	return fs
+}
+
+// AttributeName converts the name of an exported Go identifier into the name that is used for it
+// in Slow by lowercasing its first word, e.g. "ReadAll" becomes "readAll" and "URLPath" becomes
+// "urlPath".
+func AttributeName(name string) string {
+	rs := []rune(name)
+	for i := range rs {
+		if !unicode.IsUpper(rs[i]) {
+			break
+		}
+		// Leave the last capital letter of an initialism alone if it begins the next word.
+		if i > 0 && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+			break
+		}
+		rs[i] = unicode.ToLower(rs[i])
+	}
+	return string(rs)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
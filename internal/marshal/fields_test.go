@@ -0,0 +1,44 @@
+package marshal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAttributeName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "X", want: "x"},
+		{in: "ID", want: "id"},
+		{in: "Name", want: "name"},
+		{in: "ReadAll", want: "readAll"},
+		{in: "URLPath", want: "urlPath"},
+		{in: "HTTPServer2", want: "httpServer2"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := AttributeName(tc.in); got != tc.want {
+				t.Errorf("AttributeName(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	want := []field{
+		{name: "name", index: []int{0, 0}},
+		{name: "age", index: []int{0, 1}},
+		{name: "nickname", index: []int{0, 2}, omitEmpty: true},
+		{name: "mail", index: []int{0, 3}},
+		{name: "tags", index: []int{0, 5}, omitEmpty: true},
+		{name: "title", index: []int{1}},
+	}
+	got := structFields(reflect.TypeFor[employee]())
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(field{})); diff != "" {
+		t.Errorf("structFields() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,439 @@
+package marshal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// FromValue stores the Go representation of the Slow value v in the value pointed to by dst, which
+// must be a non-nil pointer. Conversions are the inverse of those made by ToValue; additionally:
+//
+//   - any numeric value can be stored in a Go number as long as it does not overflow
+//   - any integer, or float without a fractional part, can be stored in a *big.Int
+//   - a str or bytes can be stored in a []byte
+//   - a str in RFC 3339 format can be stored in a time.Time
+//   - any iterable value can be stored in a slice or array
+//   - a map with str keys can be stored in a struct; its fields are matched using the same names as
+//     ToValue and may be renamed with a `slow:"name"` tag or skipped with `slow:"-"`
+//   - values stored in an interface{} use their natural Go representation: null is nil, bools,
+//     ints, uints, bigints, floats, strs, bytes, datetimes, and durations are bool, int64, uint64,
+//     *big.Int, float64, string, []byte, time.Time, and time.Duration, lists are []any, and maps
+//     are map[string]any if every key is a str and map[any]any otherwise
+//
+// Any value that can't be converted results in a TypeError.
+func FromValue(v execute.Value, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.TypeErrorFromMessage(fmt.Sprintf("FromValue requires a non-nil pointer, not %T", dst))
+	}
+	return fromValue(v, rv.Elem())
+}
+
+// fromValue stores v in the settable value dst.
+func fromValue(v execute.Value, dst reflect.Value) error {
+	t := dst.Type()
+	if t == valueType {
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+	if o, ok := v.(*Object); ok {
+		if o.value.Type().AssignableTo(t) {
+			dst.Set(o.value)
+			return nil
+		} else if o.value.Elem().Type().AssignableTo(t) {
+			dst.Set(o.value.Elem())
+			return nil
+		}
+	}
+	if t == bigIntType {
+		if !isNumber(v) {
+			return typeError(v, t)
+		}
+		if hasFraction(v) {
+			return errors.TypeErrorFromMessage(fmt.Sprintf("%s cannot be used as Go type %q without losing precision", v, t))
+		}
+		b, err := types.ToBigInt(v)
+		if err != nil {
+			return overflowError(v, t)
+		}
+		dst.Set(reflect.ValueOf(b))
+		return nil
+	}
+	if t == timeType {
+		if dt, ok := v.(*types.Datetime); ok {
+			dst.Set(reflect.ValueOf(dt.Value()))
+			return nil
+		}
+		s, ok := v.(*types.Str)
+		if !ok {
+			return typeError(v, t)
+		}
+		tm, err := time.Parse(time.RFC3339Nano, s.Value())
+		if err != nil {
+			return errors.TypeErrorFromMessage(fmt.Sprintf("%s cannot be parsed as a time: %v", v, err))
+		}
+		dst.Set(reflect.ValueOf(tm))
+		return nil
+	}
+	if d, ok := v.(*types.Duration); ok && t == durationType {
+		dst.Set(reflect.ValueOf(d.Value()))
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		b, ok := v.(*types.Bool)
+		if !ok {
+			return typeError(v, t)
+		}
+		dst.SetBool(b.ToBool())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !isNumber(v) {
+			return typeError(v, t)
+		}
+		if floatOutOfRange(v, math.MinInt64, 1<<63) {
+			return overflowError(v, t)
+		}
+		if hasFraction(v) {
+			return errors.TypeErrorFromMessage(fmt.Sprintf("%s cannot be used as Go type %q without losing precision", v, t))
+		}
+		var n int64
+		if v.Type() == types.FloatType {
+			n = int64(must(v.ToFloat()))
+		} else if v.Type() == types.UintType && must(v.ToUint()) > math.MaxInt64 {
+			return overflowError(v, t)
+		} else if (v.Type() == types.BigIntType || v.Type() == types.DecimalType) && !must(types.ToBigInt(v)).IsInt64() {
+			return overflowError(v, t)
+		} else {
+			n = must(v.ToInt())
+		}
+		if dst.OverflowInt(n) {
+			return overflowError(v, t)
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !isNumber(v) {
+			return typeError(v, t)
+		}
+		if f := must(v.ToFloat()); f < 0 || floatOutOfRange(v, 0, 1<<64) {
+			return overflowError(v, t)
+		} else if hasFraction(v) {
+			return errors.TypeErrorFromMessage(fmt.Sprintf("%s cannot be used as Go type %q without losing precision", v, t))
+		}
+		if (v.Type() == types.BigIntType || v.Type() == types.DecimalType) && !must(types.ToBigInt(v)).IsUint64() {
+			return overflowError(v, t)
+		}
+		var n uint64
+		if v.Type() == types.FloatType {
+			n = uint64(must(v.ToFloat()))
+		} else {
+			n = must(v.ToUint())
+		}
+		if dst.OverflowUint(n) {
+			return overflowError(v, t)
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if !isNumber(v) {
+			return typeError(v, t)
+		}
+		dst.SetFloat(must(v.ToFloat()))
+		return nil
+	case reflect.String:
+		s, ok := v.(*types.Str)
+		if !ok {
+			return typeError(v, t)
+		}
+		dst.SetString(s.Value())
+		return nil
+	case reflect.Interface:
+		if v == types.Null {
+			dst.SetZero()
+			return nil
+		}
+		nat, err := natural(v)
+		if err != nil {
+			return err
+		}
+		rn := reflect.ValueOf(nat)
+		if !rn.Type().AssignableTo(t) {
+			return typeError(v, t)
+		}
+		dst.Set(rn)
+		return nil
+	case reflect.Pointer:
+		if v == types.Null {
+			dst.SetZero()
+			return nil
+		}
+		p := reflect.New(t.Elem())
+		if err := fromValue(v, p.Elem()); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	case reflect.Slice:
+		if v == types.Null {
+			dst.SetZero()
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 && (v.Type() == types.BytesType || v.Type() == types.StrType) {
+			dst.SetBytes(must(v.ToBytes()))
+			return nil
+		}
+		vs, err := iterate(v, t)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, len(vs), len(vs))
+		for i, e := range vs {
+			if err := fromValue(e, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+		return nil
+	case reflect.Array:
+		vs, err := iterate(v, t)
+		if err != nil {
+			return err
+		}
+		if len(vs) != t.Len() {
+			return errors.TypeErrorFromMessage(fmt.Sprintf("a value of length %d cannot be used as Go type %q", len(vs), t))
+		}
+		for i, e := range vs {
+			if err := fromValue(e, dst.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if v == types.Null {
+			dst.SetZero()
+			return nil
+		}
+		m, ok := v.(*types.Map)
+		if !ok {
+			return typeError(v, t)
+		}
+		out := reflect.MakeMap(t)
+		err := eachEntry(m, func(k, e execute.Value) error {
+			gk, ge := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+			if err := fromValue(k, gk); err != nil {
+				return err
+			}
+			if err := fromValue(e, ge); err != nil {
+				return err
+			}
+			out.SetMapIndex(gk, ge)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		dst.Set(out)
+		return nil
+	case reflect.Struct:
+		m, ok := v.(*types.Map)
+		if !ok {
+			return typeError(v, t)
+		}
+		for _, f := range structFields(t) {
+			e, err := m.Get(types.NewStr(f.name), types.Null)
+			if err != nil {
+				return err
+			}
+			if e == types.Null {
+				continue
+			}
+			fv, err := dst.FieldByIndexErr(f.index)
+			if err != nil {
+				// The field is promoted through a nil embedded pointer; allocate it.
+				fv = fieldByIndexAlloc(dst, f.index)
+			}
+			if err := fromValue(e, fv); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return typeError(v, t)
+}
+
+// natural returns the natural Go representation of v, as described in FromValue.
+func natural(v execute.Value) (any, error) {
+	switch v := v.(type) {
+	case *types.Bool:
+		return v.ToBool(), nil
+	case *types.Int:
+		return must(v.ToInt()), nil
+	case *types.Uint:
+		return must(v.ToUint()), nil
+	case *types.BigInt:
+		return new(big.Int).Set(v.Value()), nil
+	case *types.Float:
+		return must(v.ToFloat()), nil
+	case *types.Str:
+		return v.Value(), nil
+	case *types.Bytes:
+		return must(v.ToBytes()), nil
+	case *types.Datetime:
+		return v.Value(), nil
+	case *types.Duration:
+		return v.Value(), nil
+	case *types.List:
+		vs, err := iterate(v, reflect.TypeFor[[]any]())
+		if err != nil {
+			return nil, err
+		}
+		out := make([]any, len(vs))
+		for i, e := range vs {
+			if e == types.Null {
+				continue
+			}
+			if out[i], err = natural(e); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case *types.Map:
+		strKeys := true
+		eachEntry(v, func(k, _ execute.Value) error {
+			_, ok := k.(*types.Str)
+			strKeys = strKeys && ok
+			return nil
+		})
+		if strKeys {
+			out := make(map[string]any)
+			err := eachEntry(v, func(k, e execute.Value) error {
+				ne, err := naturalOrNil(e)
+				out[k.(*types.Str).Value()] = ne
+				return err
+			})
+			return out, err
+		}
+		out := make(map[any]any)
+		err := eachEntry(v, func(k, e execute.Value) error {
+			nk, err := natural(k)
+			if err != nil {
+				return err
+			}
+			if !reflect.TypeOf(nk).Comparable() {
+				return errors.UnhashableTypeError(k.Type())
+			}
+			ne, err := naturalOrNil(e)
+			out[nk] = ne
+			return err
+		})
+		return out, err
+	case *Object:
+		return v.Interface(), nil
+	}
+	return v, nil
+}
+
+func naturalOrNil(v execute.Value) (any, error) {
+	if v == types.Null {
+		return nil, nil
+	}
+	return natural(v)
+}
+
+// iterate collects the values yielded by iterating over v.
+func iterate(v execute.Value, t reflect.Type) ([]execute.Value, error) {
+	it, err := v.ToIterator()
+	if err != nil {
+		return nil, typeError(v, t)
+	}
+	var vs []execute.Value
+	for it.HasNext() {
+		e, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		vs = append(vs, e)
+	}
+	return vs, nil
+}
+
+// eachEntry calls f with each key-value pair in m.
+func eachEntry(m *types.Map, f func(k, v execute.Value) error) error {
+	it := must(m.ToIterator())
+	for it.HasNext() {
+		k, err := it.Next()
+		if err != nil {
+			return err
+		}
+		v, err := m.Get(k, nil)
+		if err != nil {
+			return err
+		}
+		if err := f(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func isNumber(v execute.Value) bool {
+	t := v.Type()
+	return t == types.BigIntType || t == types.BoolType || t == types.DecimalType || t == types.FloatType || t == types.IntType || t == types.UintType
+}
+
+// hasFraction returns whether the number v has a nonzero fractional part.
+func hasFraction(v execute.Value) bool {
+	switch v := v.(type) {
+	case *types.Float:
+		return v.HasRemainder()
+	case *types.Decimal:
+		return !v.Value().IsInteger()
+	}
+	return false
+}
+
+// floatOutOfRange returns whether v is a float that is NaN, infinite, or outside of [lo, hi), and so
+// can't be converted to a Go integer type without overflowing.
+func floatOutOfRange(v execute.Value, lo, hi float64) bool {
+	if v.Type() != types.FloatType {
+		return false
+	}
+	f := must(v.ToFloat())
+	return !(f >= lo && f < hi)
+}
+
+func typeError(v execute.Value, t reflect.Type) error {
+	return errors.NewTypeError(v.Type(), t)
+}
+
+func overflowError(v execute.Value, t reflect.Type) error {
+	return errors.TypeErrorFromMessage(fmt.Sprintf("%s overflows Go type %q", v, t))
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
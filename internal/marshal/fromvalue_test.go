@@ -0,0 +1,387 @@
+package marshal
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+var equateBigInts = cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 })
+
+func mustMap(t *testing.T, kvs ...execute.Value) *types.Map {
+	t.Helper()
+	m := types.NewMap()
+	for i := 0; i < len(kvs); i += 2 {
+		if _, err := m.Set(kvs[i], kvs[i+1]); err != nil {
+			t.Fatalf("Map.Set() returned an unexpected error: %v", err)
+		}
+	}
+	return m
+}
+
+func TestFromValue(t *testing.T) {
+	type wrapper struct {
+		P *person
+	}
+	tests := []struct {
+		name    string
+		in      execute.Value
+		dst     func() any
+		want    any
+		wantErr error
+	}{
+		{
+			name: "bool",
+			in:   types.NewBool(true),
+			dst:  func() any { return new(bool) },
+			want: true,
+		},
+		{
+			name:    "bool_from_int",
+			in:      types.NewInt(1),
+			dst:     func() any { return new(bool) },
+			wantErr: errors.NewTypeError(types.IntType, reflect.TypeFor[bool]()),
+		},
+		{
+			name:    "bool_from_str",
+			in:      types.NewStr("false"),
+			dst:     func() any { return new(bool) },
+			wantErr: errors.NewTypeError(types.StrType, reflect.TypeFor[bool]()),
+		},
+		{
+			name: "int",
+			in:   types.NewInt(-2),
+			dst:  func() any { return new(int16) },
+			want: int16(-2),
+		},
+		{
+			name: "int_from_float",
+			in:   types.NewFloat(2),
+			dst:  func() any { return new(int) },
+			want: 2,
+		},
+		{
+			name:    "int_from_fractional_float",
+			in:      types.NewFloat(2.5),
+			dst:     func() any { return new(int) },
+			wantErr: errors.TypeErrorFromMessage(`2.5 cannot be used as Go type "int" without losing precision`),
+		},
+		{
+			name:    "int_overflow",
+			in:      types.NewInt(300),
+			dst:     func() any { return new(int8) },
+			wantErr: errors.TypeErrorFromMessage(`300 overflows Go type "int8"`),
+		},
+		{
+			name:    "int_from_large_uint",
+			in:      types.NewUint(math.MaxUint64),
+			dst:     func() any { return new(int64) },
+			wantErr: errors.TypeErrorFromMessage(`18446744073709551615u overflows Go type "int64"`),
+		},
+		{
+			name:    "int_from_large_bigint",
+			in:      types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64)),
+			dst:     func() any { return new(int64) },
+			wantErr: errors.TypeErrorFromMessage(`18446744073709551616n overflows Go type "int64"`),
+		},
+		{
+			name: "int_from_decimal",
+			in:   types.NewDecimal(decimal.MustParse("12.00")),
+			dst:  func() any { return new(int) },
+			want: 12,
+		},
+		{
+			name:    "int_from_fractional_decimal",
+			in:      types.NewDecimal(decimal.MustParse("0.01")),
+			dst:     func() any { return new(int) },
+			wantErr: errors.TypeErrorFromMessage(`0.01d cannot be used as Go type "int" without losing precision`),
+		},
+		{
+			name:    "int_from_large_decimal",
+			in:      types.NewDecimal(decimal.MustParse("1e20")),
+			dst:     func() any { return new(int64) },
+			wantErr: errors.TypeErrorFromMessage(`100000000000000000000d overflows Go type "int64"`),
+		},
+		{
+			name:    "int_from_large_float",
+			in:      types.NewFloat(1e30),
+			dst:     func() any { return new(int64) },
+			wantErr: errors.TypeErrorFromMessage(`1e+30 overflows Go type "int64"`),
+		},
+		{
+			name:    "int_from_float_at_limit",
+			in:      types.NewFloat(1 << 63),
+			dst:     func() any { return new(int64) },
+			wantErr: errors.TypeErrorFromMessage(`9.223372036854776e+18 overflows Go type "int64"`),
+		},
+		{
+			name: "int_from_float_min",
+			in:   types.NewFloat(math.MinInt64),
+			dst:  func() any { return new(int64) },
+			want: int64(math.MinInt64),
+		},
+		{
+			name:    "int_from_nan",
+			in:      types.NewFloat(math.NaN()),
+			dst:     func() any { return new(int) },
+			wantErr: errors.TypeErrorFromMessage(`NaN overflows Go type "int"`),
+		},
+		{
+			name:    "int_from_inf",
+			in:      types.NewFloat(math.Inf(-1)),
+			dst:     func() any { return new(int) },
+			wantErr: errors.TypeErrorFromMessage(`-Inf overflows Go type "int"`),
+		},
+		{
+			name:    "int_from_str",
+			in:      types.NewStr("1"),
+			dst:     func() any { return new(int) },
+			wantErr: errors.NewTypeError(types.StrType, types.IntType),
+		},
+		{
+			name: "uint",
+			in:   types.NewInt(7),
+			dst:  func() any { return new(uint8) },
+			want: uint8(7),
+		},
+		{
+			name:    "uint_negative",
+			in:      types.NewInt(-1),
+			dst:     func() any { return new(uint) },
+			wantErr: errors.TypeErrorFromMessage(`-1 overflows Go type "uint"`),
+		},
+		{
+			name:    "uint_from_large_bigint",
+			in:      types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64)),
+			dst:     func() any { return new(uint64) },
+			wantErr: errors.TypeErrorFromMessage(`18446744073709551616n overflows Go type "uint64"`),
+		},
+		{
+			name: "uint_from_large_float",
+			in:   types.NewFloat(1e19),
+			dst:  func() any { return new(uint64) },
+			want: uint64(1e19),
+		},
+		{
+			name:    "uint_from_float_overflow",
+			in:      types.NewFloat(1e30),
+			dst:     func() any { return new(uint64) },
+			wantErr: errors.TypeErrorFromMessage(`1e+30 overflows Go type "uint64"`),
+		},
+		{
+			name:    "uint_from_float_narrow_overflow",
+			in:      types.NewFloat(256),
+			dst:     func() any { return new(uint8) },
+			wantErr: errors.TypeErrorFromMessage(`256.0 overflows Go type "uint8"`),
+		},
+		{
+			name:    "uint_from_nan",
+			in:      types.NewFloat(math.NaN()),
+			dst:     func() any { return new(uint) },
+			wantErr: errors.TypeErrorFromMessage(`NaN overflows Go type "uint"`),
+		},
+		{
+			name:    "uint_from_inf",
+			in:      types.NewFloat(math.Inf(1)),
+			dst:     func() any { return new(uint) },
+			wantErr: errors.TypeErrorFromMessage(`+Inf overflows Go type "uint"`),
+		},
+		{
+			name: "big_int",
+			in:   types.NewBigInt(big.NewInt(-9)),
+			dst:  func() any { return new(*big.Int) },
+			want: big.NewInt(-9),
+		},
+		{
+			name: "big_int_from_uint",
+			in:   types.NewUint(math.MaxUint64),
+			dst:  func() any { return new(*big.Int) },
+			want: new(big.Int).SetUint64(math.MaxUint64),
+		},
+		{
+			name:    "big_int_from_fractional_float",
+			in:      types.NewFloat(2.5),
+			dst:     func() any { return new(*big.Int) },
+			wantErr: errors.TypeErrorFromMessage(`2.5 cannot be used as Go type "*big.Int" without losing precision`),
+		},
+		{
+			name: "float_from_decimal",
+			in:   types.NewDecimal(decimal.MustParse("0.1")),
+			dst:  func() any { return new(float64) },
+			want: 0.1,
+		},
+		{
+			name: "float",
+			in:   types.NewInt(3),
+			dst:  func() any { return new(float32) },
+			want: float32(3),
+		},
+		{
+			name: "string",
+			in:   types.NewStr("hi"),
+			dst:  func() any { return new(string) },
+			want: "hi",
+		},
+		{
+			name: "bytes_from_str",
+			in:   types.NewStr("hi"),
+			dst:  func() any { return new([]byte) },
+			want: []byte("hi"),
+		},
+		{
+			name: "time",
+			in:   types.NewDatetime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			dst:  func() any { return new(time.Time) },
+			want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name: "time_from_str",
+			in:   types.NewStr("2024-01-02T03:04:05Z"),
+			dst:  func() any { return new(time.Time) },
+			want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name:    "time_from_int",
+			in:      types.NewInt(1),
+			dst:     func() any { return new(time.Time) },
+			wantErr: errors.NewTypeError(types.IntType, reflect.TypeFor[time.Time]()),
+		},
+		{
+			name: "duration",
+			in:   types.NewDuration(time.Minute),
+			dst:  func() any { return new(time.Duration) },
+			want: time.Minute,
+		},
+		{
+			name: "duration_from_int",
+			in:   types.NewInt(5),
+			dst:  func() any { return new(time.Duration) },
+			want: 5 * time.Nanosecond,
+		},
+		{
+			name: "datetime_in_interface",
+			in:   types.NewDatetime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			dst:  func() any { return new(any) },
+			want: any(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+		{
+			name: "slice",
+			in:   types.NewList([]execute.Value{types.NewInt(1), types.NewInt(2)}),
+			dst:  func() any { return new([]int) },
+			want: []int{1, 2},
+		},
+		{
+			name: "slice_from_iterable",
+			in:   types.NewStr("ab"),
+			dst:  func() any { return new([]string) },
+			want: []string{"a", "b"},
+		},
+		{
+			name: "array",
+			in:   types.NewList([]execute.Value{types.NewInt(1), types.NewInt(2)}),
+			dst:  func() any { return new([2]uint) },
+			want: [2]uint{1, 2},
+		},
+		{
+			name:    "array_wrong_length",
+			in:      types.NewList([]execute.Value{types.NewInt(1)}),
+			dst:     func() any { return new([2]uint) },
+			wantErr: errors.TypeErrorFromMessage(`a value of length 1 cannot be used as Go type "[2]uint"`),
+		},
+		{
+			name: "map",
+			in:   mustMap(t, types.NewStr("a"), types.NewInt(1)),
+			dst:  func() any { return new(map[string]float64) },
+			want: map[string]float64{"a": 1},
+		},
+		{
+			name: "any",
+			in: types.NewList([]execute.Value{
+				types.Null,
+				types.NewInt(1),
+				mustMap(t, types.NewStr("a"), types.NewBool(true)),
+			}),
+			dst:  func() any { return new(any) },
+			want: any([]any{nil, int64(1), map[string]any{"a": true}}),
+		},
+		{
+			name: "struct",
+			in: mustMap(t,
+				types.NewStr("name"), types.NewStr("Alice"),
+				types.NewStr("age"), types.NewInt(30),
+				types.NewStr("mail"), types.NewStr("a@b.c"),
+				types.NewStr("password"), types.NewStr("ignored"),
+			),
+			dst:  func() any { return new(person) },
+			want: person{Name: "Alice", Age: 30, Email: "a@b.c"},
+		},
+		{
+			name:    "struct_bad_field",
+			in:      mustMap(t, types.NewStr("age"), types.NewStr("old")),
+			dst:     func() any { return new(person) },
+			wantErr: errors.NewTypeError(types.StrType, types.IntType),
+		},
+		{
+			name: "pointer",
+			in:   mustMap(t, types.NewStr("p"), mustMap(t, types.NewStr("name"), types.NewStr("Bob"))),
+			dst:  func() any { return new(wrapper) },
+			want: wrapper{P: &person{Name: "Bob"}},
+		},
+		{
+			name: "pointer_null",
+			in:   mustMap(t, types.NewStr("p"), types.Null),
+			dst:  func() any { return new(wrapper) },
+			want: wrapper{},
+		},
+		{
+			name: "value",
+			in:   types.NewInt(1),
+			dst:  func() any { return new(execute.Value) },
+			want: execute.Value(types.NewInt(1)),
+		},
+		{
+			name: "object",
+			in:   NewObject(reflect.ValueOf(&person{Name: "Carol"})),
+			dst:  func() any { return new(person) },
+			want: person{Name: "Carol"},
+		},
+		{
+			name:    "unsupported",
+			in:      types.NewInt(1),
+			dst:     func() any { return new(chan int) },
+			wantErr: errors.TypeErrorFromMessage(`type "int" cannot be used as type "chan int"`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := tc.dst()
+			err := FromValue(tc.in, dst)
+			if diff := cmp.Diff(tc.wantErr, err, cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+				t.Fatalf("FromValue() returned unexpected error (-want +got):\n%s", diff)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			got := reflect.ValueOf(dst).Elem().Interface()
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(person{}, types.Int{}), equateBigInts); diff != "" {
+				t.Errorf("FromValue() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFromValue_notPointer(t *testing.T) {
+	var i int
+	want := errors.TypeErrorFromMessage("FromValue requires a non-nil pointer, not int")
+	if diff := cmp.Diff(want, FromValue(types.NewInt(1), i), cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+		t.Errorf("FromValue() returned unexpected error (-want +got):\n%s", diff)
+	}
+}
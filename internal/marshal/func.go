@@ -0,0 +1,87 @@
+package marshal
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// NewFunc wraps the Go function fn in a Slow func. When called, its arguments are converted into
+// the function's parameter types using FromValue and its results are converted using ToValue.
+// Variadic functions accept any number of trailing arguments.
+//
+// If the last result of fn is an error, a non-nil error is returned from the call: Slow errors are
+// returned as-is and any other error is wrapped in a RuntimeError. Of the remaining results, none
+// produces null, one produces its converted value, and more than one produces a list. If fn panics,
+// the panic is recovered and returned as a RuntimeError so that it doesn't crash the host program.
+func NewFunc(name string, fn reflect.Value) (*types.Func, error) {
+	if fn.Kind() != reflect.Func {
+		return nil, errors.TypeErrorFromMessage(fmt.Sprintf("Go type %q is not a function", fn.Type()))
+	}
+	t := fn.Type()
+	returnsErr := t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType
+	impl := func(args ...execute.Value) (_ execute.Value, err error) {
+		in, err := callArgs(name, t, args)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.NewRuntimeError(fmt.Sprintf("%s panicked: %v", name, r))
+			}
+		}()
+		out := fn.Call(in)
+		if returnsErr {
+			if err, _ := out[len(out)-1].Interface().(error); err != nil {
+				if se, ok := err.(*errors.SlowError); ok {
+					return nil, se
+				}
+				return nil, errors.NewRuntimeError(err.Error())
+			}
+			out = out[:len(out)-1]
+		}
+		switch len(out) {
+		case 0:
+			return types.Null, nil
+		case 1:
+			return toValue(out[0])
+		}
+		vs := make([]execute.Value, len(out))
+		for i, o := range out {
+			if vs[i], err = toValue(o); err != nil {
+				return nil, err
+			}
+		}
+		return types.NewList(vs), nil
+	}
+	return types.NewGoFunc(name, impl), nil
+}
+
+// callArgs converts the arguments of a call to a Go function of type t into Go values.
+func callArgs(name string, t reflect.Type, args []execute.Value) ([]reflect.Value, error) {
+	n := t.NumIn()
+	if t.IsVariadic() {
+		if len(args) < n-1 {
+			return nil, errors.CallError(name, len(args), n-1)
+		}
+	} else if len(args) != n {
+		return nil, errors.CallError(name, len(args), n)
+	}
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		var at reflect.Type
+		if t.IsVariadic() && i >= n-1 {
+			at = t.In(n - 1).Elem()
+		} else {
+			at = t.In(i)
+		}
+		in[i] = reflect.New(at).Elem()
+		if err := fromValue(a, in[i]); err != nil {
+			return nil, err
+		}
+	}
+	return in, nil
+}
@@ -0,0 +1,120 @@
+package marshal
+
+import (
+	goerrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewFunc(t *testing.T) {
+	slowErr := errors.NewValueError("bad value")
+	tests := []struct {
+		name    string
+		fn      any
+		args    []execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{
+			name: "no_results",
+			fn:   func() {},
+			want: types.Null,
+		},
+		{
+			name: "one_result",
+			fn:   func(a, b int) int { return a + b },
+			args: []execute.Value{types.NewInt(1), types.NewInt(2)},
+			want: types.NewInt(3),
+		},
+		{
+			name: "multiple_results",
+			fn:   func(s string) (string, int) { return strings.ToUpper(s), len(s) },
+			args: []execute.Value{types.NewStr("ab")},
+			want: types.NewList([]execute.Value{types.NewStr("AB"), types.NewInt(2)}),
+		},
+		{
+			name: "variadic",
+			fn:   func(sep string, ss ...string) string { return strings.Join(ss, sep) },
+			args: []execute.Value{types.NewStr("-"), types.NewStr("a"), types.NewStr("b")},
+			want: types.NewStr("a-b"),
+		},
+		{
+			name: "variadic_empty",
+			fn:   func(sep string, ss ...string) string { return strings.Join(ss, sep) },
+			args: []execute.Value{types.NewStr("-")},
+			want: types.NewStr(""),
+		},
+		{
+			name: "nil_error",
+			fn:   func() (int, error) { return 1, nil },
+			want: types.NewInt(1),
+		},
+		{
+			name:    "go_error",
+			fn:      func() (int, error) { return 0, goerrors.New("oops") },
+			wantErr: errors.NewRuntimeError("oops"),
+		},
+		{
+			name:    "slow_error",
+			fn:      func() error { return slowErr },
+			wantErr: slowErr,
+		},
+		{
+			name:    "panic",
+			fn:      func(s []int) int { return s[0] },
+			args:    []execute.Value{types.NewList(nil)},
+			wantErr: errors.NewRuntimeError("f panicked: runtime error: index out of range [0] with length 0"),
+		},
+		{
+			name:    "panic_with_value",
+			fn:      func() { panic("oops") },
+			wantErr: errors.NewRuntimeError("f panicked: oops"),
+		},
+		{
+			name:    "too_few_args",
+			fn:      func(a, b int) {},
+			args:    []execute.Value{types.NewInt(1)},
+			wantErr: errors.CallError("f", 1, 2),
+		},
+		{
+			name:    "too_few_args_variadic",
+			fn:      func(a int, b ...int) {},
+			wantErr: errors.CallError("f", 0, 1),
+		},
+		{
+			name:    "bad_arg",
+			fn:      func(a int) {},
+			args:    []execute.Value{types.NewStr("x")},
+			wantErr: errors.NewTypeError(types.StrType, types.IntType),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFunc("f", reflect.ValueOf(tc.fn))
+			if err != nil {
+				t.Fatalf("NewFunc() returned an unexpected error: %v", err)
+			}
+			got, err := f.Call(nil, tc.args...)
+			if diff := cmp.Diff(tc.wantErr, err, cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+				t.Fatalf("Call() returned unexpected error (-want +got):\n%s", diff)
+			}
+			if tc.want != nil && !tc.want.Equals(got) && tc.want.String() != got.String() {
+				t.Errorf("Call() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewFunc_notFunc(t *testing.T) {
+	want := errors.TypeErrorFromMessage(`Go type "int" is not a function`)
+	_, err := NewFunc("f", reflect.ValueOf(1))
+	if diff := cmp.Diff(want, err, cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+		t.Errorf("NewFunc() returned unexpected error (-want +got):\n%s", diff)
+	}
+}
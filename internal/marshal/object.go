@@ -0,0 +1,181 @@
+package marshal
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Type definition
+// -------------------------------------------------------------------------------------------------
+
+type objectType struct {
+	t reflect.Type
+}
+
+func (t *objectType) IsNumeric() bool {
+	return false
+}
+
+func (t *objectType) New(v execute.Value) (execute.Value, error) {
+	panic("objectType.New() is not supported")
+}
+
+func (t *objectType) String() string {
+	return t.t.Elem().String()
+}
+
+// objectTypes caches the type of each Go type wrapped by an Object so that objects of the same Go
+// type have identical Slow types.
+var objectTypes sync.Map // map[reflect.Type]*objectType
+
+func objectTypeOf(t reflect.Type) *objectType {
+	ot, _ := objectTypes.LoadOrStore(t, &objectType{t})
+	return ot.(*objectType)
+}
+
+// -------------------------------------------------------------------------------------------------
+// Type implementation
+// -------------------------------------------------------------------------------------------------
+
+// Object is a Slow value that wraps a pointer to a Go struct. The struct's exported fields and
+// methods are exposed as attributes, named as described in AttributeName; fields may be renamed or
+// hidden with struct tags, as in ToValue. Setting an attribute updates the underlying struct.
+type Object struct {
+	value  reflect.Value
+	fields map[string]field
+}
+
+// NewObject returns an Object that wraps the provided pointer to a struct.
+func NewObject(v reflect.Value) *Object {
+	fields := make(map[string]field)
+	for _, f := range structFields(v.Type().Elem()) {
+		fields[f.name] = f
+	}
+	return &Object{value: v, fields: fields}
+}
+
+// Interface returns the Go value wrapped by this object.
+func (v *Object) Interface() any {
+	return v.value.Interface()
+}
+
+func (v *Object) method(a string) (reflect.Value, bool) {
+	t := v.value.Type()
+	for i := range t.NumMethod() {
+		if m := t.Method(i); AttributeName(m.Name) == a {
+			return v.value.Method(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func (v *Object) CloneIfPrimitive() execute.Value {
+	return v
+}
+
+func (v *Object) CompareTo(o execute.Value) (int, bool) {
+	return 0, false
+}
+
+func (v *Object) Equals(o execute.Value) bool {
+	oo, ok := o.(*Object)
+	if !ok {
+		return false
+	}
+	return v.value.Pointer() == oo.value.Pointer() && v.value.Type() == oo.value.Type()
+}
+
+func (v *Object) GetAttribute(a string) (execute.Value, error) {
+	if f, ok := v.fields[a]; ok {
+		fv, err := v.value.Elem().FieldByIndexErr(f.index)
+		if err != nil {
+			return types.Null, nil
+		}
+		return toValue(fv)
+	}
+	if m, ok := v.method(a); ok {
+		return NewFunc(fmt.Sprintf("%s.%s", v.Type(), a), m)
+	}
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Object) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Object) HasAttribute(a string) bool {
+	if _, ok := v.fields[a]; ok {
+		return true
+	}
+	_, ok := v.method(a)
+	return ok
+}
+
+func (v *Object) HashBytes() ([]byte, error) {
+	return nil, errors.UnhashableTypeError(v.Type())
+}
+
+func (v *Object) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *Object) SetAttribute(a string, val execute.Value) error {
+	f, ok := v.fields[a]
+	if !ok {
+		if _, ok := v.method(a); ok {
+			return errors.AssignmentError(v.Type(), a)
+		}
+		return errors.NewAttributeError(v.Type(), a)
+	}
+	return fromValue(val, fieldByIndexAlloc(v.value.Elem(), f.index))
+}
+
+func (v *Object) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Object) String() string {
+	return fmt.Sprintf("<%s object>", v.Type())
+}
+
+func (v *Object) ToBool() bool {
+	return true
+}
+
+func (v *Object) ToBytes() ([]byte, error) {
+	return nil, errors.NewTypeError(v.Type(), types.BytesType)
+}
+
+func (v *Object) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), types.FuncType)
+}
+
+func (v *Object) ToFloat() (float64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.FloatType)
+}
+
+func (v *Object) ToInt() (int64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.IntType)
+}
+
+func (v *Object) ToIterator() (execute.Iterator, error) {
+	return nil, errors.NewTypeError(v.Type(), types.IteratorType)
+}
+
+func (v *Object) ToStr() (string, error) {
+	return "", errors.NewTypeError(v.Type(), types.StrType)
+}
+
+func (v *Object) ToUint() (uint64, error) {
+	return 0, errors.NewTypeError(v.Type(), types.UintType)
+}
+
+func (v *Object) Type() execute.Type {
+	return objectTypeOf(v.value.Type())
+}
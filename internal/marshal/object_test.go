@@ -0,0 +1,158 @@
+package marshal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type counter struct {
+	Count int
+	Label string `slow:"name"`
+	Skip  bool   `slow:"-"`
+}
+
+func (c *counter) Add(n int) int {
+	c.Count += n
+	return c.Count
+}
+
+func (c counter) Describe() string {
+	return fmt.Sprintf("%s=%d", c.Label, c.Count)
+}
+
+func newCounterObject(c *counter) *Object {
+	return NewObject(reflect.ValueOf(c))
+}
+
+func TestObjectType(t *testing.T) {
+	ot := newCounterObject(&counter{}).Type()
+	if got, want := ot.String(), "marshal.counter"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if ot.IsNumeric() {
+		t.Errorf("IsNumeric() = true, want false")
+	}
+	if other := newCounterObject(&counter{}).Type(); ot != other {
+		t.Errorf("objects of the same Go type have different types: %v and %v", ot, other)
+	}
+}
+
+func TestObject(t *testing.T) {
+	errOpt := cmp.AllowUnexported(errors.SlowError{})
+
+	t.Run("Equals", func(t *testing.T) {
+		c := &counter{}
+		o := newCounterObject(c)
+		if !o.Equals(newCounterObject(c)) {
+			t.Errorf("Equals() returned false for objects wrapping the same pointer")
+		}
+		if o.Equals(newCounterObject(&counter{})) {
+			t.Errorf("Equals() returned true for objects wrapping different pointers")
+		}
+		if o.Equals(types.NewInt(1)) {
+			t.Errorf("Equals() returned true for a non-object")
+		}
+	})
+
+	t.Run("GetAttribute", func(t *testing.T) {
+		o := newCounterObject(&counter{Count: 2, Label: "c"})
+		tests := []struct {
+			attr    string
+			want    execute.Value
+			wantErr error
+		}{
+			{attr: "count", want: types.NewInt(2)},
+			{attr: "name", want: types.NewStr("c")},
+			{attr: "label", wantErr: errors.NewAttributeError(o.Type(), "label")},
+			{attr: "skip", wantErr: errors.NewAttributeError(o.Type(), "skip")},
+		}
+		for _, tc := range tests {
+			got, err := o.GetAttribute(tc.attr)
+			if diff := cmp.Diff(tc.wantErr, err, errOpt); diff != "" {
+				t.Errorf("GetAttribute(%q) returned unexpected error (-want +got):\n%s", tc.attr, diff)
+			}
+			if tc.want != nil && !tc.want.Equals(got) {
+				t.Errorf("GetAttribute(%q) = %v, want %v", tc.attr, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("HasAttribute", func(t *testing.T) {
+		o := newCounterObject(&counter{})
+		for attr, want := range map[string]bool{
+			"count":    true,
+			"name":     true,
+			"add":      true,
+			"describe": true,
+			"Count":    false,
+			"skip":     false,
+		} {
+			if got := o.HasAttribute(attr); got != want {
+				t.Errorf("HasAttribute(%q) = %v, want %v", attr, got, want)
+			}
+		}
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		c := &counter{Label: "c"}
+		o := newCounterObject(c)
+		add, err := o.GetAttribute("add")
+		if err != nil {
+			t.Fatalf("GetAttribute() returned an unexpected error: %v", err)
+		}
+		callable, err := add.ToCallable()
+		if err != nil {
+			t.Fatalf("ToCallable() returned an unexpected error: %v", err)
+		}
+		got, err := callable.Call(nil, types.NewInt(3))
+		if err != nil {
+			t.Fatalf("Call() returned an unexpected error: %v", err)
+		}
+		if !got.Equals(types.NewInt(3)) || c.Count != 3 {
+			t.Errorf("Call() = %v and set Count to %d, want 3 and 3", got, c.Count)
+		}
+		describe, _ := o.GetAttribute("describe")
+		callable, _ = describe.ToCallable()
+		if got, _ := callable.Call(nil); !got.Equals(types.NewStr("c=3")) {
+			t.Errorf("Call() = %v, want \"c=3\"", got)
+		}
+	})
+
+	t.Run("SetAttribute", func(t *testing.T) {
+		c := &counter{}
+		o := newCounterObject(c)
+		if err := o.SetAttribute("count", types.NewInt(5)); err != nil {
+			t.Errorf("SetAttribute() returned an unexpected error: %v", err)
+		}
+		if c.Count != 5 {
+			t.Errorf("SetAttribute() set Count to %d, want 5", c.Count)
+		}
+		tests := []struct {
+			attr    string
+			val     execute.Value
+			wantErr error
+		}{
+			{attr: "count", val: types.NewStr("x"), wantErr: errors.TypeErrorFromMessage(`type "str" cannot be used as type "int"`)},
+			{attr: "add", val: types.Null, wantErr: errors.AssignmentError(o.Type(), "add")},
+			{attr: "nope", val: types.Null, wantErr: errors.NewAttributeError(o.Type(), "nope")},
+		}
+		for _, tc := range tests {
+			err := o.SetAttribute(tc.attr, tc.val)
+			if diff := cmp.Diff(tc.wantErr, err, errOpt); diff != "" {
+				t.Errorf("SetAttribute(%q) returned unexpected error (-want +got):\n%s", tc.attr, diff)
+			}
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got, want := newCounterObject(&counter{}).String(), "<marshal.counter object>"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	})
+}
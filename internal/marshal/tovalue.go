@@ -0,0 +1,159 @@
+// Package marshal converts values between Go and Slow using reflection.
+package marshal
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+)
+
+var (
+	bigIntType   = reflect.TypeFor[*big.Int]()
+	durationType = reflect.TypeFor[time.Duration]()
+	errorType    = reflect.TypeFor[error]()
+	timeType     = reflect.TypeFor[time.Time]()
+	valueType    = reflect.TypeFor[execute.Value]()
+)
+
+// ToValue converts a Go value into a Slow value:
+//
+//   - nil and nil pointers, maps, slices, and interfaces become null
+//   - bools, signed integers, unsigned integers, floats, and strings become the corresponding
+//     primitive type
+//   - []byte becomes bytes
+//   - *big.Int becomes bigint
+//   - time.Time becomes datetime and time.Duration becomes duration
+//   - slices and arrays become lists
+//   - maps become maps
+//   - structs become maps keyed by field name (see FromValue for the supported struct tags)
+//   - pointers to structs become objects whose exported fields and methods are attributes
+//   - functions become funcs whose arguments and return values are converted automatically
+//
+// execute.Values are returned unchanged.
+func ToValue(v any) (execute.Value, error) {
+	if v == nil {
+		return types.Null, nil
+	}
+	return toValue(reflect.ValueOf(v))
+}
+
+func toValue(rv reflect.Value) (execute.Value, error) {
+	if !rv.IsValid() {
+		return types.Null, nil
+	}
+	if rv.Type().Implements(valueType) {
+		if rv.Kind() == reflect.Interface || rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return types.Null, nil
+			}
+		}
+		return rv.Interface().(execute.Value), nil
+	}
+	if rv.Type() == bigIntType {
+		if rv.IsNil() {
+			return types.Null, nil
+		}
+		return types.NewBigInt(new(big.Int).Set(rv.Interface().(*big.Int))), nil
+	}
+	if rv.Type() == timeType {
+		return types.NewDatetime(rv.Interface().(time.Time)), nil
+	}
+	if rv.Type() == durationType {
+		return types.NewDuration(rv.Interface().(time.Duration)), nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return types.NewBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return types.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return types.NewUint(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return types.NewFloat(rv.Float()), nil
+	case reflect.String:
+		return types.NewStr(rv.String()), nil
+	case reflect.Interface:
+		if rv.IsNil() {
+			return types.Null, nil
+		}
+		return toValue(rv.Elem())
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return types.Null, nil
+		}
+		if rv.Elem().Kind() == reflect.Struct && rv.Elem().Type() != timeType {
+			return NewObject(rv), nil
+		}
+		return toValue(rv.Elem())
+	case reflect.Slice:
+		if rv.IsNil() {
+			return types.Null, nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return types.NewBytes(append([]byte{}, rv.Bytes()...)), nil
+		}
+		fallthrough
+	case reflect.Array:
+		vs := make([]execute.Value, rv.Len())
+		for i := range vs {
+			v, err := toValue(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			vs[i] = v
+		}
+		return types.NewList(vs), nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return types.Null, nil
+		}
+		m := types.NewMap()
+		iter := rv.MapRange()
+		for iter.Next() {
+			k, err := toValue(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			v, err := toValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			if _, err := m.Set(k, v); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case reflect.Struct:
+		m := types.NewMap()
+		for _, f := range structFields(rv.Type()) {
+			fv, err := rv.FieldByIndexErr(f.index)
+			if err != nil {
+				// The field is promoted through a nil embedded pointer.
+				continue
+			}
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			v, err := toValue(fv)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := m.Set(types.NewStr(f.name), v); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case reflect.Func:
+		if rv.IsNil() {
+			return types.Null, nil
+		}
+		return NewFunc(runtime.FuncForPC(rv.Pointer()).Name(), rv)
+	}
+	return nil, errors.TypeErrorFromMessage(fmt.Sprintf("Go type %q cannot be converted to a Slow value", rv.Type()))
+}
@@ -0,0 +1,128 @@
+package marshal
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type person struct {
+	Name     string
+	Age      int
+	Nickname string   `slow:",omitempty"`
+	Email    string   `slow:"mail"`
+	Password string   `slow:"-"`
+	Tags     []string `slow:"tags,omitempty"`
+	secret   string
+}
+
+type employee struct {
+	person
+	Title string
+}
+
+func TestToValue(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	var nilPtr *int
+	one := 1
+	tests := []struct {
+		name    string
+		in      any
+		want    any
+		wantErr error
+	}{
+		{name: "nil", in: nil, want: nil},
+		{name: "nil_ptr", in: nilPtr, want: nil},
+		{name: "bool", in: true, want: true},
+		{name: "int", in: int8(-3), want: int64(-3)},
+		{name: "uint", in: uint16(3), want: uint64(3)},
+		{name: "float", in: float32(1.5), want: float64(1.5)},
+		{name: "str", in: "hi", want: "hi"},
+		{name: "ptr", in: &one, want: int64(1)},
+		{name: "bytes", in: []byte("hi"), want: []byte("hi")},
+		{name: "big_int", in: big.NewInt(-5), want: big.NewInt(-5)},
+		{name: "nil_big_int", in: (*big.Int)(nil), want: nil},
+		{name: "time", in: when, want: when},
+		{name: "duration", in: 90 * time.Second, want: 90 * time.Second},
+		{name: "slice", in: []int{1, 2}, want: []any{int64(1), int64(2)}},
+		{name: "array", in: [2]string{"a", "b"}, want: []any{"a", "b"}},
+		{name: "nested", in: [][]bool{{true}, nil}, want: []any{[]any{true}, nil}},
+		{name: "map", in: map[string]int{"a": 1}, want: map[string]any{"a": int64(1)}},
+		{name: "map_int_keys", in: map[int]string{1: "a"}, want: map[any]any{int64(1): "a"}},
+		{name: "value", in: types.NewInt(2), want: int64(2)},
+		{
+			name: "struct",
+			in:   person{Name: "Alice", Age: 30, Email: "a@b.c", Password: "hunter2", secret: "x"},
+			want: map[string]any{"name": "Alice", "age": int64(30), "mail": "a@b.c"},
+		},
+		{
+			name: "struct_embedded",
+			in:   employee{person: person{Name: "Bob", Nickname: "B", Tags: []string{"x"}}, Title: "CEO"},
+			want: map[string]any{
+				"name":     "Bob",
+				"age":      int64(0),
+				"nickname": "B",
+				"mail":     "",
+				"tags":     []any{"x"},
+				"title":    "CEO",
+			},
+		},
+		{
+			name:    "unsupported",
+			in:      make(chan int),
+			wantErr: errors.TypeErrorFromMessage(`Go type "chan int" cannot be converted to a Slow value`),
+		},
+		{
+			name:    "unsupported_nested",
+			in:      []any{1, complex(1, 2)},
+			wantErr: errors.TypeErrorFromMessage(`Go type "complex128" cannot be converted to a Slow value`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := ToValue(tc.in)
+			if diff := cmp.Diff(tc.wantErr, err, cmp.AllowUnexported(errors.SlowError{})); diff != "" {
+				t.Fatalf("ToValue() returned unexpected error (-want +got):\n%s", diff)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			got, err := naturalOrNil(v)
+			if err != nil {
+				t.Fatalf("naturalOrNil() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, equateBigInts); diff != "" {
+				t.Errorf("ToValue() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToValue_types(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want execute.Type
+	}{
+		{name: "object", in: &person{}, want: objectTypeOf(reflect.TypeFor[*person]())},
+		{name: "func", in: func() {}, want: types.FuncType},
+		{name: "map_ptr", in: &map[string]int{}, want: types.MapType},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToValue(tc.in)
+			if err != nil {
+				t.Fatalf("ToValue() returned an unexpected error: %v", err)
+			}
+			if got.Type() != tc.want {
+				t.Errorf("ToValue() returned a value of type %q, want %q", got.Type(), tc.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package operators
 
 import (
+	"fmt"
 	"math"
+	"math/big"
 	"reflect"
+	"time"
 
+	"github.com/chrispyles/slow/internal/decimal"
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/types"
@@ -16,28 +20,80 @@ var logicalOperators = map[*BinaryOperator]bool{
 }
 
 var reassignmentToArithmeticOperator = map[*BinaryOperator]*BinaryOperator{
-	BinOp_RPLUS:  BinOp_PLUS,
-	BinOp_RMINUS: BinOp_MINUS,
-	BinOp_RTIMES: BinOp_TIMES,
-	BinOp_RDIV:   BinOp_DIV,
-	BinOp_RMOD:   BinOp_MOD,
-	BinOp_RFDIV:  BinOp_FDIV,
-	BinOp_REXP:   BinOp_EXP,
-	BinOp_RAND:   BinOp_AND,
-	BinOp_ROR:    BinOp_OR,
-	BinOp_RXOR:   BinOp_XOR,
+	BinOp_RPLUS:   BinOp_PLUS,
+	BinOp_RMINUS:  BinOp_MINUS,
+	BinOp_RTIMES:  BinOp_TIMES,
+	BinOp_RDIV:    BinOp_DIV,
+	BinOp_RMOD:    BinOp_MOD,
+	BinOp_RFDIV:   BinOp_FDIV,
+	BinOp_REXP:    BinOp_EXP,
+	BinOp_RAND:    BinOp_AND,
+	BinOp_ROR:     BinOp_OR,
+	BinOp_RXOR:    BinOp_XOR,
+	BinOp_RBAND:   BinOp_BAND,
+	BinOp_RBOR:    BinOp_BOR,
+	BinOp_RBXOR:   BinOp_BXOR,
+	BinOp_RLSHIFT: BinOp_LSHIFT,
+	BinOp_RRSHIFT: BinOp_RSHIFT,
+}
+
+var bitwiseOperators = map[*BinaryOperator]bool{
+	BinOp_BAND: true,
+	BinOp_BOR:  true,
+	BinOp_BXOR: true,
+}
+
+var shiftOperators = map[*BinaryOperator]bool{
+	BinOp_LSHIFT: true,
+	BinOp_RSHIFT: true,
 }
 
 var comparableTypes = map[execute.Type]bool{
-	types.BoolType:  true,
-	types.BytesType: true,
-	types.FloatType: true,
-	types.IntType:   true,
-	types.StrType:   true,
-	types.UintType:  true,
+	types.BigIntType:  true,
+	types.BoolType:    true,
+	types.BytesType:   true,
+	types.DecimalType: true,
+	types.FloatType:   true,
+	types.IntType:     true,
+	types.StrType:     true,
+	types.UintType:    true,
+}
+
+var integerTypes = map[execute.Type]bool{
+	types.BigIntType: true,
+	types.IntType:    true,
+	types.UintType:   true,
+}
+
+// bitwiseTypes are the types that can be used with the bitwise and shift operators.
+var bitwiseTypes = map[execute.Type]bool{
+	types.BigIntType: true,
+	types.BoolType:   true,
+	types.BytesType:  true,
+	types.IntType:    true,
+	types.UintType:   true,
+}
+
+// timeTypes are the types of datetimes and durations.
+var timeTypes = map[execute.Type]bool{
+	types.DatetimeType: true,
+	types.DurationType: true,
 }
 
+// fixedWidthTypes are the numeric types whose arithmetic can overflow.
+var fixedWidthTypes = map[execute.Type]bool{
+	types.BoolType: true,
+	types.IntType:  true,
+	types.UintType: true,
+}
+
+// Value returns the result of applying this operator to l and r using the default Options.
 func (o *BinaryOperator) Value(l, r execute.Value) (execute.Value, error) {
+	return o.ValueWith(l, r, Options{})
+}
+
+// ValueWith returns the result of applying this operator to l and r using the provided Options.
+func (o *BinaryOperator) ValueWith(l, r execute.Value, opts Options) (execute.Value, error) {
 	// If this is a reassignment operator, convert it to its arithmetic version to calculate the new
 	// value.
 	if ao, ok := reassignmentToArithmeticOperator[o]; ok {
@@ -45,7 +101,13 @@ func (o *BinaryOperator) Value(l, r execute.Value) (execute.Value, error) {
 	}
 
 	lt, rt := l.Type(), r.Type()
-	if o == BinOp_MOD {
+	// Datetimes and durations have their own arithmetic, which never wraps around.
+	if (timeTypes[lt] || timeTypes[rt]) && !logicalOperators[o] {
+		return timeArithmetic(o, l, r)
+	}
+
+	// The remainder of a decimal is calculated like the other decimal arithmetic below.
+	if o == BinOp_MOD && lt != types.DecimalType && rt != types.DecimalType {
 		// Floats with no remainder can be treated as ints.
 		if lt == types.FloatType && !l.(*types.Float).HasRemainder() {
 			lt = types.IntType
@@ -54,13 +116,21 @@ func (o *BinaryOperator) Value(l, r execute.Value) (execute.Value, error) {
 			rt = types.IntType
 		}
 
-		if lt != types.IntType && lt != types.UintType {
+		if !integerTypes[lt] {
 			return nil, errors.IncompatibleType(lt, o.String())
 		}
-		if rt != types.IntType && rt != types.UintType {
+		if !integerTypes[rt] {
 			return nil, errors.IncompatibleType(rt, o.String())
 		}
 
+		if lt == types.BigIntType || rt == types.BigIntType {
+			lb, rb := must(types.ToBigInt(l)), must(types.ToBigInt(r))
+			if rb.Sign() == 0 {
+				return nil, errors.NewZeroDivisionError()
+			}
+			return types.NewBigInt(new(big.Int).Rem(lb, rb)), nil
+		}
+
 		if lt == types.UintType && rt == types.UintType {
 			ru := must(r.ToUint())
 			if ru == 0 {
@@ -93,12 +163,20 @@ func (o *BinaryOperator) Value(l, r execute.Value) (execute.Value, error) {
 		}
 	}
 
+	// The result of a shift has the type of its left operand, so the operands aren't cast.
+	if shiftOperators[o] {
+		return shift(o, l, r, opts.Checked)
+	}
+
 	caster, ok := newTypeCaster(lt, rt)
 	if o == BinOp_DIV {
 		if !lt.IsNumeric() || !rt.IsNumeric() {
 			return nil, errors.IncompatibleTypes(lt, rt, o.String())
 		}
-		caster, ok = newFloatCaster()
+		// Division produces a float unless either operand is a decimal.
+		if !ok || caster.dest != types.DecimalType {
+			caster, ok = newFloatCaster()
+		}
 	}
 	if !ok {
 		return nil, errors.IncompatibleTypes(lt, rt, o.String())
@@ -145,6 +223,22 @@ func (o *BinaryOperator) Value(l, r execute.Value) (execute.Value, error) {
 		}
 	}
 
+	// This check comes before the operands are cast because floats that aren't finite can't be cast to
+	// decimals.
+	if bitwiseOperators[o] {
+		if !bitwiseTypes[caster.dest] {
+			return nil, errors.IncompatibleTypes(lt, rt, o.String())
+		}
+		lc, rc := doCast()
+		return bitwise(o, caster.dest, lc, rc)
+	}
+
+	// Floats that aren't finite can't be converted to decimals, so decimal arithmetic converts its
+	// operands itself instead of using the caster.
+	if caster.dest == types.DecimalType {
+		return decimalArithmetic(o, l, r, opts.Decimal)
+	}
+
 	lc, rc := doCast()
 
 	// Return an error if there is an attempt to divide by zero.
@@ -152,6 +246,10 @@ func (o *BinaryOperator) Value(l, r execute.Value) (execute.Value, error) {
 		return nil, errors.NewZeroDivisionError()
 	}
 
+	if caster.dest == types.BigIntType || opts.Checked && fixedWidthTypes[caster.dest] {
+		return integerArithmetic(o, caster.dest, lc, rc)
+	}
+
 	switch o {
 	case BinOp_PLUS:
 		switch caster.dest {
@@ -223,6 +321,355 @@ func (o *BinaryOperator) Value(l, r execute.Value) (execute.Value, error) {
 	return nil, errors.IncompatibleTypes(lt, rt, o.String())
 }
 
+// integerArithmetic returns the result of applying an arithmetic operator to two values of type t,
+// calculated with arbitrary precision. If t is a fixed-width type, an OverflowError is returned if the
+// result doesn't fit in it. As with unchecked arithmetic, the result of arithmetic on bools is a uint.
+func integerArithmetic(o *BinaryOperator, t execute.Type, l, r execute.Value) (execute.Value, error) {
+	x, y := must(types.ToBigInt(l)), must(types.ToBigInt(r))
+	z := new(big.Int)
+	switch o {
+	case BinOp_PLUS:
+		z.Add(x, y)
+	case BinOp_MINUS:
+		z.Sub(x, y)
+	case BinOp_TIMES:
+		z.Mul(x, y)
+	case BinOp_FDIV:
+		z.Quo(x, y)
+	case BinOp_EXP:
+		if y.Sign() >= 0 {
+			z.Exp(x, y, nil)
+			break
+		}
+		// A negative power of an integer is a fraction unless the integer is 1 or -1, and fractions are
+		// truncated to 0.
+		switch {
+		case x.Sign() == 0:
+			return nil, errors.NewZeroDivisionError()
+		case x.CmpAbs(big.NewInt(1)) != 0:
+		case x.Sign() < 0 && y.Bit(0) == 1:
+			z.SetInt64(-1)
+		default:
+			z.SetInt64(1)
+		}
+	default:
+		return nil, errors.IncompatibleTypes(l.Type(), r.Type(), o.String())
+	}
+	if t == types.BoolType {
+		t = types.UintType
+	}
+	if !types.FitsType(z, t) {
+		return nil, errors.NewOverflowError(t, o.String())
+	}
+	switch t {
+	case types.IntType:
+		return types.NewInt(z.Int64()), nil
+	case types.UintType:
+		return types.NewUint(z.Uint64()), nil
+	}
+	return types.NewBigInt(z), nil
+}
+
+// decimalArithmetic returns the result of applying an arithmetic operator to two decimals. Results
+// that can't be represented exactly are rounded using ctx. As with ints, floor division and remainders
+// truncate the quotient towards zero.
+func decimalArithmetic(o *BinaryOperator, l, r execute.Value, ctx decimal.Context) (execute.Value, error) {
+	x, err := types.ToDecimal(l)
+	if err != nil {
+		return nil, err
+	}
+	y, err := types.ToDecimal(r)
+	if err != nil {
+		return nil, err
+	}
+	if y.Sign() == 0 && (o == BinOp_DIV || o == BinOp_MOD || o == BinOp_FDIV) {
+		return nil, errors.NewZeroDivisionError()
+	}
+	var z decimal.Decimal
+	switch o {
+	case BinOp_PLUS:
+		z = x.Add(y)
+	case BinOp_MINUS:
+		z = x.Sub(y)
+	case BinOp_TIMES:
+		z = x.Mul(y)
+	case BinOp_DIV:
+		z = x.Quo(y, ctx)
+	case BinOp_FDIV:
+		z, _ = x.QuoRem(y)
+	case BinOp_MOD:
+		_, z = x.QuoRem(y)
+	case BinOp_EXP:
+		if n := y.Int(); y.IsInteger() && n.IsInt64() {
+			if x.Sign() == 0 && n.Sign() < 0 {
+				return nil, errors.NewZeroDivisionError()
+			}
+			z = x.Pow(n.Int64(), ctx)
+			break
+		}
+		// Fractional powers are generally irrational, so they are calculated with floats.
+		d, ok := decimal.FromFloat(math.Pow(x.Float64(), y.Float64()))
+		if !ok {
+			return nil, errors.NewValueError("result of operator \"**\" cannot be represented as a decimal")
+		}
+		z = d.RoundToPrecision(ctx)
+	default:
+		return nil, errors.IncompatibleTypes(l.Type(), r.Type(), o.String())
+	}
+	return types.NewDecimal(z), nil
+}
+
+// timeArithmetic returns the result of applying an operator to two values, at least one of which is a
+// datetime or a duration. Datetimes can be compared with each other, and can be subtracted from each
+// other or offset by a duration. Durations can be compared, added, subtracted, divided, and taken
+// the remainder of with each other, and can be multiplied and divided by numbers. Durations that
+// don't fit in 64 bits of nanoseconds return an OverflowError.
+func timeArithmetic(o *BinaryOperator, l, r execute.Value) (execute.Value, error) {
+	lt, rt := l.Type(), r.Type()
+	if o.IsComparison() {
+		c, ok := l.CompareTo(r)
+		if !ok {
+			return nil, errors.IncompatibleTypes(lt, rt, o.String())
+		}
+		switch o {
+		case BinOp_EQ:
+			return types.NewBool(c == 0), nil
+		case BinOp_NEQ:
+			return types.NewBool(c != 0), nil
+		case BinOp_LT:
+			return types.NewBool(c < 0), nil
+		case BinOp_LEQ:
+			return types.NewBool(c <= 0), nil
+		case BinOp_GT:
+			return types.NewBool(c > 0), nil
+		default:
+			return types.NewBool(c >= 0), nil
+		}
+	}
+
+	// Adding a duration to a datetime is commutative.
+	if lt == types.DurationType && rt == types.DatetimeType && o == BinOp_PLUS {
+		l, r = r, l
+	}
+	if ldt, ok := l.(*types.Datetime); ok {
+		t := ldt.Value()
+		switch r := r.(type) {
+		case *types.Duration:
+			switch o {
+			case BinOp_PLUS:
+				return types.NewDatetime(t.Add(r.Value())), nil
+			case BinOp_MINUS:
+				return types.NewDatetime(t.Add(-r.Value())), nil
+			}
+		case *types.Datetime:
+			if o == BinOp_MINUS {
+				d := t.Sub(r.Value())
+				// Sub saturates at the minimum and maximum durations instead of overflowing.
+				if !r.Value().Add(d).Equal(t) {
+					return nil, errors.NewOverflowError(types.DurationType, o.String())
+				}
+				return types.NewDuration(d), nil
+			}
+		}
+		return nil, errors.IncompatibleTypes(lt, rt, o.String())
+	}
+
+	ld, lok := l.(*types.Duration)
+	rd, rok := r.(*types.Duration)
+	if lok && rok {
+		x, y := big.NewInt(int64(ld.Value())), big.NewInt(int64(rd.Value()))
+		if y.Sign() == 0 && (o == BinOp_DIV || o == BinOp_MOD || o == BinOp_FDIV) {
+			return nil, errors.NewZeroDivisionError()
+		}
+		z := new(big.Int)
+		switch o {
+		case BinOp_PLUS:
+			z.Add(x, y)
+		case BinOp_MINUS:
+			z.Sub(x, y)
+		case BinOp_MOD:
+			z.Rem(x, y)
+		case BinOp_DIV:
+			return types.NewFloat(float64(ld.Value()) / float64(rd.Value())), nil
+		case BinOp_FDIV:
+			return types.NewInt(int64(ld.Value() / rd.Value())), nil
+		default:
+			return nil, errors.IncompatibleTypes(lt, rt, o.String())
+		}
+		return durationResult(o, new(big.Rat).SetInt(z))
+	}
+
+	// The remaining operators scale a duration by a number, and multiplication is commutative.
+	if rok && o == BinOp_TIMES {
+		ld, lok, r = rd, true, l
+	}
+	if !lok || (o != BinOp_TIMES && o != BinOp_DIV) {
+		return nil, errors.IncompatibleTypes(lt, rt, o.String())
+	}
+	factor, ok := exactValue(r)
+	if !ok {
+		return nil, errors.IncompatibleTypes(lt, rt, o.String())
+	}
+	z := new(big.Rat).SetInt64(int64(ld.Value()))
+	if o == BinOp_TIMES {
+		z.Mul(z, factor)
+	} else {
+		if factor.Sign() == 0 {
+			return nil, errors.NewZeroDivisionError()
+		}
+		z.Quo(z, factor)
+	}
+	return durationResult(o, z)
+}
+
+// exactValue returns the exact value of a number, or false if v is not a finite number.
+func exactValue(v execute.Value) (*big.Rat, bool) {
+	switch v := v.(type) {
+	case *types.BigInt, *types.Bool, *types.Int, *types.Uint:
+		return new(big.Rat).SetInt(must(types.ToBigInt(v))), true
+	case *types.Decimal:
+		d := v.Value()
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(d.Scale(), -d.Scale()))), nil)
+		if d.Scale() < 0 {
+			return new(big.Rat).SetInt(pow.Mul(pow, d.Coefficient())), true
+		}
+		return new(big.Rat).SetFrac(d.Coefficient(), pow), true
+	case *types.Float:
+		if r := new(big.Rat).SetFloat64(must(v.ToFloat())); r != nil {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// durationResult returns a duration of z nanoseconds, rounded to the nearest nanosecond with ties
+// rounded to even.
+func durationResult(o *BinaryOperator, z *big.Rat) (execute.Value, error) {
+	q, m := new(big.Int).QuoRem(z.Num(), z.Denom(), new(big.Int))
+	// Round the truncated quotient away from zero if the remainder is more than half of the
+	// denominator, or exactly half and the quotient is odd.
+	if c := new(big.Int).Lsh(m.Abs(m), 1).Cmp(z.Denom()); c > 0 || c == 0 && q.Bit(0) == 1 {
+		q.Add(q, big.NewInt(int64(z.Sign())))
+	}
+	if !q.IsInt64() {
+		return nil, errors.NewOverflowError(types.DurationType, o.String())
+	}
+	return types.NewDuration(time.Duration(q.Int64())), nil
+}
+
+// bitwise returns the result of applying a bitwise operator to two values of type t. As with
+// arithmetic, the result for bools is a uint. Bytes are combined byte by byte, so they must have the
+// same length.
+func bitwise(o *BinaryOperator, t execute.Type, l, r execute.Value) (execute.Value, error) {
+	switch t {
+	case types.BigIntType:
+		x, y := must(types.ToBigInt(l)), must(types.ToBigInt(r))
+		z := new(big.Int)
+		switch o {
+		case BinOp_BAND:
+			z.And(x, y)
+		case BinOp_BOR:
+			z.Or(x, y)
+		case BinOp_BXOR:
+			z.Xor(x, y)
+		}
+		return types.NewBigInt(z), nil
+	case types.BytesType:
+		x, y := must(l.ToBytes()), must(r.ToBytes())
+		if len(x) != len(y) {
+			return nil, errors.NewValueError(fmt.Sprintf("operator %q requires bytes of the same length, got %d and %d", o.String(), len(x), len(y)))
+		}
+		for i := range x {
+			x[i] = applyBitwise(o, x[i], y[i])
+		}
+		return types.NewBytes(x), nil
+	case types.IntType:
+		return types.NewInt(applyBitwise(o, must(l.ToInt()), must(r.ToInt()))), nil
+	}
+	return types.NewUint(applyBitwise(o, must(l.ToUint()), must(r.ToUint()))), nil
+}
+
+func applyBitwise[T byte | int64 | uint64](o *BinaryOperator, x, y T) T {
+	switch o {
+	case BinOp_BAND:
+		return x & y
+	case BinOp_BOR:
+		return x | y
+	}
+	return x ^ y
+}
+
+// shift returns the result of shifting l left or right by r bits. The result has the type of l (or
+// uint if l is a bool), and bytes are shifted as a single big-endian number that keeps its length.
+// Shifting by a negative number of bits is an error. Right shifts of signed values fill with the sign
+// bit, and in checked mode, left shifts that lose bits of an int or uint return an OverflowError.
+func shift(o *BinaryOperator, l, r execute.Value, checked bool) (execute.Value, error) {
+	lt, rt := l.Type(), r.Type()
+	if !bitwiseTypes[lt] {
+		return nil, errors.IncompatibleType(lt, o.String())
+	}
+	if !integerTypes[rt] && rt != types.BoolType {
+		return nil, errors.IncompatibleType(rt, o.String())
+	}
+	count := must(types.ToBigInt(r))
+	if count.Sign() < 0 {
+		return nil, errors.NewValueError(fmt.Sprintf("negative shift count %s", count))
+	}
+	// Counts that don't fit in a uint shift every bit out of fixed-width values anyway.
+	n, fits := uint(math.MaxUint), count.IsUint64() && count.Uint64() <= math.MaxUint
+	if fits {
+		n = uint(count.Uint64())
+	}
+	left := o == BinOp_LSHIFT
+	switch lt {
+	case types.BigIntType:
+		x := must(types.ToBigInt(l))
+		if !left {
+			return types.NewBigInt(new(big.Int).Rsh(x, n)), nil
+		}
+		if x.Sign() != 0 && !fits {
+			return nil, errors.NewOverflowError(types.BigIntType, o.String())
+		}
+		return types.NewBigInt(new(big.Int).Lsh(x, n)), nil
+	case types.BytesType:
+		b := must(l.ToBytes())
+		x := new(big.Int).SetBytes(b)
+		switch {
+		case !left:
+			x.Rsh(x, n)
+		case n < uint(len(b))*8:
+			x.Lsh(x, n)
+		default:
+			x.SetInt64(0)
+		}
+		// Drop the bytes that were shifted past the start of the value.
+		out := x.Bytes()
+		if len(out) > len(b) {
+			out = out[len(out)-len(b):]
+		}
+		clear(b)
+		copy(b[len(b)-len(out):], out)
+		return types.NewBytes(b), nil
+	case types.IntType:
+		x := must(l.ToInt())
+		if !left {
+			return types.NewInt(x >> n), nil
+		}
+		if checked && x != 0 && (n >= 64 || x<<n>>n != x) {
+			return nil, errors.NewOverflowError(types.IntType, o.String())
+		}
+		return types.NewInt(x << n), nil
+	}
+	x := must(l.ToUint())
+	if !left {
+		return types.NewUint(x >> n), nil
+	}
+	if checked && x != 0 && (n >= 64 || x<<n>>n != x) {
+		return nil, errors.NewOverflowError(types.UintType, o.String())
+	}
+	return types.NewUint(x << n), nil
+}
+
 func (o *BinaryOperator) IsComparison() bool {
 	return o == BinOp_EQ ||
 		o == BinOp_NEQ ||
@@ -242,22 +689,27 @@ func (o *BinaryOperator) String() string {
 }
 
 var operatorPrecedence = map[*BinaryOperator]int{
-	BinOp_EXP:   -2,
-	BinOp_TIMES: -1,
-	BinOp_DIV:   -1,
-	BinOp_FDIV:  -1,
-	BinOp_MOD:   -1,
-	BinOp_PLUS:  0,
-	BinOp_MINUS: 0,
-	BinOp_EQ:    1,
-	BinOp_NEQ:   1,
-	BinOp_LT:    1,
-	BinOp_LEQ:   1,
-	BinOp_GT:    1,
-	BinOp_GEQ:   1,
-	BinOp_AND:   2,
-	BinOp_OR:    2,
-	BinOp_XOR:   2,
+	BinOp_EXP:    -2,
+	BinOp_TIMES:  -1,
+	BinOp_DIV:    -1,
+	BinOp_FDIV:   -1,
+	BinOp_MOD:    -1,
+	BinOp_PLUS:   0,
+	BinOp_MINUS:  0,
+	BinOp_LSHIFT: 1,
+	BinOp_RSHIFT: 1,
+	BinOp_BAND:   2,
+	BinOp_BXOR:   3,
+	BinOp_BOR:    4,
+	BinOp_EQ:     5,
+	BinOp_NEQ:    5,
+	BinOp_LT:     5,
+	BinOp_LEQ:    5,
+	BinOp_GT:     5,
+	BinOp_GEQ:    5,
+	BinOp_AND:    6,
+	BinOp_OR:     6,
+	BinOp_XOR:    6,
 }
 
 // Compare returns true if this BinaryOperator takes precendence over other (i.e. this operation
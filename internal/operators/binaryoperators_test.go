@@ -2,9 +2,13 @@ package operators
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"slices"
 	"testing"
+	"time"
 
+	"github.com/chrispyles/slow/internal/decimal"
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	slowtesting "github.com/chrispyles/slow/internal/testing"
@@ -588,6 +592,320 @@ func TestBinaryOperator_Value(t *testing.T) {
 	}
 }
 
+func TestBinaryOperator_BigInt(t *testing.T) {
+	big2To64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	bigInt := func(i int64) execute.Value { return types.NewBigInt(big.NewInt(i)) }
+	tests := []struct {
+		op      *BinaryOperator
+		left    execute.Value
+		right   execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{BinOp_PLUS, types.NewBigInt(big2To64), types.NewInt(-1), types.NewBigInt(new(big.Int).SetUint64(math.MaxUint64)), nil},
+		{BinOp_PLUS, types.NewUint(math.MaxUint64), bigInt(1), types.NewBigInt(big2To64), nil},
+		{BinOp_MINUS, types.NewBool(false), bigInt(1), bigInt(-1), nil},
+		{BinOp_TIMES, types.NewInt(math.MaxInt64), bigInt(2), types.NewBigInt(new(big.Int).SetUint64(math.MaxUint64 - 1)), nil},
+		{BinOp_DIV, bigInt(5), types.NewInt(2), types.NewFloat(2.5), nil},
+		{BinOp_FDIV, bigInt(-7), types.NewInt(2), bigInt(-3), nil},
+		{BinOp_FDIV, bigInt(1), types.NewInt(0), nil, errors.NewZeroDivisionError()},
+		{BinOp_MOD, bigInt(-7), types.NewUint(3), bigInt(-1), nil},
+		{BinOp_MOD, types.NewFloat(7), bigInt(4), bigInt(3), nil},
+		{BinOp_MOD, types.NewInt(1), bigInt(0), nil, errors.NewZeroDivisionError()},
+		{BinOp_EXP, bigInt(2), types.NewInt(64), types.NewBigInt(big2To64), nil},
+		{BinOp_EXP, bigInt(2), types.NewInt(-1), bigInt(0), nil},
+		{BinOp_EXP, bigInt(-1), types.NewInt(-3), bigInt(-1), nil},
+		{BinOp_EXP, bigInt(0), types.NewInt(-1), nil, errors.NewZeroDivisionError()},
+		{BinOp_PLUS, bigInt(1), types.NewFloat(0.5), types.NewFloat(1.5), nil},
+		{BinOp_PLUS, bigInt(1), types.NewStr("a"), nil, errors.IncompatibleTypes(types.BigIntType, types.StrType, "+")},
+		{BinOp_LT, types.NewUint(math.MaxUint64), types.NewBigInt(big2To64), types.NewBool(true), nil},
+		{BinOp_EQ, types.NewBigInt(big2To64), types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64)), types.NewBool(true), nil},
+		{BinOp_GEQ, bigInt(1), types.NewFloat(1.5), types.NewBool(false), nil},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s_%s_%s_%s", tc.op, tc.left.Type(), tc.left, tc.right.Type(), tc.right), func(t *testing.T) {
+			got, err := tc.op.Value(tc.left, tc.right)
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBinaryOperator_Decimal(t *testing.T) {
+	dec := func(s string) execute.Value { return types.NewDecimal(decimal.MustParse(s)) }
+	tests := []struct {
+		op      *BinaryOperator
+		left    execute.Value
+		right   execute.Value
+		opts    Options
+		want    execute.Value
+		wantErr error
+	}{
+		{op: BinOp_PLUS, left: dec("0.1"), right: dec("0.2"), want: dec("0.3")},
+		{op: BinOp_PLUS, left: types.NewFloat(0.1), right: dec("0.2"), want: dec("0.3")},
+		{op: BinOp_RPLUS, left: dec("1.50"), right: types.NewInt(2), want: dec("3.50")},
+		{op: BinOp_MINUS, left: types.NewBigInt(big.NewInt(1)), right: dec("0.01"), want: dec("0.99")},
+		{op: BinOp_TIMES, left: dec("1.10"), right: types.NewUint(3), want: dec("3.30")},
+		{op: BinOp_DIV, left: dec("1"), right: types.NewInt(3), want: dec("0.3333333333333333333333333333")},
+		{op: BinOp_DIV, left: dec("2"), right: types.NewInt(3), opts: Options{Decimal: decimal.Context{Precision: 3}}, want: dec("0.667")},
+		{
+			op:    BinOp_DIV,
+			left:  dec("2"),
+			right: types.NewInt(3),
+			opts:  Options{Decimal: decimal.Context{Precision: 3, Rounding: decimal.Down}},
+			want:  dec("0.666"),
+		},
+		{op: BinOp_DIV, left: dec("10.00"), right: types.NewInt(4), want: dec("2.50")},
+		{op: BinOp_DIV, left: dec("1"), right: dec("0.00"), wantErr: errors.NewZeroDivisionError()},
+		{op: BinOp_FDIV, left: dec("-7.5"), right: types.NewInt(2), want: dec("-3")},
+		{op: BinOp_MOD, left: dec("-7.5"), right: types.NewInt(2), want: dec("-1.5")},
+		{op: BinOp_MOD, left: types.NewInt(7), right: dec("0.5"), want: dec("0.0")},
+		{op: BinOp_MOD, left: dec("1"), right: types.NewInt(0), wantErr: errors.NewZeroDivisionError()},
+		{op: BinOp_EXP, left: dec("1.1"), right: types.NewInt(2), want: dec("1.21")},
+		{op: BinOp_EXP, left: dec("2"), right: types.NewInt(-2), want: dec("0.25")},
+		{op: BinOp_EXP, left: dec("0"), right: types.NewInt(-1), wantErr: errors.NewZeroDivisionError()},
+		{op: BinOp_EXP, left: dec("4"), right: dec("0.5"), want: dec("2")},
+		{
+			op:      BinOp_EXP,
+			left:    dec("-4"),
+			right:   dec("0.5"),
+			wantErr: errors.NewValueError(`result of operator "**" cannot be represented as a decimal`),
+		},
+		{
+			op:      BinOp_PLUS,
+			left:    dec("1"),
+			right:   types.NewFloat(math.Inf(1)),
+			wantErr: errors.ConversionOverflowError("+Inf", types.DecimalType),
+		},
+		{op: BinOp_PLUS, left: dec("1"), right: types.NewStr("a"), wantErr: errors.IncompatibleTypes(types.DecimalType, types.StrType, "+")},
+		{op: BinOp_EQ, left: dec("1.50"), right: dec("1.5"), want: types.NewBool(true)},
+		{op: BinOp_LT, left: dec("0.1"), right: types.NewFloat(0.1), want: types.NewBool(false)},
+		{op: BinOp_GT, left: dec("1e100"), right: types.NewUint(math.MaxUint64), want: types.NewBool(true)},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s_%s_%s_%s", tc.op, tc.left.Type(), tc.left, tc.right.Type(), tc.right), func(t *testing.T) {
+			got, err := tc.op.ValueWith(tc.left, tc.right, tc.opts)
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBinaryOperator_Time(t *testing.T) {
+	dec := func(s string) execute.Value { return types.NewDecimal(decimal.MustParse(s)) }
+	dur := func(d time.Duration) execute.Value { return types.NewDuration(d) }
+	ny, err := types.LoadZone("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	epoch := time.Unix(0, 0).UTC()
+	dt := func(d time.Duration) execute.Value { return types.NewDatetime(epoch.Add(d)) }
+	tests := []struct {
+		op      *BinaryOperator
+		left    execute.Value
+		right   execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{op: BinOp_PLUS, left: dt(0), right: dur(time.Hour), want: dt(time.Hour)},
+		{op: BinOp_PLUS, left: dur(time.Hour), right: dt(0), want: dt(time.Hour)},
+		{op: BinOp_RPLUS, left: dt(0), right: dur(time.Minute), want: dt(time.Minute)},
+		{op: BinOp_MINUS, left: dt(0), right: dur(time.Hour), want: dt(-time.Hour)},
+		{op: BinOp_MINUS, left: dt(time.Hour), right: dt(time.Minute), want: dur(59 * time.Minute)},
+		{
+			// Adding a duration to a datetime adds elapsed time, even across a daylight saving time change.
+			op:    BinOp_PLUS,
+			left:  types.NewDatetime(time.Date(2024, 3, 10, 1, 30, 0, 0, ny)),
+			right: dur(time.Hour),
+			want:  types.NewDatetime(time.Date(2024, 3, 10, 3, 30, 0, 0, ny)),
+		},
+		{
+			op:      BinOp_MINUS,
+			left:    types.NewDatetime(time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)),
+			right:   dt(0),
+			wantErr: errors.NewOverflowError(types.DurationType, "-"),
+		},
+		{op: BinOp_MINUS, left: dur(time.Hour), right: dt(0), wantErr: errors.IncompatibleTypes(types.DurationType, types.DatetimeType, "-")},
+		{op: BinOp_PLUS, left: dt(0), right: dt(0), wantErr: errors.IncompatibleTypes(types.DatetimeType, types.DatetimeType, "+")},
+		{op: BinOp_PLUS, left: dt(0), right: types.NewInt(1), wantErr: errors.IncompatibleTypes(types.DatetimeType, types.IntType, "+")},
+		{op: BinOp_PLUS, left: dur(time.Hour), right: dur(time.Minute), want: dur(61 * time.Minute)},
+		{op: BinOp_MINUS, left: dur(time.Minute), right: dur(time.Hour), want: dur(-59 * time.Minute)},
+		{op: BinOp_MOD, left: dur(100 * time.Second), right: dur(time.Minute), want: dur(40 * time.Second)},
+		{op: BinOp_DIV, left: dur(90 * time.Second), right: dur(time.Minute), want: types.NewFloat(1.5)},
+		{op: BinOp_FDIV, left: dur(90 * time.Second), right: dur(time.Minute), want: types.NewInt(1)},
+		{op: BinOp_DIV, left: dur(time.Second), right: dur(0), wantErr: errors.NewZeroDivisionError()},
+		{op: BinOp_MOD, left: dur(time.Second), right: dur(0), wantErr: errors.NewZeroDivisionError()},
+		{op: BinOp_TIMES, left: dur(time.Hour), right: dur(time.Hour), wantErr: errors.IncompatibleTypes(types.DurationType, types.DurationType, "*")},
+		{
+			op:      BinOp_PLUS,
+			left:    dur(math.MaxInt64),
+			right:   dur(1),
+			wantErr: errors.NewOverflowError(types.DurationType, "+"),
+		},
+		{op: BinOp_TIMES, left: dur(time.Minute), right: types.NewInt(3), want: dur(3 * time.Minute)},
+		{op: BinOp_TIMES, left: types.NewFloat(1.5), right: dur(time.Minute), want: dur(90 * time.Second)},
+		{op: BinOp_TIMES, left: dur(time.Second), right: dec("0.0000000015"), want: dur(2)},
+		{op: BinOp_RTIMES, left: dur(time.Second), right: types.NewUint(2), want: dur(2 * time.Second)},
+		{op: BinOp_DIV, left: dur(time.Minute), right: types.NewInt(4), want: dur(15 * time.Second)},
+		{op: BinOp_DIV, left: dur(time.Minute), right: types.NewFloat(0), wantErr: errors.NewZeroDivisionError()},
+		{op: BinOp_DIV, left: types.NewInt(4), right: dur(time.Minute), wantErr: errors.IncompatibleTypes(types.IntType, types.DurationType, "/")},
+		{op: BinOp_MINUS, left: dur(time.Minute), right: types.NewInt(1), wantErr: errors.IncompatibleTypes(types.DurationType, types.IntType, "-")},
+		{
+			op:      BinOp_TIMES,
+			left:    dur(time.Second),
+			right:   types.NewFloat(math.Inf(1)),
+			wantErr: errors.IncompatibleTypes(types.DurationType, types.FloatType, "*"),
+		},
+		{op: BinOp_TIMES, left: dur(time.Hour), right: types.NewInt(1 << 40), wantErr: errors.NewOverflowError(types.DurationType, "*")},
+		{op: BinOp_EQ, left: dur(time.Minute), right: dur(60 * time.Second), want: types.NewBool(true)},
+		{op: BinOp_LT, left: dur(time.Second), right: dur(time.Minute), want: types.NewBool(true)},
+		{op: BinOp_GEQ, left: dt(0), right: dt(time.Second), want: types.NewBool(false)},
+		{
+			op:    BinOp_EQ,
+			left:  types.NewDatetime(time.Date(2024, 1, 1, 7, 0, 0, 0, ny)),
+			right: types.NewDatetime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+			want:  types.NewBool(true),
+		},
+		{op: BinOp_EQ, left: dur(time.Second), right: types.NewInt(1), wantErr: errors.IncompatibleTypes(types.DurationType, types.IntType, "==")},
+		{op: BinOp_LT, left: dt(0), right: dur(0), wantErr: errors.IncompatibleTypes(types.DatetimeType, types.DurationType, "<")},
+		{op: BinOp_AND, left: dur(0), right: types.NewInt(1), want: dur(0)},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s_%s_%s_%s", tc.op, tc.left.Type(), tc.left, tc.right.Type(), tc.right), func(t *testing.T) {
+			got, err := tc.op.Value(tc.left, tc.right)
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBinaryOperator_Bitwise(t *testing.T) {
+	bigInt := func(i int64) execute.Value { return types.NewBigInt(big.NewInt(i)) }
+	tests := []struct {
+		op      *BinaryOperator
+		left    execute.Value
+		right   execute.Value
+		opts    Options
+		want    execute.Value
+		wantErr error
+	}{
+		{op: BinOp_BAND, left: types.NewInt(6), right: types.NewInt(3), want: types.NewInt(2)},
+		{op: BinOp_BOR, left: types.NewInt(6), right: types.NewInt(3), want: types.NewInt(7)},
+		{op: BinOp_BXOR, left: types.NewInt(6), right: types.NewInt(3), want: types.NewInt(5)},
+		{op: BinOp_BAND, left: types.NewInt(-1), right: types.NewUint(0xFF), want: types.NewInt(0xFF)},
+		{op: BinOp_BOR, left: types.NewUint(1), right: types.NewUint(2), want: types.NewUint(3)},
+		{op: BinOp_BXOR, left: types.NewBool(true), right: types.NewBool(true), want: types.NewUint(0)},
+		{op: BinOp_RBAND, left: types.NewInt(12), right: types.NewBool(true), want: types.NewInt(0)},
+		{op: BinOp_BAND, left: bigInt(-1), right: types.NewUint(math.MaxUint64), want: types.NewBigInt(new(big.Int).SetUint64(math.MaxUint64))},
+		{op: BinOp_BXOR, left: bigInt(5), right: bigInt(-1), want: bigInt(-6)},
+		{op: BinOp_BAND, left: types.NewBytes([]byte{0xF0, 0xF0}), right: types.NewBytes([]byte{0x0F, 0xF0}), want: types.NewBytes([]byte{0x00, 0xF0})},
+		{op: BinOp_RBOR, left: types.NewBytes([]byte{0x01}), right: types.NewBytes([]byte{0x10}), want: types.NewBytes([]byte{0x11})},
+		{
+			op:      BinOp_BXOR,
+			left:    types.NewBytes([]byte{0x01}),
+			right:   types.NewBytes([]byte{0x01, 0x02}),
+			wantErr: errors.NewValueError(`operator "^" requires bytes of the same length, got 1 and 2`),
+		},
+		{op: BinOp_BAND, left: types.NewBytes([]byte{0x01}), right: types.NewInt(1), wantErr: errors.IncompatibleTypes(types.BytesType, types.IntType, "&")},
+		{op: BinOp_BOR, left: types.NewFloat(1), right: types.NewInt(1), wantErr: errors.IncompatibleTypes(types.FloatType, types.IntType, "|")},
+		{
+			op:      BinOp_BOR,
+			left:    types.NewFloat(math.NaN()),
+			right:   types.NewDecimal(decimal.MustParse("1")),
+			wantErr: errors.IncompatibleTypes(types.FloatType, types.DecimalType, "|"),
+		},
+		{op: BinOp_LSHIFT, left: types.NewInt(1), right: types.NewInt(4), want: types.NewInt(16)},
+		{op: BinOp_LSHIFT, left: types.NewInt(1), right: types.NewInt(64), want: types.NewInt(0)},
+		{op: BinOp_RSHIFT, left: types.NewInt(-16), right: types.NewUint(2), want: types.NewInt(-4)},
+		{op: BinOp_RSHIFT, left: types.NewInt(-16), right: types.NewInt(100), want: types.NewInt(-1)},
+		{op: BinOp_RSHIFT, left: types.NewInt(16), right: types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 70)), want: types.NewInt(0)},
+		{op: BinOp_LSHIFT, left: types.NewUint(1), right: types.NewInt(63), want: types.NewUint(1 << 63)},
+		{op: BinOp_RLSHIFT, left: types.NewBool(true), right: types.NewInt(2), want: types.NewUint(4)},
+		{op: BinOp_RRSHIFT, left: types.NewUint(math.MaxUint64), right: types.NewInt(60), want: types.NewUint(15)},
+		{op: BinOp_LSHIFT, left: bigInt(1), right: types.NewInt(64), want: types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64))},
+		{op: BinOp_RSHIFT, left: bigInt(-5), right: types.NewInt(1), want: bigInt(-3)},
+		{
+			op:      BinOp_LSHIFT,
+			left:    bigInt(1),
+			right:   types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 70)),
+			wantErr: errors.NewOverflowError(types.BigIntType, "<<"),
+		},
+		{op: BinOp_LSHIFT, left: types.NewBytes([]byte{0x0F, 0xF0}), right: types.NewInt(4), want: types.NewBytes([]byte{0xFF, 0x00})},
+		{op: BinOp_RSHIFT, left: types.NewBytes([]byte{0x0F, 0xF0}), right: types.NewInt(12), want: types.NewBytes([]byte{0x00, 0x00})},
+		{op: BinOp_RSHIFT, left: types.NewBytes([]byte{0x80, 0x00}), right: types.NewInt(9), want: types.NewBytes([]byte{0x00, 0x40})},
+		{op: BinOp_LSHIFT, left: types.NewBytes([]byte{0xFF}), right: types.NewInt(8), want: types.NewBytes([]byte{0x00})},
+		{op: BinOp_LSHIFT, left: types.NewInt(1), right: types.NewInt(-1), wantErr: errors.NewValueError("negative shift count -1")},
+		{op: BinOp_LSHIFT, left: types.NewInt(1), right: types.NewFloat(1), wantErr: errors.IncompatibleType(types.FloatType, "<<")},
+		{op: BinOp_RSHIFT, left: types.NewStr("a"), right: types.NewInt(1), wantErr: errors.IncompatibleType(types.StrType, ">>")},
+		{op: BinOp_LSHIFT, left: types.NewInt(1), right: types.NewInt(62), opts: Options{Checked: true}, want: types.NewInt(1 << 62)},
+		{op: BinOp_LSHIFT, left: types.NewInt(-1), right: types.NewInt(63), opts: Options{Checked: true}, want: types.NewInt(math.MinInt64)},
+		{op: BinOp_LSHIFT, left: types.NewInt(0), right: types.NewInt(100), opts: Options{Checked: true}, want: types.NewInt(0)},
+		{op: BinOp_LSHIFT, left: types.NewInt(1), right: types.NewInt(63), opts: Options{Checked: true}, wantErr: errors.NewOverflowError(types.IntType, "<<")},
+		{op: BinOp_LSHIFT, left: types.NewUint(3), right: types.NewInt(63), opts: Options{Checked: true}, wantErr: errors.NewOverflowError(types.UintType, "<<")},
+		{op: BinOp_RSHIFT, left: types.NewInt(1), right: types.NewInt(100), opts: Options{Checked: true}, want: types.NewInt(0)},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s_%s_%s_%s", tc.op, tc.left.Type(), tc.left, tc.right.Type(), tc.right), func(t *testing.T) {
+			got, err := tc.op.ValueWith(tc.left, tc.right, tc.opts)
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBinaryOperator_ValueWith_checked(t *testing.T) {
+	tests := []struct {
+		op      *BinaryOperator
+		left    execute.Value
+		right   execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{BinOp_PLUS, types.NewInt(math.MaxInt64 - 1), types.NewInt(1), types.NewInt(math.MaxInt64), nil},
+		{BinOp_PLUS, types.NewInt(math.MaxInt64), types.NewInt(1), nil, errors.NewOverflowError(types.IntType, "+")},
+		{BinOp_RPLUS, types.NewInt(math.MaxInt64), types.NewUint(1), nil, errors.NewOverflowError(types.IntType, "+")},
+		{BinOp_MINUS, types.NewUint(1), types.NewUint(2), nil, errors.NewOverflowError(types.UintType, "-")},
+		{BinOp_MINUS, types.NewBool(false), types.NewBool(true), nil, errors.NewOverflowError(types.UintType, "-")},
+		{BinOp_TIMES, types.NewUint(1 << 32), types.NewUint(1 << 32), nil, errors.NewOverflowError(types.UintType, "*")},
+		{BinOp_TIMES, types.NewInt(-3), types.NewInt(4), types.NewInt(-12), nil},
+		{BinOp_FDIV, types.NewInt(math.MinInt64), types.NewInt(-1), nil, errors.NewOverflowError(types.IntType, "//")},
+		{BinOp_FDIV, types.NewInt(-7), types.NewInt(2), types.NewInt(-3), nil},
+		{BinOp_EXP, types.NewInt(2), types.NewInt(63), nil, errors.NewOverflowError(types.IntType, "**")},
+		{BinOp_EXP, types.NewUint(2), types.NewUint(63), types.NewUint(1 << 63), nil},
+		{BinOp_MOD, types.NewInt(math.MinInt64), types.NewInt(-1), types.NewInt(0), nil},
+		{BinOp_PLUS, types.NewFloat(math.MaxFloat64), types.NewFloat(math.MaxFloat64), types.NewFloat(math.Inf(1)), nil},
+		{BinOp_PLUS, types.NewStr("a"), types.NewStr("b"), types.NewStr("ab"), nil},
+		{BinOp_PLUS, types.NewInt(math.MaxInt64), types.NewBigInt(big.NewInt(1)), types.NewBigInt(new(big.Int).SetUint64(1 << 63)), nil},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s_%s_%s_%s", tc.op, tc.left.Type(), tc.left, tc.right.Type(), tc.right), func(t *testing.T) {
+			got, err := tc.op.ValueWith(tc.left, tc.right, Options{Checked: true})
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestBinaryOperator_IsComparison(t *testing.T) {
 	comparisons := map[string]bool{
 		"==": true,
@@ -618,6 +936,11 @@ func TestBinaryOperator_IsReassignmentOperator(t *testing.T) {
 		"&&=": true,
 		"||=": true,
 		"^^=": true,
+		"&=":  true,
+		"|=":  true,
+		"^=":  true,
+		"<<=": true,
+		">>=": true,
 	}
 	for _, op := range binaryOperators {
 		t.Run(op.String(), func(t *testing.T) {
@@ -630,20 +953,30 @@ func TestBinaryOperator_IsReassignmentOperator(t *testing.T) {
 
 func TestBinaryOperator_Compare(t *testing.T) {
 	greaterToLowerPrecedence := map[string][]string{
-		"**":  {"*", "/", "//", "%", "+", "-", "*=", "/=", "//=", "%=", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"**=": {"*", "/", "//", "%", "+", "-", "*=", "/=", "//=", "%=", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"*":   {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"/":   {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"//":  {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"%":   {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"*=":  {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"/=":  {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"//=": {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"%=":  {"+", "-", "+=", "-=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"+":   {"==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"-":   {"==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"+=":  {"==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
-		"-=":  {"==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"**":  {"*", "/", "//", "%", "+", "-", "<<", ">>", "&", "^", "|", "*=", "/=", "//=", "%=", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"**=": {"*", "/", "//", "%", "+", "-", "<<", ">>", "&", "^", "|", "*=", "/=", "//=", "%=", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"*":   {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"/":   {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"//":  {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"%":   {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"*=":  {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"/=":  {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"//=": {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"%=":  {"+", "-", "<<", ">>", "&", "^", "|", "+=", "-=", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"+":   {"<<", ">>", "&", "^", "|", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"-":   {"<<", ">>", "&", "^", "|", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"+=":  {"<<", ">>", "&", "^", "|", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"-=":  {"<<", ">>", "&", "^", "|", "<<=", ">>=", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"<<":  {"&", "^", "|", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		">>":  {"&", "^", "|", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"<<=": {"&", "^", "|", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		">>=": {"&", "^", "|", "&=", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"&":   {"^", "|", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"&=":  {"^", "|", "^=", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"^":   {"|", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"^=":  {"|", "|=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"|":   {"==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
+		"|=":  {"==", "!=", "<", "<=", ">", ">=", "&&", "||", "^^", "&&=", "||=", "^^="},
 		"==":  {"&&", "||", "^^", "&&=", "||=", "^^="},
 		"!=":  {"&&", "||", "^^", "&&=", "||=", "^^="},
 		">":   {"&&", "||", "^^", "&&=", "||=", "^^="},
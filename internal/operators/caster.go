@@ -1,6 +1,9 @@
 package operators
 
 import (
+	"math/big"
+
+	"github.com/chrispyles/slow/internal/decimal"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/types"
 )
@@ -27,8 +30,16 @@ func (c *typeCaster) singleCast(val execute.Value) (execute.Value, error) {
 	var res execute.Value
 	var err error
 	switch c.dest {
+	case types.BigIntType:
+		var v *big.Int
+		v, err = types.ToBigInt(val)
+		res = types.NewBigInt(v)
 	case types.BoolType:
 		res = types.NewBool(val.ToBool())
+	case types.DecimalType:
+		var v decimal.Decimal
+		v, err = types.ToDecimal(val)
+		res = types.NewDecimal(v)
 	case types.FloatType:
 		var v float64
 		v, err = val.ToFloat()
@@ -2,8 +2,10 @@ package operators
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/chrispyles/slow/internal/decimal"
 	"github.com/chrispyles/slow/internal/execute"
 	slowcmpopts "github.com/chrispyles/slow/internal/testing/cmpopts"
 	"github.com/chrispyles/slow/internal/types"
@@ -40,6 +42,27 @@ func TestTypeCaster(t *testing.T) {
 			wantLeftCast:  types.NewInt(1),
 			wantRightCast: types.NewInt(2),
 		},
+		{
+			left:          types.NewUint(1),
+			right:         types.NewBigInt(big.NewInt(2)),
+			wantDest:      types.BigIntType,
+			wantLeftCast:  types.NewBigInt(big.NewInt(1)),
+			wantRightCast: types.NewBigInt(big.NewInt(2)),
+		},
+		{
+			left:          types.NewFloat(1),
+			right:         types.NewBigInt(big.NewInt(2)),
+			wantDest:      types.FloatType,
+			wantLeftCast:  types.NewFloat(1),
+			wantRightCast: types.NewFloat(2),
+		},
+		{
+			left:          types.NewFloat(0.1),
+			right:         types.NewDecimal(decimal.MustParse("2")),
+			wantDest:      types.DecimalType,
+			wantLeftCast:  types.NewDecimal(decimal.MustParse("0.1")),
+			wantRightCast: types.NewDecimal(decimal.MustParse("2")),
+		},
 		{
 			left:          types.NewBool(true),
 			right:         types.NewUint(2),
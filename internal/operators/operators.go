@@ -3,6 +3,9 @@ package operators
 import (
 	"iter"
 	"maps"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/execute"
 )
 
 var (
@@ -48,6 +51,22 @@ func newBinaryOperator(chars string) *BinaryOperator {
 	return bop
 }
 
+// Options configure how operators calculate their results. With the zero value, int and uint
+// arithmetic wraps around on overflow and inexact decimal results are rounded with the default
+// decimal context.
+type Options struct {
+	// Checked indicates whether int and uint arithmetic returns an OverflowError instead of wrapping
+	// around when a result doesn't fit in its type.
+	Checked bool
+	// Decimal determines how decimal results that can't be represented exactly are rounded.
+	Decimal decimal.Context
+}
+
+// RuntimeOptions returns the Options configured for the provided runtime, which may be nil.
+func RuntimeOptions(r *execute.Runtime) Options {
+	return Options{Checked: r.IsCheckedArithmetic(), Decimal: r.DecimalContext()}
+}
+
 func ToUnaryOp(maybeOp string) (*UnaryOperator, bool) {
 	if op, ok := unaryOperators[maybeOp]; ok {
 		return op, true
@@ -69,6 +88,7 @@ var (
 	UnOp_NOT  = newUnaryOperator("!")
 	UnOp_INCR = newUnaryOperator("++")
 	UnOp_DECR = newUnaryOperator("--")
+	UnOp_BNOT = newUnaryOperator("~")
 )
 
 // binary operators
@@ -85,17 +105,29 @@ var (
 	BinOp_OR    = newBinaryOperator("||")
 	BinOp_XOR   = newBinaryOperator("^^")
 
+	// bitwise operators
+	BinOp_BAND   = newBinaryOperator("&")
+	BinOp_BOR    = newBinaryOperator("|")
+	BinOp_BXOR   = newBinaryOperator("^")
+	BinOp_LSHIFT = newBinaryOperator("<<")
+	BinOp_RSHIFT = newBinaryOperator(">>")
+
 	// reassignment operators
-	BinOp_RPLUS  = newBinaryOperator("+=")
-	BinOp_RMINUS = newBinaryOperator("-=")
-	BinOp_RTIMES = newBinaryOperator("*=")
-	BinOp_RDIV   = newBinaryOperator("/=")
-	BinOp_RMOD   = newBinaryOperator("%=")
-	BinOp_RFDIV  = newBinaryOperator("//=")
-	BinOp_REXP   = newBinaryOperator("**=")
-	BinOp_RAND   = newBinaryOperator("&&=")
-	BinOp_ROR    = newBinaryOperator("||=")
-	BinOp_RXOR   = newBinaryOperator("^^=")
+	BinOp_RPLUS   = newBinaryOperator("+=")
+	BinOp_RMINUS  = newBinaryOperator("-=")
+	BinOp_RTIMES  = newBinaryOperator("*=")
+	BinOp_RDIV    = newBinaryOperator("/=")
+	BinOp_RMOD    = newBinaryOperator("%=")
+	BinOp_RFDIV   = newBinaryOperator("//=")
+	BinOp_REXP    = newBinaryOperator("**=")
+	BinOp_RAND    = newBinaryOperator("&&=")
+	BinOp_ROR     = newBinaryOperator("||=")
+	BinOp_RXOR    = newBinaryOperator("^^=")
+	BinOp_RBAND   = newBinaryOperator("&=")
+	BinOp_RBOR    = newBinaryOperator("|=")
+	BinOp_RBXOR   = newBinaryOperator("^=")
+	BinOp_RLSHIFT = newBinaryOperator("<<=")
+	BinOp_RRSHIFT = newBinaryOperator(">>=")
 
 	// comparison operators
 	BinOp_EQ  = newBinaryOperator("==")
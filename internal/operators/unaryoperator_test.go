@@ -2,8 +2,12 @@ package operators
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/chrispyles/slow/internal/decimal"
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	slowtesting "github.com/chrispyles/slow/internal/testing"
@@ -129,6 +133,117 @@ func TestUnaryOperator_Value(t *testing.T) {
 	}
 }
 
+func TestUnaryOperator_Decimal(t *testing.T) {
+	dec := func(s string) execute.Value { return types.NewDecimal(decimal.MustParse(s)) }
+	tests := []struct {
+		op   *UnaryOperator
+		val  execute.Value
+		want execute.Value
+	}{
+		{UnOp_NEG, dec("1.50"), dec("-1.50")},
+		{UnOp_POS, dec("-2"), dec("-2")},
+		{UnOp_NOT, dec("0.0"), types.NewBool(true)},
+		{UnOp_INCR, dec("0.5"), dec("1.5")},
+		{UnOp_DECR, dec("0.5"), dec("-0.5")},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s", tc.op, tc.val), func(t *testing.T) {
+			got, err := tc.op.Value(tc.val)
+			if err != nil {
+				t.Fatalf("Value() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnaryOperator_Duration(t *testing.T) {
+	tests := []struct {
+		op      *UnaryOperator
+		val     execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{op: UnOp_NEG, val: types.NewDuration(time.Minute), want: types.NewDuration(-time.Minute)},
+		{op: UnOp_POS, val: types.NewDuration(-time.Minute), want: types.NewDuration(-time.Minute)},
+		{op: UnOp_NOT, val: types.NewDuration(0), want: types.NewBool(true)},
+		{op: UnOp_NEG, val: types.NewDuration(math.MinInt64), wantErr: errors.NewOverflowError(types.DurationType, "-")},
+		{op: UnOp_NEG, val: types.NewDatetime(time.Unix(0, 0)), wantErr: errors.IncompatibleType(types.DatetimeType, "-")},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s_%s", tc.op, tc.val.Type(), tc.val), func(t *testing.T) {
+			got, err := tc.op.Value(tc.val)
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnaryOperator_BitwiseNot(t *testing.T) {
+	tests := []struct {
+		val     execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{types.NewInt(5), types.NewInt(-6), nil},
+		{types.NewUint(0), types.NewUint(math.MaxUint64), nil},
+		{types.NewBool(false), types.NewUint(math.MaxUint64), nil},
+		{types.NewBigInt(big.NewInt(-1)), types.NewBigInt(big.NewInt(0)), nil},
+		{types.NewBytes([]byte{0x00, 0xF0}), types.NewBytes([]byte{0xFF, 0x0F}), nil},
+		{types.NewFloat(1), nil, errors.IncompatibleType(types.FloatType, "~")},
+		{types.NewStr("a"), nil, errors.IncompatibleType(types.StrType, "~")},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s", tc.val.Type(), tc.val), func(t *testing.T) {
+			got, err := UnOp_BNOT.Value(tc.val)
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("Value() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnaryOperator_ValueWith_checked(t *testing.T) {
+	tests := []struct {
+		op      *UnaryOperator
+		val     execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{UnOp_NEG, types.NewInt(math.MaxInt64), types.NewInt(-math.MaxInt64), nil},
+		{UnOp_NEG, types.NewInt(math.MinInt64), nil, errors.NewOverflowError(types.IntType, "-")},
+		{UnOp_NEG, types.NewUint(1 << 63), types.NewInt(math.MinInt64), nil},
+		{UnOp_NEG, types.NewUint(math.MaxUint64), nil, errors.NewOverflowError(types.IntType, "-")},
+		{UnOp_NEG, types.NewBool(true), types.NewInt(-1), nil},
+		{UnOp_NEG, types.NewFloat(1), types.NewFloat(-1), nil},
+		{UnOp_NEG, types.NewBigInt(big.NewInt(math.MinInt64)), types.NewBigInt(new(big.Int).SetUint64(1 << 63)), nil},
+		{UnOp_POS, types.NewBigInt(big.NewInt(2)), types.NewBigInt(big.NewInt(2)), nil},
+		{UnOp_INCR, types.NewInt(math.MaxInt64), nil, errors.NewOverflowError(types.IntType, "+")},
+		{UnOp_DECR, types.NewUint(0), nil, errors.NewOverflowError(types.UintType, "-")},
+		{UnOp_DECR, types.NewBigInt(big.NewInt(0)), types.NewBigInt(big.NewInt(-1)), nil},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%s_%s", tc.op, tc.val.Type(), tc.val), func(t *testing.T) {
+			got, err := tc.op.ValueWith(tc.val, Options{Checked: true})
+			if diff := cmp.Diff(tc.wantErr, err, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, slowcmpopts.AllowUnexported()); diff != "" {
+				t.Errorf("ValueWith() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUnaryOperator_IsReassignmentOperator(t *testing.T) {
 	reassignmentOperators := map[string]bool{
 		"++": true,
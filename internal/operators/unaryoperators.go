@@ -1,15 +1,27 @@
 package operators
 
 import (
+	"math"
+	"math/big"
+
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/types"
 )
 
+// Value returns the result of applying this operator to v using the default Options.
 func (o *UnaryOperator) Value(v execute.Value) (execute.Value, error) {
+	return o.ValueWith(v, Options{})
+}
+
+// ValueWith returns the result of applying this operator to v using the provided Options.
+func (o *UnaryOperator) ValueWith(v execute.Value, opts Options) (execute.Value, error) {
 	switch o {
 	case UnOp_POS:
-		if v.Type() != types.FloatType &&
+		if v.Type() != types.DecimalType &&
+			v.Type() != types.DurationType &&
+			v.Type() != types.FloatType &&
+			v.Type() != types.BigIntType &&
 			v.Type() != types.IntType &&
 			v.Type() != types.UintType &&
 			v.Type() != types.BoolType {
@@ -17,7 +29,26 @@ func (o *UnaryOperator) Value(v execute.Value) (execute.Value, error) {
 		}
 		return v.CloneIfPrimitive(), nil
 	case UnOp_NEG:
+		if v.Type() == types.BigIntType || opts.Checked && fixedWidthTypes[v.Type()] {
+			n := new(big.Int).Neg(must(types.ToBigInt(v)))
+			if v.Type() == types.BigIntType {
+				return types.NewBigInt(n), nil
+			}
+			if !n.IsInt64() {
+				return nil, errors.NewOverflowError(types.IntType, o.String())
+			}
+			return types.NewInt(n.Int64()), nil
+		}
 		switch v.Type() {
+		case types.DecimalType:
+			return types.NewDecimal(v.(*types.Decimal).Value().Neg()), nil
+		case types.DurationType:
+			// Durations never wrap around, so the minimum duration can't be negated.
+			d := v.(*types.Duration).Value()
+			if d == math.MinInt64 {
+				return nil, errors.NewOverflowError(types.DurationType, o.String())
+			}
+			return types.NewDuration(-d), nil
 		case types.FloatType:
 			return types.NewFloat(-1 * must(v.ToFloat())), nil
 		case types.IntType:
@@ -32,10 +63,29 @@ func (o *UnaryOperator) Value(v execute.Value) (execute.Value, error) {
 	case UnOp_NOT:
 		// Each type's ToBool method determines the value's truthiness.
 		return types.NewBool(!v.ToBool()), nil
+	case UnOp_BNOT:
+		switch v.Type() {
+		case types.BigIntType:
+			return types.NewBigInt(new(big.Int).Not(must(types.ToBigInt(v)))), nil
+		case types.BytesType:
+			b := must(v.ToBytes())
+			for i := range b {
+				b[i] = ^b[i]
+			}
+			return types.NewBytes(b), nil
+		case types.IntType:
+			return types.NewInt(^must(v.ToInt())), nil
+		case types.UintType:
+			fallthrough
+		case types.BoolType:
+			return types.NewUint(^must(v.ToUint())), nil
+		default:
+			return nil, errors.IncompatibleType(v.Type(), o.String())
+		}
 	case UnOp_INCR:
-		return BinOp_PLUS.Value(v, types.NewUint(1))
+		return BinOp_PLUS.ValueWith(v, types.NewUint(1), opts)
 	case UnOp_DECR:
-		return BinOp_MINUS.Value(v, types.NewUint(1))
+		return BinOp_MINUS.ValueWith(v, types.NewUint(1), opts)
 	}
 	panic("unandled unary operator in UnaryOperator.Value()")
 }
@@ -5,59 +5,118 @@ package parser
 import (
 	"encoding/hex"
 	"fmt"
-	"log"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/chrispyles/slow/internal/ast"
-	"github.com/chrispyles/slow/internal/config"
+	"github.com/chrispyles/slow/internal/decimal"
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/lexer"
 	"github.com/chrispyles/slow/internal/operators"
+	"github.com/chrispyles/slow/internal/source"
 	"github.com/chrispyles/slow/internal/types"
-	goerrors "github.com/pkg/errors"
-	"github.com/sanity-io/litter"
 )
 
 var symbolRegex = regexp.MustCompile(`^[A-Za-z_]\w*$`)
 
-// stringEscapeSequences maps raw escape sequences that can be used in string literals to the
-// character they should be replaced with.
-var stringEscapeSequences = map[string]string{
-	`\\`: "\\",
-	`\n`: "\n",
-	`\r`: "\r",
-	`\t`: "\t",
-	`\"`: "\"",
+func Parse(s string) (execute.AST, error) {
+	return ParseFile("", s)
 }
 
-func Parse(s string) (execute.AST, error) {
-	b, err := doParse(s)
+// ParseFile parses s, which was read from the provided file. The file is recorded in the location of
+// each node in the AST. If s contains syntax errors, all of them are returned in an
+// errors.ErrorList, or on their own if there is only one.
+func ParseFile(file, s string) (execute.AST, error) {
+	b, err := doParse(file, s)
 	if err != nil {
 		return nil, err
 	}
 	return ast.New(b), nil
 }
 
-func doParse(s string) (execute.Block, error) {
-	var b execute.Block
-	buf := lexer.NewBuffer(s)
-	if *config.Debug {
-		litter.Dump(buf)
+// ParsePartial parses s like ParseFile, but returns an AST even if s contains syntax errors, for tools
+// like formatters and language servers that work with code that is still being written. Each
+// top-level statement that could not be parsed is replaced by an ast.BadNode. The AST is nil only if
+// s could not be split into tokens.
+func ParsePartial(file, s string) (*ast.AST, error) {
+	b, err := doParse(file, s)
+	if b == nil && err != nil {
+		return nil, err
+	}
+	return ast.New(b), err
+}
+
+// doParse parses each statement in s. When a statement can't be parsed, its error is recorded and
+// parsing resumes at the next statement, so that every syntax error in s is reported at once. The
+// returned block is nil only if s could not be split into tokens.
+func doParse(file, s string) (execute.Block, error) {
+	b := execute.Block{}
+	buf, err := lexer.NewFileBuffer(file, s)
+	if err != nil {
+		return nil, err
 	}
+	var errs []error
 	for buf.Current().Type != lexer.EOF {
 		buf.ConsumeNewlines()
+		start := buf.Current()
 		expr, err := parseStatement(buf)
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
+			synchronize(buf)
+			// A "}" can't close a block at the top level of a file, so there's no statement for it to end.
+			if buf.Current().Type == lexer.CloseCurlyBracket {
+				buf.Pop()
+			}
+			expr = badNode(buf, start)
 		}
 		if expr != nil {
 			b = append(b, expr)
 		}
 	}
-	return b, nil
+	return b, errors.Join(errs...)
+}
+
+// synchronize skips the rest of a statement that could not be parsed so that parsing can resume at the
+// next one. It stops after the newline that ends the statement, or before a "}" that closes the
+// enclosing block. Brackets opened in the skipped code are skipped until they are closed.
+func synchronize(buf *lexer.Buffer) {
+	depth := 0
+	for {
+		switch buf.Current().Type {
+		case lexer.EOF:
+			return
+		case lexer.EOL:
+			if depth == 0 {
+				buf.Pop()
+				return
+			}
+		case lexer.OpenParen, lexer.OpenBracket, lexer.OpenCurlyBracket:
+			depth++
+		case lexer.CloseParen, lexer.CloseBracket:
+			if depth > 0 {
+				depth--
+			}
+		case lexer.CloseCurlyBracket:
+			if depth == 0 {
+				return
+			}
+			depth--
+		}
+		buf.Pop()
+	}
+}
+
+// badNode returns a placeholder for a statement that could not be parsed, which started at the
+// provided token and ended at the last token consumed from the buffer.
+func badNode(buf *lexer.Buffer, start lexer.Token) *ast.BadNode {
+	end := buf.Previous().Span
+	if end.Start.Offset < start.Span.Start.Offset {
+		end = start.Span
+	}
+	return &ast.BadNode{Node: ast.Node{Span: start.Span.To(end)}}
 }
 
 func parseStatement(buf *lexer.Buffer) (execute.Expression, error) {
@@ -66,18 +125,57 @@ func parseStatement(buf *lexer.Buffer) (execute.Expression, error) {
 		return nil, nil
 	}
 	if stmt, ok := stmtHandlers[tkn.Type]; ok {
-		return stmt(buf)
+		return located(buf, tkn.Span)(stmt(buf))
+	}
+	if isImportStatement(buf) {
+		return located(buf, tkn.Span)(parseImport(buf))
 	}
 	return parseExpr(buf, bp_Default)
 }
 
+// located returns a function that sets the location of a node that was parsed starting at the
+// provided span and ending at the last token consumed from the buffer.
+func located(buf *lexer.Buffer, start source.Span) func(execute.Expression, error) (execute.Expression, error) {
+	return func(expr execute.Expression, err error) (execute.Expression, error) {
+		if n, ok := expr.(ast.Located); ok && err == nil {
+			n.SetLocation(start.To(buf.Previous().Span))
+		}
+		return expr, err
+	}
+}
+
+// locationOf returns the location of a node, or the span of the current token if the node has no
+// location.
+func locationOf(buf *lexer.Buffer, expr execute.Expression) source.Span {
+	if n, ok := expr.(ast.Located); ok && n.Location().IsValid() {
+		return n.Location()
+	}
+	return buf.CurrentSpan()
+}
+
+// isImportStatement returns whether the buffer is at the start of an import statement. Since
+// import is also the name of a builtin function, a statement is only an import statement if the
+// symbol "import" is followed by a string or an opening "{".
+func isImportStatement(buf *lexer.Buffer) bool {
+	if c := buf.Current(); c.Type != lexer.Symbol || c.Value != "import" {
+		return false
+	}
+	buf.Pop()
+	next := buf.Current().Type
+	buf.MoveBack()
+	return next == lexer.String || next == lexer.OpenCurlyBracket
+}
+
 func parseExpr(buf *lexer.Buffer, bp bindingPower) (execute.Expression, error) {
 	tkn := buf.Current()
 	nud, ok := nudHandlers[tkn.Type]
 	if !ok {
-		panic(fmt.Sprintf("no NUD handler for token kind: %s", tkn.Type))
+		if tkn.Type == lexer.EOF {
+			return nil, errors.NewEOFError(buf)
+		}
+		return nil, errors.UnexpectedSymbolError(buf, tkn.Value, "")
 	}
-	left, err := nud(buf)
+	left, err := located(buf, tkn.Span)(nud(buf))
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +183,9 @@ func parseExpr(buf *lexer.Buffer, bp bindingPower) (execute.Expression, error) {
 		tkn := buf.Current()
 		led, ok := ledHandlers[tkn.Type]
 		if !ok {
-			panic(fmt.Sprintf("no LED handler for token kind: %s", tkn.Type))
+			return nil, errors.UnexpectedSymbolError(buf, tkn.Value, "")
 		}
-		left, err = led(buf, left, ledBPs[tkn.Type])
+		left, err = located(buf, locationOf(buf, left))(led(buf, left, ledBPs[tkn.Type]))
 		if err != nil {
 			return nil, err
 		}
@@ -96,10 +194,11 @@ func parseExpr(buf *lexer.Buffer, bp bindingPower) (execute.Expression, error) {
 }
 
 func parseAssignment(buf *lexer.Buffer, left execute.Expression, bp bindingPower) (execute.Expression, error) {
-	buf.Pop() // remove "=" from the buffer
 	if err := validateAssignable(buf, left); err != nil {
-		return nil, err
+		// Assigning to an expression that can't be assigned to is usually a mistyped comparison.
+		return nil, errors.WithHint(err, "did you mean `==`?")
 	}
+	buf.Pop() // remove "=" from the buffer
 	right, err := parseExpr(buf, bp)
 	if err != nil {
 		return nil, err
@@ -117,10 +216,12 @@ func parseAssignment(buf *lexer.Buffer, left execute.Expression, bp bindingPower
 }
 
 func parseBinaryOperation(buf *lexer.Buffer, left execute.Expression, bp bindingPower) (execute.Expression, error) {
-	op, ok := operators.ToBinaryOp(buf.Pop().Value)
+	tkn := buf.Current()
+	op, ok := operators.ToBinaryOp(tkn.Value)
 	if !ok {
-		panic("not a bin op")
+		return nil, errors.NewSyntaxError(buf, "unknown binary operator", tkn.Value)
 	}
+	buf.Pop()
 	if op.IsReassignmentOperator() {
 		if err := validateAssignable(buf, left); err != nil {
 			return nil, err
@@ -139,23 +240,33 @@ func parseBlock(buf *lexer.Buffer) (execute.Block, error) {
 	if c := buf.Pop(); c.Type != lexer.OpenCurlyBracket {
 		return nil, errors.UnexpectedSymbolError(buf, c.Value, "{")
 	}
+	// Like doParse, each statement that can't be parsed is skipped so that the errors in the rest of
+	// the block are reported too.
 	var b execute.Block
+	var errs []error
 	for {
 		buf.ConsumeNewlines()
-		if buf.Current().Type == lexer.CloseCurlyBracket {
+		switch buf.Current().Type {
+		case lexer.CloseCurlyBracket:
 			buf.Pop() // remove "}" from the buffer
-			break
+			return b, errors.Join(errs...)
+		case lexer.EOF:
+			return nil, errors.Join(append(errs, errors.NewEOFError(buf))...)
+		}
+		var expr execute.Expression
+		var err error
+		if buf.Current().Type == lexer.Export {
+			err = errors.NewSyntaxError(buf, "export statements must be at the top level of a file", "")
+		} else {
+			expr, err = parseStatement(buf)
 		}
-		expr, err := parseStatement(buf)
 		if err != nil {
-			if *config.Debug {
-				litter.Dump(b)
-			}
-			return nil, err
+			errs = append(errs, err)
+			synchronize(buf)
+			continue
 		}
 		b = append(b, expr)
 	}
-	return b, nil
 }
 
 func parseCall(buf *lexer.Buffer, left execute.Expression, bp bindingPower) (execute.Expression, error) {
@@ -206,6 +317,37 @@ func parseGroupingExpr(buf *lexer.Buffer) (execute.Expression, error) {
 	return expr, nil
 }
 
+func parseExport(buf *lexer.Buffer) (execute.Expression, error) {
+	buf.Pop() // remove "export" from the buffer
+	var node *ast.ExportNode
+	switch buf.Current().Type {
+	case lexer.Var, lexer.Const:
+		decl, err := parseVar(buf)
+		if err != nil {
+			return nil, err
+		}
+		node = &ast.ExportNode{Decl: decl}
+	case lexer.Func:
+		decl, err := parseFunc(buf)
+		if err != nil {
+			return nil, err
+		}
+		node = &ast.ExportNode{Decl: decl}
+	default:
+		names, err := parseSymbolList(buf, lexer.EOL)
+		if err != nil {
+			return nil, err
+		}
+		node = &ast.ExportNode{Names: names}
+	}
+	for _, name := range node.ExportedNames() {
+		if strings.HasPrefix(name, "_") {
+			return nil, errors.NewSyntaxError(buf, "private variables cannot be exported", name)
+		}
+	}
+	return node, nil
+}
+
 func parseFor(buf *lexer.Buffer) (execute.Expression, error) {
 	buf.Pop() // remove "for" from the buffer
 	iterName := buf.Pop()
@@ -279,7 +421,7 @@ func parseIf(buf *lexer.Buffer) (execute.Expression, error) {
 				return nil, err
 			}
 		} else if buf.Current().Type == lexer.If {
-			elseIfBody, err := parseIf(buf)
+			elseIfBody, err := located(buf, buf.CurrentSpan())(parseIf(buf))
 			if err != nil {
 				return nil, err
 			}
@@ -292,6 +434,48 @@ func parseIf(buf *lexer.Buffer) (execute.Expression, error) {
 	return node, nil
 }
 
+func parseImport(buf *lexer.Buffer) (execute.Expression, error) {
+	buf.Pop() // remove "import" from the buffer
+	node := &ast.ImportNode{}
+	if buf.Current().Type == lexer.OpenCurlyBracket {
+		buf.Pop() // remove "{" from the buffer
+		buf.ConsumeNewlines()
+		names, err := parseSymbolList(buf, lexer.CloseCurlyBracket)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, errors.NewSyntaxError(buf, "import statement does not import any names", "")
+		}
+		if err := expectClose(buf, "}"); err != nil {
+			return nil, err
+		}
+		if c := buf.Pop(); c.Type != lexer.Symbol || c.Value != "from" {
+			buf.MoveBack()
+			return nil, errors.UnexpectedSymbolError(buf, c.Value, "from")
+		}
+		node.Names = names
+	}
+	path := buf.Pop()
+	if path.Type != lexer.String {
+		buf.MoveBack()
+		return nil, errors.NewSyntaxError(buf, "expected a module name", path.Value)
+	}
+	node.Path = lexer.Unquote(path.Value)
+	if node.Names == nil {
+		if c := buf.Pop(); c.Type != lexer.As {
+			buf.MoveBack()
+			return nil, errors.UnexpectedSymbolError(buf, c.Value, "as")
+		}
+		alias := buf.Pop()
+		if err := validateSymbol(buf, alias); err != nil {
+			return nil, err
+		}
+		node.Alias = alias.Value
+	}
+	return node, nil
+}
+
 func parseList(buf *lexer.Buffer) (execute.Expression, error) {
 	buf.Pop() // remove "[" from the buffer
 	next := buf.Current()
@@ -325,7 +509,7 @@ func parseLiteral(buf *lexer.Buffer) (execute.Expression, error) {
 	tkn := buf.Pop()
 	switch tkn.Type {
 	case lexer.String:
-		return parseString(tkn.Value)
+		return &ast.ConstantNode{Value: types.NewStr(lexer.Unquote(tkn.Value))}, nil
 	case lexer.True:
 		return &ast.ConstantNode{Value: types.NewBool(true)}, nil
 	case lexer.False:
@@ -349,39 +533,80 @@ func parseLiteral(buf *lexer.Buffer) (execute.Expression, error) {
 		}
 		return &ast.ConstantNode{Value: types.NewBytes(bytes)}, nil
 	case lexer.Number:
-		if strings.HasSuffix(tkn.Value, "u") {
-			// Remove trailing "u" from uint syntax
-			numerals := tkn.Value[:len(tkn.Value)-1]
-			uintValue, err := strconv.ParseUint(numerals, 10, 64)
-			if err != nil {
-				if *config.Debug {
-					log.Print(fmt.Errorf("%w", err).Error())
-				}
-				return nil, errors.NewValueError(fmt.Sprintf("unable to parse %q as uint", tkn))
-			}
-			return &ast.ConstantNode{Value: types.NewUint(uintValue)}, nil
+		return parseNumber(tkn)
+	default:
+		buf.MoveBack()
+		return nil, errors.UnexpectedSymbolError(buf, tkn.Value, "")
+	}
+}
+
+// numberBases maps the prefixes of numeric literals to their bases.
+var numberBases = map[string]int{"0b": 2, "0o": 8, "0x": 16}
+
+// parseNumber parses a numeric literal. The lexer has already checked its syntax, so the only errors
+// are literals whose values don't fit in their type.
+func parseNumber(tkn lexer.Token) (execute.Expression, error) {
+	digits := strings.ReplaceAll(tkn.Value, "_", "")
+	base := 10
+	if len(digits) > 2 {
+		if b, ok := numberBases[digits[:2]]; ok {
+			base = b
+			digits = strings.TrimSuffix(digits[2:], "i")
 		}
-		if strings.Contains(tkn.Value, ".") {
-			floatValue, err := strconv.ParseFloat(tkn.Value, 64)
-			if err != nil {
-				if *config.Debug {
-					log.Print(fmt.Errorf("%w", err).Error())
-				}
-				return nil, errors.NewValueError(fmt.Sprintf("unable to parse %q as float", tkn))
-			}
-			return &ast.ConstantNode{Value: types.NewFloat(floatValue)}, nil
+	}
+	if numerals, ok := strings.CutSuffix(digits, "d"); ok && base == 10 {
+		// The lexer only emits well-formed literals, so the only error is an exponent that is too large.
+		decimalValue, err := decimal.Parse(numerals)
+		if err != nil {
+			return nil, errors.SyntaxErrorAt(tkn.Span, "decimal literal out of range", tkn.Value)
+		}
+		return &ast.ConstantNode{Value: types.NewDecimal(decimalValue)}, nil
+	}
+	if numerals, ok := strings.CutSuffix(digits, "n"); ok {
+		bigValue, ok := new(big.Int).SetString(numerals, base)
+		if !ok {
+			return nil, errors.SyntaxErrorAt(tkn.Span, "malformed number", tkn.Value)
+		}
+		return &ast.ConstantNode{Value: types.NewBigInt(bigValue)}, nil
+	}
+	if numerals, ok := strings.CutSuffix(digits, "u"); ok {
+		uintValue, err := strconv.ParseUint(numerals, base, 64)
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			return nil, errors.WithHint(numberError(tkn, "uint", err), fmt.Sprintf("did you mean %q?", strings.TrimSuffix(tkn.Value, "u")+"n"))
+		} else if err != nil {
+			return nil, numberError(tkn, "uint", err)
 		}
-		intValue, err := strconv.ParseInt(tkn.Value, 10, 64)
+		return &ast.ConstantNode{Value: types.NewUint(uintValue)}, nil
+	}
+	if base == 10 && strings.ContainsAny(digits, ".eE") {
+		floatValue, err := strconv.ParseFloat(digits, 64)
 		if err != nil {
-			if *config.Debug {
-				log.Print(fmt.Errorf("%w", err).Error())
+			return nil, numberError(tkn, "float", err)
+		}
+		return &ast.ConstantNode{Value: types.NewFloat(floatValue)}, nil
+	}
+	intValue, err := strconv.ParseInt(digits, base, 64)
+	if err != nil {
+		// Suggest a uint literal if the value would fit in one, and a bigint literal otherwise.
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			suffix := "n"
+			if _, uerr := strconv.ParseUint(digits, base, 64); uerr == nil {
+				suffix = "u"
 			}
-			return nil, errors.NewValueError(fmt.Sprintf("unable to parse %q as int", tkn))
+			return nil, errors.WithHint(numberError(tkn, "int", err), fmt.Sprintf("did you mean %q?", strings.TrimSuffix(tkn.Value, "i")+suffix))
 		}
-		return &ast.ConstantNode{Value: types.NewInt(intValue)}, nil
-	default:
-		panic(fmt.Sprintf("unknown lexer token type in parseLiteral: %s", tkn.Type))
+		return nil, numberError(tkn, "int", err)
 	}
+	return &ast.ConstantNode{Value: types.NewInt(intValue)}, nil
+}
+
+// numberError returns a SyntaxError for a numeric literal that could not be parsed as the provided
+// type.
+func numberError(tkn lexer.Token, typeName string, err error) error {
+	if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+		return errors.SyntaxErrorAt(tkn.Span, fmt.Sprintf("%s literal out of range", typeName), tkn.Value)
+	}
+	return errors.SyntaxErrorAt(tkn.Span, "malformed number", tkn.Value)
 }
 
 func parseMap(buf *lexer.Buffer) (execute.Expression, error) {
@@ -432,7 +657,8 @@ func parseMemberAccess(buf *lexer.Buffer, left execute.Expression, bp bindingPow
 		}
 		return &ast.AttributeNode{Left: left, Right: buf.Pop().Value}, nil
 	default:
-		panic(fmt.Sprintf("unknown lexer token in parseMemberAccess: %s", t))
+		buf.MoveBack()
+		return nil, errors.UnexpectedSymbolError(buf, buf.Current().Value, "")
 	}
 }
 
@@ -470,22 +696,6 @@ func parseRange(buf *lexer.Buffer, left execute.Expression, bp bindingPower) (ex
 	return &ast.RangeNode{Start: left, Stop: stop, Step: step}, nil
 }
 
-func parseString(tkn string) (execute.Expression, error) {
-	split := strings.Split(tkn, "")
-	var s string
-	for i := 1; i < len(split)-1; i++ {
-		// Check if this character + the next one form an escape sequence (which are 2 characters long).
-		// If so, add the corresponding value to the string and skip the (i+1)th character.
-		if unescaped, ok := stringEscapeSequences[tkn[i:i+2]]; ok {
-			s += unescaped
-			i++
-		} else {
-			s += string(tkn[i])
-		}
-	}
-	return &ast.ConstantNode{Value: types.NewStr(s)}, nil
-}
-
 func parseSwitch(buf *lexer.Buffer) (execute.Expression, error) {
 	buf.Pop() // remove "switch" from the buffer
 	expr, err := parseExpr(buf, bp_Default)
@@ -537,6 +747,31 @@ func parseSwitch(buf *lexer.Buffer) (execute.Expression, error) {
 	return &ast.SwitchNode{Value: expr, Cases: cases, DefaultCase: defaultCase}, nil
 }
 
+// parseSymbolList parses a comma-separated list of symbols that ends at a token of the provided
+// type, which is not removed from the buffer. Newlines are allowed after commas.
+func parseSymbolList(buf *lexer.Buffer, end lexer.TokenType) ([]string, error) {
+	var names []string
+	for buf.Current().Type != end && buf.Current().Type != lexer.EOF {
+		name := buf.Pop()
+		if err := validateSymbol(buf, name); err != nil {
+			return nil, err
+		}
+		names = append(names, name.Value)
+		if c := buf.Current(); c.Type == lexer.Comma {
+			buf.Pop()
+			if end != lexer.EOL {
+				buf.ConsumeNewlines()
+			}
+		} else if c.Type != end && c.Type != lexer.EOF {
+			return nil, errors.UnexpectedSymbolError(buf, c.Value, ",")
+		}
+	}
+	if len(names) == 0 && end == lexer.EOL {
+		return nil, errors.NewSyntaxError(buf, "expected a declaration or a list of variables to export", buf.Current().Value)
+	}
+	return names, nil
+}
+
 func parseTypeCast(buf *lexer.Buffer, left execute.Expression, bp bindingPower) (execute.Expression, error) {
 	buf.Pop() // rmeove "as" from the buffer
 	tkn := buf.Pop()
@@ -551,10 +786,12 @@ func parseTypeCast(buf *lexer.Buffer, left execute.Expression, bp bindingPower)
 }
 
 func parseUnaryOperation(buf *lexer.Buffer) (execute.Expression, error) {
-	op, ok := operators.ToUnaryOp(buf.Pop().Value)
+	tkn := buf.Current()
+	op, ok := operators.ToUnaryOp(tkn.Value)
 	if !ok {
-		panic("not a unary op")
+		return nil, errors.NewSyntaxError(buf, "unknown unary operator", tkn.Value)
 	}
+	buf.Pop()
 	var expr execute.Expression
 	var err error
 	expr, err = parseExpr(buf, bp_Unary)
@@ -579,6 +816,9 @@ func parseVar(buf *lexer.Buffer) (execute.Expression, error) {
 		return nil, err
 	}
 	node := &ast.VarNode{Name: name.Value, IsConst: isConst}
+	if c := buf.Current(); c.Type == lexer.Equals {
+		return nil, errors.WithHint(errors.UnexpectedSymbolError(buf, c.Value, ""), "did you mean `=`?")
+	}
 	if buf.Current().Type != lexer.Assignment {
 		if isConst {
 			return nil, errors.NewSyntaxError(buf, "const expression does not initialize a value", "")
@@ -630,11 +870,7 @@ func validateSymbol(buf errors.Buffer, tkn lexer.Token) error {
 		return errors.NewSyntaxError(buf, "not a symbol", tkn.Value)
 	}
 	if !symbolRegex.Match([]byte(tkn.Value)) {
-		var err error = errors.NewSyntaxError(buf, "invalid symbol", tkn.Value)
-		if *config.Debug {
-			err = goerrors.WithStack(err)
-		}
-		return err
+		return errors.NewSyntaxError(buf, "invalid symbol", tkn.Value)
 	}
 	// TODO: is it possible for this to be true? or will the lexer always catch it?
 	if lexer.IsReservedKeyword(tkn.Value) {
@@ -1,28 +1,42 @@
 package parser
 
 import (
+	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/chrispyles/slow/internal/ast"
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 	"github.com/chrispyles/slow/internal/operators"
 	"github.com/chrispyles/slow/internal/types"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
-var allowTypesUnexported = cmp.AllowUnexported(
-	ast.AssignmentTarget{},
-	operators.BinaryOperator{},
-	operators.UnaryOperator{},
-	types.Bool{},
-	types.Float{},
-	types.Func{},
-	types.Int{},
-	types.Iterator{},
-	types.List{},
-	types.Str{},
-	types.Uint{},
-)
+var allowTypesUnexported = cmp.Options{
+	cmp.AllowUnexported(
+		ast.AssignmentTarget{},
+		operators.BinaryOperator{},
+		operators.UnaryOperator{},
+		types.BigInt{},
+		types.Bool{},
+		types.Datetime{},
+		types.Decimal{},
+		types.Duration{},
+		types.Float{},
+		types.Func{},
+		types.Int{},
+		types.Iterator{},
+		types.List{},
+		types.Str{},
+		types.Uint{},
+	),
+	cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 }),
+	// Node locations are tested separately in TestLocations.
+	cmpopts.IgnoreTypes(ast.Node{}),
+}
 
 // TODO: these tests also test the operator logic; is this OK?
 func TestArithmetic(t *testing.T) {
@@ -98,6 +112,26 @@ func TestArithmetic(t *testing.T) {
 			code: "- ( 2 * 3)",
 			want: types.NewInt(-6),
 		},
+		{
+			code: "2**3",
+			want: types.NewInt(8),
+		},
+		{
+			code: "true ^^ false",
+			want: types.NewBool(true),
+		},
+		{
+			code: "var x = 2\nx **= 3\nx",
+			want: types.NewInt(8),
+		},
+		{
+			code: "var x = 1\nx &&= 0\nx ||= 3\nx ^^= 0\nx",
+			want: types.NewBool(true),
+		},
+		{
+			code: "var x = 1\n++x\n--x\n++x\nx",
+			want: types.NewInt(2),
+		},
 		// TODO: more operators
 		// TODO: test errors
 	}
@@ -111,7 +145,7 @@ func TestArithmetic(t *testing.T) {
 			if err != nil {
 				t.Fatalf("a.Execute returned an unexpected error: %v", err)
 			}
-			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(types.Float{}, types.Int{}, types.Uint{})); diff != "" {
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(types.Bool{}, types.Float{}, types.Int{}, types.Uint{})); diff != "" {
 				t.Errorf("a.Execute returned unexpected diff (-want +got):\n%s", diff)
 			}
 		})
@@ -141,6 +175,52 @@ func TestSmallASTs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "bitwise_precedence",
+			code: "a | b ^ c & d << 1 == e",
+			want: &ast.AST{
+				Nodes: execute.Block{
+					&ast.BinaryOpNode{
+						Op: operators.BinOp_EQ,
+						Left: &ast.BinaryOpNode{
+							Op:   operators.BinOp_BOR,
+							Left: &ast.VariableNode{Name: "a"},
+							Right: &ast.BinaryOpNode{
+								Op:   operators.BinOp_BXOR,
+								Left: &ast.VariableNode{Name: "b"},
+								Right: &ast.BinaryOpNode{
+									Op:   operators.BinOp_BAND,
+									Left: &ast.VariableNode{Name: "c"},
+									Right: &ast.BinaryOpNode{
+										Op:    operators.BinOp_LSHIFT,
+										Left:  &ast.VariableNode{Name: "d"},
+										Right: &ast.ConstantNode{Value: types.NewInt(1)},
+									},
+								},
+							},
+						},
+						Right: &ast.VariableNode{Name: "e"},
+					},
+				},
+			},
+		},
+		{
+			name: "bitwise_reassignment",
+			code: "x >>= ~y + 1",
+			want: &ast.AST{
+				Nodes: execute.Block{
+					&ast.BinaryOpNode{
+						Op:   operators.BinOp_RRSHIFT,
+						Left: &ast.VariableNode{Name: "x"},
+						Right: &ast.BinaryOpNode{
+							Op:    operators.BinOp_PLUS,
+							Left:  &ast.UnaryOpNode{Op: operators.UnOp_BNOT, Expr: &ast.VariableNode{Name: "y"}},
+							Right: &ast.ConstantNode{Value: types.NewInt(1)},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "arithmetic_with_method_calls",
 			code: "m.get(i) + m.get(i + 1)",
@@ -457,3 +537,410 @@ for x in range(20) {
 		t.Errorf("Incorrect AST (-want +got):\n%s", diff)
 	}
 }
+
+func TestImportExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    *ast.AST
+		wantErr string
+	}{
+		{
+			name: "import_as",
+			code: `import "lib/utils.slo" as utils`,
+			want: &ast.AST{Nodes: execute.Block{
+				&ast.ImportNode{Path: "lib/utils.slo", Alias: "utils"},
+			}},
+		},
+		{
+			name: "import_names",
+			code: "import {\n  a,\n  b,\n} from \"x.slo\"",
+			want: &ast.AST{Nodes: execute.Block{
+				&ast.ImportNode{Path: "x.slo", Names: []string{"a", "b"}},
+			}},
+		},
+		{
+			name: "import_call",
+			code: `import("fs")`,
+			want: &ast.AST{Nodes: execute.Block{
+				&ast.CallNode{
+					Func: &ast.VariableNode{Name: "import"},
+					Args: []execute.Expression{&ast.ConstantNode{Value: types.NewStr("fs")}},
+				},
+			}},
+		},
+		{
+			name: "export_var",
+			code: "export var x = 1",
+			want: &ast.AST{Nodes: execute.Block{
+				&ast.ExportNode{Decl: &ast.VarNode{Name: "x", Value: &ast.ConstantNode{Value: types.NewInt(1)}}},
+			}},
+		},
+		{
+			name: "export_const",
+			code: "export const x = 1",
+			want: &ast.AST{Nodes: execute.Block{
+				&ast.ExportNode{Decl: &ast.VarNode{Name: "x", IsConst: true, Value: &ast.ConstantNode{Value: types.NewInt(1)}}},
+			}},
+		},
+		{
+			name: "export_func",
+			code: "export func f() {\n}",
+			want: &ast.AST{Nodes: execute.Block{
+				&ast.ExportNode{Decl: &ast.FuncNode{Name: "f"}},
+			}},
+		},
+		{
+			name: "export_names",
+			code: "export a, b\nexport c",
+			want: &ast.AST{Nodes: execute.Block{
+				&ast.ExportNode{Names: []string{"a", "b"}},
+				&ast.ExportNode{Names: []string{"c"}},
+			}},
+		},
+		{
+			name:    "import_missing_as",
+			code:    `import "x.slo"`,
+			wantErr: `SyntaxError: unexpected symbol, expected "as" on line 1`,
+		},
+		{
+			name:    "import_missing_from",
+			code:    `import { a } "x.slo"`,
+			wantErr: `SyntaxError: unexpected symbol, expected "from" on line 1: "\"x.slo\""`,
+		},
+		{
+			name:    "import_no_names",
+			code:    `import {} from "x.slo"`,
+			wantErr: "SyntaxError: import statement does not import any names on line 1",
+		},
+		{
+			name:    "import_non_string",
+			code:    `import { a } from x`,
+			wantErr: `SyntaxError: expected a module name on line 1: "x"`,
+		},
+		{
+			name:    "export_private",
+			code:    "export var _x = 1",
+			wantErr: `SyntaxError: private variables cannot be exported on line 1: "_x"`,
+		},
+		{
+			name:    "export_nothing",
+			code:    "export\n",
+			wantErr: `SyntaxError: expected a declaration or a list of variables to export on line 1: "\n"`,
+		},
+		{
+			name:    "export_in_block",
+			code:    "if true {\n  export var x = 1\n}",
+			wantErr: "SyntaxError: export statements must be at the top level of a file on line 2",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.code)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Parse() returned incorrect error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, allowTypesUnexported); diff != "" {
+				t.Errorf("Incorrect AST (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLocations(t *testing.T) {
+	code := "var x = foo(1,\n  a.b)\nif x {\n  -y\n}\n"
+	a, err := ParseFile("a.slo", code)
+	if err != nil {
+		t.Fatalf("ParseFile() returned an unexpected error: %v", err)
+	}
+	nodes := a.(*ast.AST).Nodes
+	varNode := nodes[0].(*ast.VarNode)
+	callNode := varNode.Value.(*ast.CallNode)
+	ifNode := nodes[1].(*ast.IfNode)
+	span := func(startLine, startCol, endLine, endCol int) string {
+		return fmt.Sprintf("a.slo %d:%d-%d:%d", startLine, startCol, endLine, endCol)
+	}
+	tests := []struct {
+		name string
+		node execute.Expression
+		want string
+	}{
+		{name: "var", node: varNode, want: span(1, 1, 2, 7)},
+		{name: "call", node: callNode, want: span(1, 9, 2, 7)},
+		{name: "func", node: callNode.Func, want: span(1, 9, 1, 12)},
+		{name: "arg", node: callNode.Args[0], want: span(1, 13, 1, 14)},
+		{name: "attribute", node: callNode.Args[1], want: span(2, 3, 2, 6)},
+		{name: "if", node: ifNode, want: span(3, 1, 5, 2)},
+		{name: "cond", node: ifNode.Cond, want: span(3, 4, 3, 5)},
+		{name: "unop", node: ifNode.Body[0], want: span(4, 3, 4, 5)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.node.(ast.Located).Location()
+			got := fmt.Sprintf("%s %s-%s", s.File, s.Start, s.End)
+			if got != tc.want {
+				t.Errorf("Location() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHints(t *testing.T) {
+	tests := []struct {
+		code     string
+		wantHint string
+	}{
+		{code: "if x + 1 = 2 {\n}", wantHint: "did you mean `==`?"},
+		{code: "var x == 1", wantHint: "did you mean `=`?"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			_, err := Parse(tc.code)
+			se, ok := err.(*errors.SlowError)
+			if !ok {
+				t.Fatalf("Parse() returned %v, want a Slow error", err)
+			}
+			if got := se.Hint(); got != tc.wantHint {
+				t.Errorf("Parse() returned an error with hint %q, want %q", got, tc.wantHint)
+			}
+		})
+	}
+}
+
+func TestLexerErrors(t *testing.T) {
+	want := `SyntaxError: unterminated string on line 2: "\"b)"`
+	_, err := Parse("print(\"a\")\nprint(\"b)\n")
+	if err == nil || err.Error() != want {
+		t.Errorf("Parse() returned incorrect error: got %v, want %q", err, want)
+	}
+}
+
+func TestErrorRecovery(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{
+			name: "incomplete_expression",
+			code: "var x = 1 +",
+			want: []string{"EOFError: ran out of input on line 1"},
+		},
+		{
+			name: "statements",
+			code: "var x = 1 +\nvar y = 2\nz = )\n",
+			want: []string{
+				`SyntaxError: unexpected symbol on line 1: "\n"`,
+				`SyntaxError: unexpected symbol on line 3: ")"`,
+			},
+		},
+		{
+			name: "skips_brackets",
+			code: "var x = foo(1 +, [\n  2,\n])\nvar y = ]\n",
+			want: []string{
+				`SyntaxError: unexpected symbol on line 1: ","`,
+				`SyntaxError: unexpected symbol on line 4: "]"`,
+			},
+		},
+		{
+			name: "block",
+			code: "func f() {\n  var = 1\n  x +\n}\nvar y = )\n",
+			want: []string{
+				`SyntaxError: not a symbol on line 2: "="`,
+				`SyntaxError: unexpected symbol on line 3: "\n"`,
+				`SyntaxError: unexpected symbol on line 5: ")"`,
+			},
+		},
+		{
+			name: "nested_blocks",
+			code: "if x {\n  export var y = 1\n  while y {\n    )\n  }\n  y +\n}",
+			want: []string{
+				"SyntaxError: export statements must be at the top level of a file on line 2",
+				`SyntaxError: unexpected symbol on line 4: ")"`,
+				`SyntaxError: unexpected symbol on line 6: "\n"`,
+			},
+		},
+		{
+			name: "unclosed_block",
+			code: "func f() {\n  )\n",
+			want: []string{
+				`SyntaxError: unexpected symbol on line 2: ")"`,
+				"EOFError: ran out of input on line 3",
+			},
+		},
+		{
+			name: "stray_curly_bracket",
+			code: "}\nvar x = 1 *\n",
+			want: []string{
+				`SyntaxError: unexpected symbol on line 1: "}"`,
+				`SyntaxError: unexpected symbol on line 2: "\n"`,
+			},
+		},
+		{
+			name: "bad_type_cast",
+			code: "x as",
+			want: []string{"SyntaxError: expected a type on line 1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.code)
+			var got []string
+			for _, e := range errors.Errors(err) {
+				got = append(got, e.Error())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Parse() returned incorrect errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParsePartial(t *testing.T) {
+	code := "var x = 1\nvar y = (x + *) - 1\nprint(x)\n"
+	a, err := ParsePartial("a.slo", code)
+	if err == nil {
+		t.Fatal("ParsePartial() did not return an error")
+	}
+	if a == nil {
+		t.Fatal("ParsePartial() did not return an AST")
+	}
+	span := func(startLine, startCol, endLine, endCol int) string {
+		return fmt.Sprintf("a.slo %d:%d-%d:%d", startLine, startCol, endLine, endCol)
+	}
+	want := []struct {
+		node execute.Expression
+		span string
+	}{
+		{node: &ast.VarNode{}, span: span(1, 1, 1, 10)},
+		{node: &ast.BadNode{}, span: span(2, 1, 2, 20)},
+		{node: &ast.CallNode{}, span: span(3, 1, 3, 9)},
+	}
+	if len(a.Nodes) != len(want) {
+		t.Fatalf("ParsePartial() returned %d nodes, want %d", len(a.Nodes), len(want))
+	}
+	for i, w := range want {
+		if got, want := fmt.Sprintf("%T", a.Nodes[i]), fmt.Sprintf("%T", w.node); got != want {
+			t.Errorf("node %d has type %s, want %s", i, got, want)
+		}
+		s := a.Nodes[i].(ast.Located).Location()
+		if got := fmt.Sprintf("%s %s-%s", s.File, s.Start, s.End); got != w.span {
+			t.Errorf("node %d has location %s, want %s", i, got, w.span)
+		}
+	}
+
+	if _, err := ParsePartial("", `"unterminated`); err == nil {
+		t.Error("ParsePartial() did not return an error for code that can't be tokenized")
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		code     string
+		want     execute.Value
+		wantErr  string
+		wantHint string
+	}{
+		{code: "1_000_000", want: types.NewInt(1000000)},
+		{code: "1_000u", want: types.NewUint(1000)},
+		{code: "1e-9", want: types.NewFloat(1e-9)},
+		{code: "2.5E+3", want: types.NewFloat(2500)},
+		{code: "1_0.5", want: types.NewFloat(10.5)},
+		{code: "0b1010", want: types.NewInt(10)},
+		{code: "0b1010u", want: types.NewUint(10)},
+		{code: "0o755", want: types.NewInt(493)},
+		{code: "0xFF_FFi", want: types.NewInt(65535)},
+		{code: "0xFFu", want: types.NewUint(255)},
+		{code: "9223372036854775807", want: types.NewInt(9223372036854775807)},
+		{code: "1e-400", want: types.NewFloat(0)},
+		{code: "18446744073709551616n", want: types.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64))},
+		{code: "0xffff_ffffn", want: types.NewBigInt(big.NewInt(0xffffffff))},
+		{code: "0b1n", want: types.NewBigInt(big.NewInt(1))},
+		{code: "19.99d", want: types.NewDecimal(decimal.MustParse("19.99"))},
+		{code: "1_000d", want: types.NewDecimal(decimal.MustParse("1000"))},
+		{code: "2.5e-3d", want: types.NewDecimal(decimal.MustParse("0.0025"))},
+		{
+			code:    "1e9999999d",
+			wantErr: `SyntaxError: decimal literal out of range on line 1: "1e9999999d"`,
+		},
+		{
+			code:     "9223372036854775808",
+			wantErr:  `SyntaxError: int literal out of range on line 1: "9223372036854775808"`,
+			wantHint: `did you mean "9223372036854775808u"?`,
+		},
+		{
+			code:     "0x8000_0000_0000_0000i",
+			wantErr:  `SyntaxError: int literal out of range on line 1: "0x8000_0000_0000_0000i"`,
+			wantHint: `did you mean "0x8000_0000_0000_0000u"?`,
+		},
+		{
+			code:     "18446744073709551616",
+			wantErr:  `SyntaxError: int literal out of range on line 1: "18446744073709551616"`,
+			wantHint: `did you mean "18446744073709551616n"?`,
+		},
+		{
+			code:     "18446744073709551616u",
+			wantErr:  `SyntaxError: uint literal out of range on line 1: "18446744073709551616u"`,
+			wantHint: `did you mean "18446744073709551616n"?`,
+		},
+		{
+			code:    "1e400",
+			wantErr: `SyntaxError: float literal out of range on line 1: "1e400"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			a, err := Parse(tc.code)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Parse() returned incorrect error: got %v, want %q", err, tc.wantErr)
+				}
+				if got := err.(*errors.SlowError).Hint(); got != tc.wantHint {
+					t.Errorf("Parse() returned an error with hint %q, want %q", got, tc.wantHint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() returned an unexpected error: %v", err)
+			}
+			got, err := a.Execute(execute.NewEnvironment())
+			if err != nil {
+				t.Fatalf("a.Execute returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, allowTypesUnexported); diff != "" {
+				t.Errorf("a.Execute returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStrings(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: `"héllo\t世界"`, want: "héllo\t世界"},
+		{code: `'it\'s'`, want: "it's"},
+		{code: `r"\d+"`, want: `\d+`},
+		{code: `"\u{1F600}\x21"`, want: "😀!"},
+		{code: "'''\n  a\n    b\n  '''", want: "a\n  b"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			got, err := Parse(tc.code)
+			if err != nil {
+				t.Fatalf("Parse() returned an unexpected error: %v", err)
+			}
+			want := &ast.AST{Nodes: execute.Block{&ast.ConstantNode{Value: types.NewStr(tc.want)}}}
+			if diff := cmp.Diff(want, got, allowTypesUnexported); diff != "" {
+				t.Errorf("Incorrect AST (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
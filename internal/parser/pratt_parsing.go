@@ -19,6 +19,10 @@ const (
 	bp_Cast
 	bp_Logical
 	bp_Relational
+	bp_BitwiseOr
+	bp_BitwiseXor
+	bp_BitwiseAnd
+	bp_Shift
 	bp_Additive
 	bp_Multiplicative
 	bp_Exponent
@@ -77,6 +81,15 @@ func init() {
 	makeLEDHandler(lexer.DivideEqual, bp_Assignment, parseBinaryOperation)
 	makeLEDHandler(lexer.ModEqual, bp_Assignment, parseBinaryOperation)
 	makeLEDHandler(lexer.FloorDivEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.ExponentiateEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.AndEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.OrEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.XorEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.BitwiseAndEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.BitwiseOrEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.BitwiseXorEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.LeftShiftEqual, bp_Assignment, parseBinaryOperation)
+	makeLEDHandler(lexer.RightShiftEqual, bp_Assignment, parseBinaryOperation)
 
 	// Logical
 	makeLEDHandler(lexer.And, bp_Logical, parseBinaryOperation)
@@ -91,6 +104,13 @@ func init() {
 	makeLEDHandler(lexer.Greater, bp_Relational, parseBinaryOperation)
 	makeLEDHandler(lexer.GreaterEqual, bp_Relational, parseBinaryOperation)
 
+	// Bitwise
+	makeLEDHandler(lexer.BitwiseOr, bp_BitwiseOr, parseBinaryOperation)
+	makeLEDHandler(lexer.BitwiseXor, bp_BitwiseXor, parseBinaryOperation)
+	makeLEDHandler(lexer.BitwiseAnd, bp_BitwiseAnd, parseBinaryOperation)
+	makeLEDHandler(lexer.LeftShift, bp_Shift, parseBinaryOperation)
+	makeLEDHandler(lexer.RightShift, bp_Shift, parseBinaryOperation)
+
 	// Additive & Multiplicitave
 	makeLEDHandler(lexer.Plus, bp_Additive, parseBinaryOperation)
 	makeLEDHandler(lexer.Minus, bp_Additive, parseBinaryOperation)
@@ -115,6 +135,9 @@ func init() {
 	makeNUDHandler(lexer.Plus, bp_Unary, parseUnaryOperation)
 	makeNUDHandler(lexer.Minus, bp_Unary, parseUnaryOperation)
 	makeNUDHandler(lexer.Not, bp_Unary, parseUnaryOperation)
+	makeNUDHandler(lexer.BitwiseNot, bp_Unary, parseUnaryOperation)
+	makeNUDHandler(lexer.PlusPlus, bp_Unary, parseUnaryOperation)
+	makeNUDHandler(lexer.MinusMinus, bp_Unary, parseUnaryOperation)
 	makeNUDHandler(lexer.OpenBracket, bp_Primary, parseList)
 	makeNUDHandler(lexer.OpenCurlyBracket, bp_Primary, parseMap)
 
@@ -146,4 +169,5 @@ func init() {
 	makeStmtHandler(lexer.Defer, parseDefer)
 	makeStmtHandler(lexer.Var, parseVar)
 	makeStmtHandler(lexer.Const, parseVar)
+	makeStmtHandler(lexer.Export, parseExport)
 }
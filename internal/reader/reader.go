@@ -3,22 +3,24 @@ package reader
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/chrispyles/slow/internal/errors"
 )
 
-func Read(rdr *bufio.Reader) (string, error) {
+// Read reads a complete statement from rdr, writing prompts to out.
+func Read(rdr *bufio.Reader, out io.Writer) (string, error) {
 	var s string
 	start := true
 	var complete bool
 	var isMultiline bool
 	for {
 		if start {
-			fmt.Print("-> ")
+			fmt.Fprint(out, "-> ")
 			start = false
 		} else {
-			fmt.Print(".. ")
+			fmt.Fprint(out, ".. ")
 		}
 		line, err := rdr.ReadString('\n')
 		if err != nil {
@@ -0,0 +1,70 @@
+// Package source describes locations in Slow source code.
+package source
+
+import "fmt"
+
+// Pos is a position in a source file. Line and Column are 1-indexed and Column counts runes, so the
+// zero Pos is not a valid position.
+type Pos struct {
+	// Offset is the byte offset of the position from the start of the file.
+	Offset int
+	Line   int
+	Column int
+}
+
+// IsValid returns whether this position has been set.
+func (p Pos) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Advance returns the position after the provided text, which starts at this position.
+func (p Pos) Advance(text string) Pos {
+	for _, r := range text {
+		if r == '\n' {
+			p.Line++
+			p.Column = 1
+		} else {
+			p.Column++
+		}
+	}
+	p.Offset += len(text)
+	return p
+}
+
+// Start is the position of the first character in a file.
+var Start = Pos{Offset: 0, Line: 1, Column: 1}
+
+// Span is the range of source code between Start (inclusive) and End (exclusive) in a file.
+type Span struct {
+	// File is the path to the file the span is in, or the empty string if the code was not read from
+	// a file.
+	File  string
+	Start Pos
+	End   Pos
+}
+
+// IsValid returns whether this span has been set.
+func (s Span) IsValid() bool {
+	return s.Start.IsValid()
+}
+
+// To returns a span from the start of this span to the end of the other one.
+func (s Span) To(o Span) Span {
+	return Span{File: s.File, Start: s.Start, End: o.End}
+}
+
+// FileName returns the name of the span's file, or "<input>" if it is not in a file.
+func (s Span) FileName() string {
+	if s.File == "" {
+		return "<input>"
+	}
+	return s.File
+}
+
+func (s Span) String() string {
+	return fmt.Sprintf("%s:%s", s.FileName(), s.Start)
+}
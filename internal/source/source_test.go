@@ -0,0 +1,64 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPos_Advance(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  Pos
+		text string
+		want Pos
+	}{
+		{
+			name: "empty",
+			pos:  Start,
+			want: Start,
+		},
+		{
+			name: "same_line",
+			pos:  Start,
+			text: "var x",
+			want: Pos{Offset: 5, Line: 1, Column: 6},
+		},
+		{
+			name: "newlines",
+			pos:  Pos{Offset: 3, Line: 2, Column: 4},
+			text: "a\n\nbc",
+			want: Pos{Offset: 8, Line: 4, Column: 3},
+		},
+		{
+			name: "multibyte",
+			pos:  Start,
+			text: "\"é\"",
+			want: Pos{Offset: 4, Line: 1, Column: 4},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.pos.Advance(tc.text)); diff != "" {
+				t.Errorf("Advance() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSpan(t *testing.T) {
+	a := Span{File: "a.slo", Start: Start, End: Pos{Offset: 1, Line: 1, Column: 2}}
+	b := Span{File: "a.slo", Start: Pos{Offset: 4, Line: 2, Column: 1}, End: Pos{Offset: 7, Line: 2, Column: 4}}
+	if diff := cmp.Diff(Span{File: "a.slo", Start: a.Start, End: b.End}, a.To(b)); diff != "" {
+		t.Errorf("To() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if got, want := b.String(), "a.slo:2:1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := (Span{Start: Start}).String(), "<input>:1:1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if (Span{}).IsValid() {
+		t.Errorf("IsValid() = true for the zero span")
+	}
+}
@@ -1,6 +1,8 @@
 package cmpopts
 
 import (
+	"math/big"
+	"reflect"
 	"testing"
 	"unsafe"
 
@@ -9,16 +11,24 @@ import (
 	"github.com/chrispyles/slow/internal/testing/helpers"
 	"github.com/chrispyles/slow/internal/types"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
+// AllowUnexported allows comparing the unexported fields of Slow values and errors, and compares the
+// big.Ints in bigint values by value. The environments that user-defined functions were declared in
+// are compared by identity, since they usually contain the functions themselves.
 func AllowUnexported(addl ...interface{}) cmp.Option {
-	return cmp.AllowUnexported(
+	return cmp.Options{cmp.AllowUnexported(
 		append(
 			[]interface{}{
 				errors.SlowError{},
 				execute.Environment{},
+				types.BigInt{},
 				types.Bool{},
 				types.Bytes{},
+				types.Datetime{},
+				types.Decimal{},
+				types.Duration{},
 				types.Float{},
 				types.Func{},
 				types.Generator{},
@@ -31,16 +41,34 @@ func AllowUnexported(addl ...interface{}) cmp.Option {
 				types.Uint{},
 			},
 			addl...)...,
-	)
+	), cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 }), funcScopes}
+}
+
+// funcScopes compares the scopes of user-defined functions by identity.
+var funcScopes = cmp.FilterPath(func(p cmp.Path) bool {
+	sf, ok := p.Last().(cmp.StructField)
+	return ok && sf.Name() == "scope" && p.Index(-2).Type() == reflect.TypeOf(types.Func{})
+}, cmp.Comparer(func(x, y *execute.Environment) bool { return x == y }))
+
+// IgnoreErrorLocations ignores the locations and tracebacks recorded on Slow errors.
+func IgnoreErrorLocations() cmp.Option {
+	return cmpopts.IgnoreFields(errors.SlowError{}, "span", "frames")
 }
 
 // Adapted from https://github.com/google/go-cmp/issues/162
 func EquateFuncs() cmp.Option {
-	return cmp.Comparer(func(x, y types.FuncImpl) bool {
-		px := *(*unsafe.Pointer)(unsafe.Pointer(&x))
-		py := *(*unsafe.Pointer)(unsafe.Pointer(&y))
-		return px == py
-	})
+	return cmp.Options{
+		cmp.Comparer(func(x, y types.FuncImpl) bool {
+			px := *(*unsafe.Pointer)(unsafe.Pointer(&x))
+			py := *(*unsafe.Pointer)(unsafe.Pointer(&y))
+			return px == py
+		}),
+		cmp.Comparer(func(x, y types.EnvFuncImpl) bool {
+			px := *(*unsafe.Pointer)(unsafe.Pointer(&x))
+			py := *(*unsafe.Pointer)(unsafe.Pointer(&y))
+			return px == py
+		}),
+	}
 }
 
 func CheckDiff(t *testing.T, name string, want, got interface{}, opts ...cmp.Option) {
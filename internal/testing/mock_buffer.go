@@ -1,9 +1,16 @@
 package testing
 
+import "github.com/chrispyles/slow/internal/source"
+
 type MockBuffer struct {
-	LineNumberRet int
+	LineNumberRet  int
+	CurrentSpanRet source.Span
 }
 
 func (m *MockBuffer) LineNumber() int {
 	return m.LineNumberRet
 }
+
+func (m *MockBuffer) CurrentSpan() source.Span {
+	return m.CurrentSpanRet
+}
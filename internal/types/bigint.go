@@ -0,0 +1,236 @@
+package types
+
+import (
+	stderrors "errors"
+	"math"
+	"math/big"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Type definition
+// -------------------------------------------------------------------------------------------------
+
+type bigIntType struct{}
+
+func (t *bigIntType) IsNumeric() bool {
+	return true
+}
+
+func (t *bigIntType) New(v execute.Value) (execute.Value, error) {
+	vc, err := ToBigInt(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewBigInt(vc), nil
+}
+
+func (t *bigIntType) String() string {
+	return "bigint"
+}
+
+var BigIntType = &bigIntType{}
+
+// -------------------------------------------------------------------------------------------------
+// Type implementation
+// -------------------------------------------------------------------------------------------------
+
+// BigInt is an arbitrary-precision integer. Its value is never modified after it is created, so the
+// underlying big.Int can be shared.
+type BigInt struct {
+	value *big.Int
+}
+
+func NewBigInt(v *big.Int) *BigInt {
+	return &BigInt{v}
+}
+
+// Value returns the integer that this BigInt represents. The caller must not modify it.
+func (v *BigInt) Value() *big.Int {
+	return v.value
+}
+
+func (v *BigInt) CloneIfPrimitive() execute.Value {
+	return NewBigInt(new(big.Int).Set(v.value))
+}
+
+func (v *BigInt) CompareTo(o execute.Value) (int, bool) {
+	switch o.Type() {
+	case DecimalType:
+		c, _ := o.CompareTo(v)
+		return -c, true
+	case FloatType:
+		of := must(o.ToFloat())
+		if math.IsNaN(of) {
+			return compareNumbers(must(v.ToFloat()), of), true
+		}
+		return new(big.Float).SetInt(v.value).Cmp(big.NewFloat(of)), true
+	case BigIntType:
+		fallthrough
+	case BoolType:
+		fallthrough
+	case IntType:
+		fallthrough
+	case UintType:
+		return v.value.Cmp(must(ToBigInt(o))), true
+	}
+	return 0, false
+}
+
+func (v *BigInt) Equals(o execute.Value) bool {
+	ob, ok := o.(*BigInt)
+	if !ok {
+		return false
+	}
+	return v.value.Cmp(ob.value) == 0
+}
+
+func (v *BigInt) GetAttribute(a string) (execute.Value, error) {
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *BigInt) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *BigInt) HasAttribute(a string) bool {
+	return false
+}
+
+func (v *BigInt) HashBytes() ([]byte, error) {
+	return v.value.Append(nil, 10), nil
+}
+
+func (v *BigInt) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *BigInt) SetAttribute(a string, _ execute.Value) error {
+	return errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *BigInt) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+func (v *BigInt) String() string {
+	return v.value.String() + "n"
+}
+
+func (v *BigInt) ToBool() bool {
+	return v.value.Sign() != 0
+}
+
+// ToBytes returns the big-endian bytes of the absolute value of this integer. Negative integers can't
+// be converted to bytes.
+func (v *BigInt) ToBytes() ([]byte, error) {
+	if v.value.Sign() < 0 {
+		return nil, errors.NewValueError("cannot convert a negative bigint to bytes")
+	}
+	if v.value.Sign() == 0 {
+		return []byte{0x00}, nil
+	}
+	return v.value.Bytes(), nil
+}
+
+func (v *BigInt) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), FuncType)
+}
+
+func (v *BigInt) ToFloat() (float64, error) {
+	f, _ := new(big.Float).SetInt(v.value).Float64()
+	return f, nil
+}
+
+func (v *BigInt) ToInt() (int64, error) {
+	if !v.value.IsInt64() {
+		return 0, errors.ConversionOverflowError(v.value.String(), IntType)
+	}
+	return v.value.Int64(), nil
+}
+
+func (v *BigInt) ToIterator() (execute.Iterator, error) {
+	return nil, errors.NewTypeError(v.Type(), IteratorType)
+}
+
+func (v *BigInt) ToStr() (string, error) {
+	return v.value.String(), nil
+}
+
+func (v *BigInt) ToUint() (uint64, error) {
+	if !v.value.IsUint64() {
+		return 0, errors.ConversionOverflowError(v.value.String(), UintType)
+	}
+	return v.value.Uint64(), nil
+}
+
+func (v *BigInt) Type() execute.Type {
+	return BigIntType
+}
+
+// -------------------------------------------------------------------------------------------------
+// Conversions
+// -------------------------------------------------------------------------------------------------
+
+// ToBigInt returns the value of v as an arbitrary-precision integer. Floats and decimals are truncated, strings are
+// parsed as decimal integers, and bytes are interpreted as unsigned big-endian integers.
+func ToBigInt(v execute.Value) (*big.Int, error) {
+	switch v := v.(type) {
+	case *BigInt:
+		return new(big.Int).Set(v.value), nil
+	case *Bool:
+		return big.NewInt(must(v.ToInt())), nil
+	case *Bytes:
+		return new(big.Int).SetBytes(v.value), nil
+	case *Decimal:
+		return v.value.Int(), nil
+	case *Float:
+		if math.IsNaN(v.value) || math.IsInf(v.value, 0) {
+			return nil, errors.ConversionOverflowError(v.String(), BigIntType)
+		}
+		b, _ := big.NewFloat(v.value).Int(nil)
+		return b, nil
+	case *Int:
+		return big.NewInt(v.value), nil
+	case *Str:
+		b, ok := new(big.Int).SetString(v.value, 10)
+		if !ok {
+			return nil, errors.WrapValueError(v.value, BigIntType, stderrors.New("invalid syntax"))
+		}
+		return b, nil
+	case *Uint:
+		return new(big.Int).SetUint64(v.value), nil
+	}
+	return nil, errors.NewTypeError(v.Type(), BigIntType)
+}
+
+// CheckedNew converts v to type t like t.New, except that converting a number that is out of range
+// for an int or uint returns an OverflowError instead of wrapping around.
+func CheckedNew(t execute.Type, v execute.Value) (execute.Value, error) {
+	if _, numeric := typeHierarchy[v.Type()]; numeric && (t == IntType || t == UintType) {
+		b, err := ToBigInt(v)
+		if err != nil {
+			return nil, errors.ConversionOverflowError(v.String(), t)
+		}
+		if !FitsType(b, t) {
+			return nil, errors.ConversionOverflowError(b.String(), t)
+		}
+	}
+	return t.New(v)
+}
+
+// FitsType returns whether b can be represented by a value of type t without overflowing. Every
+// integer fits in a bigint, but no integer fits in a non-integer type.
+func FitsType(b *big.Int, t execute.Type) bool {
+	switch t {
+	case BigIntType:
+		return true
+	case IntType:
+		return b.IsInt64()
+	case UintType:
+		return b.IsUint64()
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package types
+
+import (
+	stderrors "errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	testhelpers "github.com/chrispyles/slow/internal/testing/helpers"
+	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
+	"github.com/google/go-cmp/cmp"
+)
+
+// twoTo64 is 2**64, which is too large for an int or a uint.
+var twoTo64 = new(big.Int).Lsh(big.NewInt(1), 64)
+
+func TestBigIntType(t *testing.T) {
+	tc := typestesting.TypeTestCase{
+		Type: BigIntType,
+		NewTestCases: []typestesting.NewTestCase{
+			{In: NewInt(-3), Want: NewBigInt(big.NewInt(-3))},
+			{In: NewUint(math.MaxUint64), Want: NewBigInt(new(big.Int).SetUint64(math.MaxUint64))},
+			{In: NewFloat(-2.9), Want: NewBigInt(big.NewInt(-2))},
+			{In: NewBool(true), Want: NewBigInt(big.NewInt(1))},
+			{In: NewStr("18446744073709551616"), Want: NewBigInt(twoTo64)},
+			{In: NewBytes([]byte{0x01, 0x00}), Want: NewBigInt(big.NewInt(256))},
+			{
+				In:      NewStr("1.5"),
+				WantErr: errors.WrapValueError("1.5", BigIntType, stderrors.New("invalid syntax")),
+			},
+			{
+				In:      NewFloat(math.Inf(1)),
+				WantErr: errors.ConversionOverflowError("+Inf", BigIntType),
+			},
+		},
+		WantString:    "bigint",
+		WantIsNumeric: true,
+	}
+	tc.Run(t)
+}
+
+func TestBigInt(t *testing.T) {
+	t.Run("CloneIfPrimitive", func(t *testing.T) {
+		in := NewBigInt(big.NewInt(5))
+		got := in.CloneIfPrimitive().(*BigInt)
+		testhelpers.CheckDiff(t, "CloneIfPrimitive()", in, got, allowUnexported)
+		if reflect.ValueOf(in).Pointer() == reflect.ValueOf(got).Pointer() || in.value == got.value {
+			t.Errorf("CloneIfPrimitive() did not create a clone")
+		}
+	})
+
+	t.Run("CompareTo", func(t *testing.T) {
+		for _, tc := range []struct {
+			in     *BigInt
+			other  execute.Value
+			want   int
+			wantOk bool
+		}{
+			{in: NewBigInt(big.NewInt(1)), other: NewBigInt(big.NewInt(1)), want: 0, wantOk: true},
+			{in: NewBigInt(twoTo64), other: NewBigInt(big.NewInt(1)), want: 1, wantOk: true},
+			{in: NewBigInt(twoTo64), other: NewUint(math.MaxUint64), want: 1, wantOk: true},
+			{in: NewBigInt(big.NewInt(-1)), other: NewInt(0), want: -1, wantOk: true},
+			{in: NewBigInt(big.NewInt(1)), other: NewBool(true), want: 0, wantOk: true},
+			{in: NewBigInt(big.NewInt(1)), other: NewFloat(1.5), want: -1, wantOk: true},
+			{in: NewBigInt(twoTo64), other: NewFloat(math.Inf(1)), want: -1, wantOk: true},
+			{in: NewBigInt(big.NewInt(1)), other: NewStr("1"), want: 0, wantOk: false},
+		} {
+			got, ok := tc.in.CompareTo(tc.other)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("%v.CompareTo(%v) = (%d, %v), want (%d, %v)", tc.in, tc.other, got, ok, tc.want, tc.wantOk)
+			}
+		}
+	})
+
+	t.Run("CompareTo_other_types", func(t *testing.T) {
+		for _, other := range []execute.Value{NewBool(true), NewFloat(1), NewInt(1), NewUint(1)} {
+			got, ok := other.CompareTo(NewBigInt(twoTo64))
+			if got != -1 || !ok {
+				t.Errorf("%v.CompareTo(%v) = (%d, %v), want (-1, true)", other, twoTo64, got, ok)
+			}
+		}
+	})
+
+	t.Run("Equals", func(t *testing.T) {
+		v := NewBigInt(big.NewInt(3))
+		if !v.Equals(NewBigInt(big.NewInt(3))) {
+			t.Errorf("Equals() returned false for an equal bigint")
+		}
+		if v.Equals(NewBigInt(big.NewInt(4))) {
+			t.Errorf("Equals() returned true for an unequal bigint")
+		}
+		if v.Equals(NewInt(3)) {
+			t.Errorf("Equals() returned true for an int")
+		}
+	})
+
+	t.Run("HashBytes", func(t *testing.T) {
+		got := must(NewBigInt(big.NewInt(-12)).HashBytes())
+		if want := []byte("-12"); !reflect.DeepEqual(got, want) {
+			t.Errorf("HashBytes() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got, want := NewBigInt(twoTo64).String(), "18446744073709551616n"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+		if got, want := must(NewBigInt(twoTo64).ToStr()), "18446744073709551616"; got != want {
+			t.Errorf("ToStr() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("ToBool", func(t *testing.T) {
+		if NewBigInt(new(big.Int)).ToBool() {
+			t.Errorf("ToBool() returned true for 0")
+		}
+		if !NewBigInt(big.NewInt(-1)).ToBool() {
+			t.Errorf("ToBool() returned false for -1")
+		}
+	})
+
+	t.Run("ToBytes", func(t *testing.T) {
+		for _, tc := range []struct {
+			in      *big.Int
+			want    []byte
+			wantErr error
+		}{
+			{in: new(big.Int), want: []byte{0x00}},
+			{in: big.NewInt(0x1234), want: []byte{0x12, 0x34}},
+			{in: big.NewInt(-1), wantErr: errors.NewValueError("cannot convert a negative bigint to bytes")},
+		} {
+			got, err := NewBigInt(tc.in).ToBytes()
+			if diff := cmp.Diff(tc.wantErr, err, allowUnexported); diff != "" {
+				t.Errorf("ToBytes() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ToBytes() returned incorrect value (-want +got):\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("ToFloat", func(t *testing.T) {
+		if got, want := must(NewBigInt(twoTo64).ToFloat()), math.Pow(2, 64); got != want {
+			t.Errorf("ToFloat() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ToInt", func(t *testing.T) {
+		if got := must(NewBigInt(big.NewInt(math.MinInt64)).ToInt()); got != math.MinInt64 {
+			t.Errorf("ToInt() = %d, want %d", got, int64(math.MinInt64))
+		}
+		_, err := NewBigInt(twoTo64).ToInt()
+		want := errors.ConversionOverflowError("18446744073709551616", IntType)
+		if diff := cmp.Diff(want, err, allowUnexported); diff != "" {
+			t.Errorf("ToInt() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ToUint", func(t *testing.T) {
+		if got := must(NewBigInt(new(big.Int).SetUint64(math.MaxUint64)).ToUint()); got != math.MaxUint64 {
+			t.Errorf("ToUint() = %d, want %d", got, uint64(math.MaxUint64))
+		}
+		_, err := NewBigInt(big.NewInt(-1)).ToUint()
+		want := errors.ConversionOverflowError("-1", UintType)
+		if diff := cmp.Diff(want, err, allowUnexported); diff != "" {
+			t.Errorf("ToUint() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		if got := NewBigInt(new(big.Int)).Type(); got != BigIntType {
+			t.Errorf("Type() = %v, want %v", got, BigIntType)
+		}
+	})
+}
+
+func TestCommonNumericType_bigint(t *testing.T) {
+	for _, tc := range []struct {
+		other execute.Type
+		want  execute.Type
+	}{
+		{other: FloatType, want: FloatType},
+		{other: BigIntType, want: BigIntType},
+		{other: IntType, want: BigIntType},
+		{other: UintType, want: BigIntType},
+		{other: BoolType, want: BigIntType},
+	} {
+		got, ok := CommonNumericType(BigIntType, tc.other)
+		if got != tc.want || !ok {
+			t.Errorf("CommonNumericType(bigint, %v) = (%v, %v), want (%v, true)", tc.other, got, ok, tc.want)
+		}
+	}
+}
+
+func TestCheckedNew(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		t       execute.Type
+		in      execute.Value
+		want    execute.Value
+		wantErr error
+	}{
+		{name: "int", t: IntType, in: NewUint(math.MaxInt64), want: NewInt(math.MaxInt64)},
+		{name: "uint", t: UintType, in: NewBigInt(big.NewInt(2)), want: NewUint(2)},
+		{name: "float", t: IntType, in: NewFloat(-2.5), want: NewInt(-2)},
+		{name: "non_numeric", t: UintType, in: NewStr("3"), want: NewUint(3)},
+		{name: "bytes", t: IntType, in: NewBytes([]byte{0, 0, 0, 0, 0, 0, 0, 0xFF}), want: NewInt(255)},
+		{
+			name:    "negative_uint",
+			t:       UintType,
+			in:      NewInt(-1),
+			wantErr: errors.ConversionOverflowError("-1", UintType),
+		},
+		{
+			name:    "large_int",
+			t:       IntType,
+			in:      NewUint(math.MaxUint64),
+			wantErr: errors.ConversionOverflowError("18446744073709551615", IntType),
+		},
+		{
+			name:    "large_float",
+			t:       IntType,
+			in:      NewFloat(1e19),
+			wantErr: errors.ConversionOverflowError("10000000000000000000", IntType),
+		},
+		{
+			name:    "nan",
+			t:       UintType,
+			in:      NewFloat(math.NaN()),
+			wantErr: errors.ConversionOverflowError("NaN", UintType),
+		},
+		{
+			name:    "negative_decimal_uint",
+			t:       UintType,
+			in:      newDecimal("-1.5"),
+			wantErr: errors.ConversionOverflowError("-1", UintType),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CheckedNew(tc.t, tc.in)
+			if diff := cmp.Diff(tc.wantErr, err, allowUnexported); diff != "" {
+				t.Errorf("CheckedNew() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, allowUnexported); diff != "" {
+				t.Errorf("CheckedNew() returned incorrect value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
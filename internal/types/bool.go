@@ -53,6 +53,11 @@ func (v *Bool) CompareTo(o execute.Value) (int, bool) {
 			return -1, true
 		}
 		return 1, true
+	case BigIntType:
+		fallthrough
+	case DecimalType:
+		c, _ := o.CompareTo(v)
+		return -c, true
 	case FloatType:
 		ou := o.(*Float)
 		return compareNumbers(must(v.ToFloat()), ou.value), true
@@ -0,0 +1,297 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+	// Time zones are loaded from the database embedded in the binary so that they don't depend on the
+	// system's zoneinfo files, which aren't available everywhere (e.g. in WebAssembly).
+	_ "time/tzdata"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Type definition
+// -------------------------------------------------------------------------------------------------
+
+type datetimeType struct{}
+
+func (t *datetimeType) IsNumeric() bool {
+	return false
+}
+
+// New converts v to a datetime. Strings are parsed in RFC 3339 format.
+func (t *datetimeType) New(v execute.Value) (execute.Value, error) {
+	switch v := v.(type) {
+	case *Datetime:
+		return NewDatetime(v.value), nil
+	case *Str:
+		tm, err := time.Parse(time.RFC3339Nano, v.value)
+		if err != nil {
+			return nil, errors.WrapValueError(v.value, t, err)
+		}
+		return NewDatetime(tm), nil
+	}
+	return nil, errors.NewTypeError(v.Type(), t)
+}
+
+func (t *datetimeType) String() string {
+	return "datetime"
+}
+
+var DatetimeType = &datetimeType{}
+
+// LoadZone returns the time zone with the provided IANA name (e.g. "America/New_York"), "UTC", or
+// "Local".
+func LoadZone(name string) (*time.Location, error) {
+	// time.LoadLocation treats an empty name as UTC, but an empty name is almost always a mistake.
+	if name == "" {
+		return nil, errors.NewValueError(`unknown time zone ""`)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.NewValueError(fmt.Sprintf("unknown time zone %q", name))
+	}
+	return loc, nil
+}
+
+// -------------------------------------------------------------------------------------------------
+// Type implementation
+// -------------------------------------------------------------------------------------------------
+
+// Datetime is an instant in time with nanosecond precision in a particular time zone.
+type Datetime struct {
+	value time.Time
+}
+
+func NewDatetime(v time.Time) *Datetime {
+	return &Datetime{v}
+}
+
+// Value returns the instant that this Datetime represents.
+func (v *Datetime) Value() time.Time {
+	return v.value
+}
+
+// datetimeField returns a method that returns a field of the datetime.
+func datetimeField(name string, field func(time.Time) execute.Value) func(*Datetime) execute.Value {
+	return func(v *Datetime) execute.Value {
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 0; got != want {
+				return nil, errors.CallError(name, got, want)
+			}
+			return field(v.value), nil
+		})
+	}
+}
+
+var datetimeMethods = map[string]func(*Datetime) execute.Value{
+	"year": datetimeField("datetime.year", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Year()))
+	}),
+	"month": datetimeField("datetime.month", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Month()))
+	}),
+	"day": datetimeField("datetime.day", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Day()))
+	}),
+	"hour": datetimeField("datetime.hour", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Hour()))
+	}),
+	"minute": datetimeField("datetime.minute", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Minute()))
+	}),
+	"second": datetimeField("datetime.second", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Second()))
+	}),
+	"nanosecond": datetimeField("datetime.nanosecond", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Nanosecond()))
+	}),
+	// weekday returns the day of the week, starting from 0 for Sunday.
+	"weekday": datetimeField("datetime.weekday", func(t time.Time) execute.Value {
+		return NewInt(int64(t.Weekday()))
+	}),
+	"yearday": datetimeField("datetime.yearday", func(t time.Time) execute.Value {
+		return NewInt(int64(t.YearDay()))
+	}),
+	"unix": datetimeField("datetime.unix", func(t time.Time) execute.Value {
+		return NewInt(t.Unix())
+	}),
+	"zone": datetimeField("datetime.zone", func(t time.Time) execute.Value {
+		return NewStr(t.Location().String())
+	}),
+	"offset": datetimeField("datetime.offset", func(t time.Time) execute.Value {
+		_, offset := t.Zone()
+		return NewDuration(time.Duration(offset) * time.Second)
+	}),
+	"utc": datetimeField("datetime.utc", func(t time.Time) execute.Value {
+		return NewDatetime(t.UTC())
+	}),
+	"local": datetimeField("datetime.local", func(t time.Time) execute.Value {
+		return NewDatetime(t.Local())
+	}),
+	"format": func(v *Datetime) execute.Value {
+		name := "datetime.format"
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 1; got != want {
+				return nil, errors.CallError(name, got, want)
+			}
+			layout, ok := vs[0].(*Str)
+			if !ok {
+				return nil, errors.NewTypeError(vs[0].Type(), StrType)
+			}
+			return NewStr(v.value.Format(layout.value)), nil
+		})
+	},
+	"in_zone": func(v *Datetime) execute.Value {
+		name := "datetime.in_zone"
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 1; got != want {
+				return nil, errors.CallError(name, got, want)
+			}
+			zone, ok := vs[0].(*Str)
+			if !ok {
+				return nil, errors.NewTypeError(vs[0].Type(), StrType)
+			}
+			loc, err := LoadZone(zone.value)
+			if err != nil {
+				return nil, err
+			}
+			return NewDatetime(v.value.In(loc)), nil
+		})
+	},
+	"add_date": func(v *Datetime) execute.Value {
+		name := "datetime.add_date"
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 3; got != want {
+				return nil, errors.CallError(name, got, want)
+			}
+			var n [3]int
+			for i, a := range vs {
+				switch a.(type) {
+				case *BigInt, *Bool, *Int, *Uint:
+				default:
+					return nil, errors.NewTypeError(a.Type(), IntType)
+				}
+				x, err := CheckedNew(IntType, a)
+				if err != nil {
+					return nil, err
+				}
+				n[i] = int(must(x.ToInt()))
+			}
+			return NewDatetime(v.value.AddDate(n[0], n[1], n[2])), nil
+		})
+	},
+	"truncate": func(v *Datetime) execute.Value {
+		name := "datetime.truncate"
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 1; got != want {
+				return nil, errors.CallError(name, got, want)
+			}
+			d, ok := vs[0].(*Duration)
+			if !ok {
+				return nil, errors.NewTypeError(vs[0].Type(), DurationType)
+			}
+			return NewDatetime(v.value.Truncate(d.value)), nil
+		})
+	},
+}
+
+func (v *Datetime) CloneIfPrimitive() execute.Value {
+	return NewDatetime(v.value)
+}
+
+func (v *Datetime) CompareTo(o execute.Value) (int, bool) {
+	od, ok := o.(*Datetime)
+	if !ok {
+		return 0, false
+	}
+	return v.value.Compare(od.value), true
+}
+
+// Equals returns whether o is a datetime at the same instant, even if it is in a different time zone.
+func (v *Datetime) Equals(o execute.Value) bool {
+	od, ok := o.(*Datetime)
+	if !ok {
+		return false
+	}
+	return v.value.Equal(od.value)
+}
+
+func (v *Datetime) GetAttribute(a string) (execute.Value, error) {
+	if methodFactory, ok := datetimeMethods[a]; ok {
+		return methodFactory(v), nil
+	}
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Datetime) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Datetime) HasAttribute(a string) bool {
+	_, ok := datetimeMethods[a]
+	return ok
+}
+
+// HashBytes returns the bytes of the instant this datetime represents, so that datetimes that are
+// equal but in different time zones have the same hash.
+func (v *Datetime) HashBytes() ([]byte, error) {
+	b := binary.BigEndian.AppendUint64(nil, uint64(v.value.Unix()))
+	return binary.BigEndian.AppendUint32(b, uint32(v.value.Nanosecond())), nil
+}
+
+func (v *Datetime) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *Datetime) SetAttribute(a string, _ execute.Value) error {
+	return errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Datetime) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+// String returns the datetime in RFC 3339 format.
+func (v *Datetime) String() string {
+	return v.value.Format(time.RFC3339Nano)
+}
+
+func (v *Datetime) ToBool() bool {
+	return true
+}
+
+func (v *Datetime) ToBytes() ([]byte, error) {
+	return nil, errors.NewTypeError(v.Type(), BytesType)
+}
+
+func (v *Datetime) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), FuncType)
+}
+
+func (v *Datetime) ToFloat() (float64, error) {
+	return 0, errors.NewTypeError(v.Type(), FloatType)
+}
+
+func (v *Datetime) ToInt() (int64, error) {
+	return 0, errors.NewTypeError(v.Type(), IntType)
+}
+
+func (v *Datetime) ToIterator() (execute.Iterator, error) {
+	return nil, errors.NewTypeError(v.Type(), IteratorType)
+}
+
+func (v *Datetime) ToStr() (string, error) {
+	return v.String(), nil
+}
+
+func (v *Datetime) ToUint() (uint64, error) {
+	return 0, errors.NewTypeError(v.Type(), UintType)
+}
+
+func (v *Datetime) Type() execute.Type {
+	return DatetimeType
+}
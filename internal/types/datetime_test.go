@@ -0,0 +1,237 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustLoadZone(name string) *time.Location {
+	return must(LoadZone(name))
+}
+
+func TestDatetimeType(t *testing.T) {
+	tc := typestesting.TypeTestCase{
+		Type: DatetimeType,
+		NewTestCases: []typestesting.NewTestCase{
+			{
+				In:   NewStr("2024-02-29T12:30:00Z"),
+				Want: NewDatetime(time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)),
+			},
+			{
+				In:   NewStr("2024-02-29T12:30:00.5-05:00"),
+				Want: NewDatetime(time.Date(2024, 2, 29, 17, 30, 0, 500_000_000, time.UTC)),
+			},
+			{
+				In:   NewDatetime(time.Unix(0, 0)),
+				Want: NewDatetime(time.Unix(0, 0)),
+			},
+			{
+				In:      NewStr("2024-02-29"),
+				WantErr: errors.WrapValueError("2024-02-29", DatetimeType, stringError(`parsing time "2024-02-29" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "" as "T"`)),
+			},
+			{
+				In:      NewInt(0),
+				WantErr: errors.NewTypeError(IntType, DatetimeType),
+			},
+			{
+				In:      NewDuration(time.Second),
+				WantErr: errors.NewTypeError(DurationType, DatetimeType),
+			},
+		},
+		WantString:    "datetime",
+		WantIsNumeric: false,
+	}
+	tc.Run(t)
+}
+
+func TestLoadZone(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{name: "UTC", want: "UTC"},
+		{name: "America/New_York", want: "America/New_York"},
+		{name: "", wantErr: errors.NewValueError(`unknown time zone ""`)},
+		{name: "Mars/Olympus_Mons", wantErr: errors.NewValueError(`unknown time zone "Mars/Olympus_Mons"`)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			loc, err := LoadZone(tc.name)
+			if diff := cmp.Diff(tc.wantErr, err, allowUnexported); diff != "" {
+				t.Errorf("LoadZone() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if err == nil && loc.String() != tc.want {
+				t.Errorf("LoadZone() = %v, want %v", loc, tc.want)
+			}
+		})
+	}
+}
+
+func TestDatetime(t *testing.T) {
+	ny := mustLoadZone("America/New_York")
+	// Thursday, 29 February 2024, 07:30:00.25 in New York (12:30:00.25 UTC).
+	dt := NewDatetime(time.Date(2024, 2, 29, 7, 30, 0, 250_000_000, ny))
+
+	t.Run("CloneIfPrimitive", func(t *testing.T) {
+		got := dt.CloneIfPrimitive().(*Datetime)
+		if reflect.ValueOf(dt).Pointer() == reflect.ValueOf(got).Pointer() {
+			t.Errorf("CloneIfPrimitive() did not create a clone")
+		}
+		if diff := cmp.Diff(dt, got, allowUnexported); diff != "" {
+			t.Errorf("CloneIfPrimitive() returned incorrect value (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("CompareTo", func(t *testing.T) {
+		for _, tc := range []struct {
+			other  execute.Value
+			want   int
+			wantOk bool
+		}{
+			{other: NewDatetime(dt.value.UTC()), want: 0, wantOk: true},
+			{other: NewDatetime(dt.value.Add(time.Nanosecond)), want: -1, wantOk: true},
+			{other: NewDatetime(dt.value.Add(-time.Hour)), want: 1, wantOk: true},
+			{other: NewStr(dt.String()), want: 0, wantOk: false},
+		} {
+			got, ok := dt.CompareTo(tc.other)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("CompareTo(%v) = (%d, %v), want (%d, %v)", tc.other, got, ok, tc.want, tc.wantOk)
+			}
+		}
+	})
+
+	t.Run("Equals", func(t *testing.T) {
+		if !dt.Equals(NewDatetime(dt.value.UTC())) {
+			t.Errorf("Equals() returned false for the same instant in a different time zone")
+		}
+		if dt.Equals(NewDatetime(dt.value.Add(time.Second))) {
+			t.Errorf("Equals() returned true for a different instant")
+		}
+		if dt.Equals(NewStr(dt.String())) {
+			t.Errorf("Equals() returned true for a str")
+		}
+	})
+
+	t.Run("HashBytes", func(t *testing.T) {
+		got, want := must(dt.HashBytes()), must(NewDatetime(dt.value.UTC()).HashBytes())
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("HashBytes() returned different bytes for the same instant: %v and %v", got, want)
+		}
+		if other := must(NewDatetime(dt.value.Add(time.Nanosecond)).HashBytes()); reflect.DeepEqual(got, other) {
+			t.Errorf("HashBytes() returned the same bytes for different instants")
+		}
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			args    []execute.Value
+			want    execute.Value
+			wantErr error
+		}{
+			{name: "year", want: NewInt(2024)},
+			{name: "month", want: NewInt(2)},
+			{name: "day", want: NewInt(29)},
+			{name: "hour", want: NewInt(7)},
+			{name: "minute", want: NewInt(30)},
+			{name: "second", want: NewInt(0)},
+			{name: "nanosecond", want: NewInt(250_000_000)},
+			{name: "weekday", want: NewInt(4)},
+			{name: "yearday", want: NewInt(60)},
+			{name: "unix", want: NewInt(1709209800)},
+			{name: "zone", want: NewStr("America/New_York")},
+			{name: "offset", want: NewDuration(-5 * time.Hour)},
+			{name: "utc", want: NewDatetime(time.Date(2024, 2, 29, 12, 30, 0, 250_000_000, time.UTC))},
+			{name: "year", args: []execute.Value{NewInt(1)}, wantErr: errors.CallError("datetime.year", 1, 0)},
+			{name: "format", args: []execute.Value{NewStr(time.DateTime)}, want: NewStr("2024-02-29 07:30:00")},
+			{name: "format", args: []execute.Value{NewInt(1)}, wantErr: errors.NewTypeError(IntType, StrType)},
+			{name: "format", wantErr: errors.CallError("datetime.format", 0, 1)},
+			{
+				name: "in_zone",
+				args: []execute.Value{NewStr("Asia/Tokyo")},
+				want: NewDatetime(time.Date(2024, 2, 29, 21, 30, 0, 250_000_000, mustLoadZone("Asia/Tokyo"))),
+			},
+			{name: "in_zone", args: []execute.Value{NewStr("Nowhere")}, wantErr: errors.NewValueError(`unknown time zone "Nowhere"`)},
+			{
+				name: "add_date",
+				args: []execute.Value{NewInt(1), NewInt(0), NewInt(0)},
+				want: NewDatetime(time.Date(2025, 3, 1, 7, 30, 0, 250_000_000, ny)),
+			},
+			{
+				name: "add_date",
+				args: []execute.Value{NewInt(0), NewUint(1), NewInt(-1)},
+				want: NewDatetime(time.Date(2024, 3, 28, 7, 30, 0, 250_000_000, ny)),
+			},
+			{name: "add_date", args: []execute.Value{NewInt(0), NewFloat(1), NewInt(0)}, wantErr: errors.NewTypeError(FloatType, IntType)},
+			{name: "add_date", args: []execute.Value{NewInt(0)}, wantErr: errors.CallError("datetime.add_date", 1, 3)},
+			{
+				name: "truncate",
+				args: []execute.Value{NewDuration(time.Hour)},
+				want: NewDatetime(time.Date(2024, 2, 29, 7, 0, 0, 0, ny)),
+			},
+			{name: "truncate", args: []execute.Value{NewStr("1h")}, wantErr: errors.NewTypeError(StrType, DurationType)},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				f := must(dt.GetAttribute(tc.name))
+				got, err := must(f.ToCallable()).Call(nil, tc.args...)
+				if diff := cmp.Diff(tc.wantErr, err, allowUnexported); diff != "" {
+					t.Errorf("%s() returned incorrect error (-want +got):\n%s", tc.name, diff)
+				}
+				if diff := cmp.Diff(tc.want, got, allowUnexported); diff != "" {
+					t.Errorf("%s() returned incorrect value (-want +got):\n%s", tc.name, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("HasAttribute", func(t *testing.T) {
+		if !dt.HasAttribute("year") {
+			t.Errorf("HasAttribute(%q) returned false", "year")
+		}
+		if dt.HasAttribute("foo") {
+			t.Errorf("HasAttribute(%q) returned true", "foo")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		want := "2024-02-29T07:30:00.25-05:00"
+		if got := dt.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+		if got := must(dt.ToStr()); got != want {
+			t.Errorf("ToStr() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("ToBool", func(t *testing.T) {
+		if !NewDatetime(time.Time{}).ToBool() {
+			t.Errorf("ToBool() returned false")
+		}
+	})
+
+	t.Run("ToFloat", func(t *testing.T) {
+		_, err := dt.ToFloat()
+		if diff := cmp.Diff(errors.NewTypeError(DatetimeType, FloatType), err, allowUnexported); diff != "" {
+			t.Errorf("ToFloat() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ToInt", func(t *testing.T) {
+		_, err := dt.ToInt()
+		if diff := cmp.Diff(errors.NewTypeError(DatetimeType, IntType), err, allowUnexported); diff != "" {
+			t.Errorf("ToInt() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		if got := dt.Type(); got != DatetimeType {
+			t.Errorf("Type() = %v, want %v", got, DatetimeType)
+		}
+	})
+}
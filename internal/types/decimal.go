@@ -0,0 +1,241 @@
+package types
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Type definition
+// -------------------------------------------------------------------------------------------------
+
+type decimalType struct{}
+
+func (t *decimalType) IsNumeric() bool {
+	return true
+}
+
+func (t *decimalType) New(v execute.Value) (execute.Value, error) {
+	vc, err := ToDecimal(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimal(vc), nil
+}
+
+func (t *decimalType) String() string {
+	return "decimal"
+}
+
+var DecimalType = &decimalType{}
+
+// -------------------------------------------------------------------------------------------------
+// Type implementation
+// -------------------------------------------------------------------------------------------------
+
+// Decimal is an exact decimal number.
+type Decimal struct {
+	value decimal.Decimal
+}
+
+func NewDecimal(v decimal.Decimal) *Decimal {
+	return &Decimal{v}
+}
+
+// Value returns the number that this Decimal represents.
+func (v *Decimal) Value() decimal.Decimal {
+	return v.value
+}
+
+var decimalMethods = map[string]func(*Decimal) execute.Value{
+	"round": func(v *Decimal) execute.Value {
+		name := "decimal.round"
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 2; got > want {
+				return nil, errors.CallError(name, got, want)
+			}
+			if got := len(vs); got == 0 {
+				return nil, errors.CallError(name, got, 1)
+			}
+			switch vs[0].(type) {
+			case *BigInt, *Bool, *Int, *Uint:
+			default:
+				return nil, errors.NewTypeError(vs[0].Type(), IntType)
+			}
+			places, err := CheckedNew(IntType, vs[0])
+			if err != nil {
+				return nil, err
+			}
+			var mode decimal.RoundingMode
+			if len(vs) == 2 {
+				s, ok := vs[1].(*Str)
+				if !ok {
+					return nil, errors.NewTypeError(vs[1].Type(), StrType)
+				}
+				if mode, err = decimal.ParseRoundingMode(s.value); err != nil {
+					return nil, errors.NewValueError(err.Error())
+				}
+			}
+			return NewDecimal(v.value.Round(int(must(places.ToInt())), mode)), nil
+		})
+	},
+}
+
+func (v *Decimal) CloneIfPrimitive() execute.Value {
+	return NewDecimal(v.value)
+}
+
+func (v *Decimal) CompareTo(o execute.Value) (int, bool) {
+	switch o.Type() {
+	case FloatType:
+		of := must(o.ToFloat())
+		if math.IsNaN(of) {
+			return compareNumbers(v.value.Float64(), of), true
+		}
+		if math.IsInf(of, 0) {
+			return -int(math.Copysign(1, of)), true
+		}
+		return v.value.Cmp(must(ToDecimal(o))), true
+	case BigIntType:
+		fallthrough
+	case BoolType:
+		fallthrough
+	case DecimalType:
+		fallthrough
+	case IntType:
+		fallthrough
+	case UintType:
+		return v.value.Cmp(must(ToDecimal(o))), true
+	}
+	return 0, false
+}
+
+func (v *Decimal) Equals(o execute.Value) bool {
+	od, ok := o.(*Decimal)
+	if !ok {
+		return false
+	}
+	return v.value.Cmp(od.value) == 0
+}
+
+func (v *Decimal) GetAttribute(a string) (execute.Value, error) {
+	if methodFactory, ok := decimalMethods[a]; ok {
+		return methodFactory(v), nil
+	}
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Decimal) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Decimal) HasAttribute(a string) bool {
+	_, ok := decimalMethods[a]
+	return ok
+}
+
+// HashBytes returns the bytes of the normalized form of this decimal, so that decimals that are equal
+// but written with different numbers of trailing zeros (e.g. 1.5d and 1.50d) have the same hash.
+func (v *Decimal) HashBytes() ([]byte, error) {
+	n := v.value.Normalize()
+	return fmt.Appendf(nil, "%se%d", n.Coefficient(), -n.Scale()), nil
+}
+
+func (v *Decimal) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *Decimal) SetAttribute(a string, _ execute.Value) error {
+	return errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Decimal) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Decimal) String() string {
+	return v.value.String() + "d"
+}
+
+func (v *Decimal) ToBool() bool {
+	return v.value.Sign() != 0
+}
+
+func (v *Decimal) ToBytes() ([]byte, error) {
+	return nil, errors.NewTypeError(v.Type(), BytesType)
+}
+
+func (v *Decimal) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), FuncType)
+}
+
+func (v *Decimal) ToFloat() (float64, error) {
+	return v.value.Float64(), nil
+}
+
+// ToInt returns the value of this decimal truncated to an integer.
+func (v *Decimal) ToInt() (int64, error) {
+	i := v.value.Int()
+	if !i.IsInt64() {
+		return 0, errors.ConversionOverflowError(v.value.String(), IntType)
+	}
+	return i.Int64(), nil
+}
+
+func (v *Decimal) ToIterator() (execute.Iterator, error) {
+	return nil, errors.NewTypeError(v.Type(), IteratorType)
+}
+
+func (v *Decimal) ToStr() (string, error) {
+	return v.value.String(), nil
+}
+
+// ToUint returns the value of this decimal truncated to an integer. Negative values that fit in an
+// int wrap around like ints do when they are converted to uints.
+func (v *Decimal) ToUint() (uint64, error) {
+	i := v.value.Int()
+	if i.IsUint64() {
+		return i.Uint64(), nil
+	}
+	if i.IsInt64() {
+		return uint64(i.Int64()), nil
+	}
+	return 0, errors.ConversionOverflowError(v.value.String(), UintType)
+}
+
+func (v *Decimal) Type() execute.Type {
+	return DecimalType
+}
+
+// -------------------------------------------------------------------------------------------------
+// Conversions
+// -------------------------------------------------------------------------------------------------
+
+// ToDecimal returns the value of v as a decimal. Floats are converted to the shortest decimal that
+// represents them (so 0.1 becomes 0.1d) and strings are parsed in plain or scientific notation.
+func ToDecimal(v execute.Value) (decimal.Decimal, error) {
+	switch v := v.(type) {
+	case *Decimal:
+		return v.value, nil
+	case *BigInt, *Bool, *Int, *Uint:
+		return decimal.FromInt(must(ToBigInt(v))), nil
+	case *Float:
+		d, ok := decimal.FromFloat(v.value)
+		if !ok {
+			return decimal.Decimal{}, errors.ConversionOverflowError(v.String(), DecimalType)
+		}
+		return d, nil
+	case *Str:
+		d, err := decimal.Parse(v.value)
+		if err != nil {
+			return decimal.Decimal{}, errors.WrapValueError(v.value, DecimalType, stderrors.New("invalid syntax"))
+		}
+		return d, nil
+	}
+	return decimal.Decimal{}, errors.NewTypeError(v.Type(), DecimalType)
+}
@@ -0,0 +1,257 @@
+package types
+
+import (
+	stderrors "errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	testhelpers "github.com/chrispyles/slow/internal/testing/helpers"
+	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newDecimal(s string) *Decimal {
+	return NewDecimal(decimal.MustParse(s))
+}
+
+func TestDecimalType(t *testing.T) {
+	tc := typestesting.TypeTestCase{
+		Type: DecimalType,
+		NewTestCases: []typestesting.NewTestCase{
+			{In: NewInt(-3), Want: newDecimal("-3")},
+			{In: NewUint(math.MaxUint64), Want: newDecimal("18446744073709551615")},
+			{In: NewBigInt(twoTo64), Want: newDecimal("18446744073709551616")},
+			{In: NewFloat(0.1), Want: newDecimal("0.1")},
+			{In: NewBool(true), Want: newDecimal("1")},
+			{In: NewStr("-12.50"), Want: newDecimal("-12.50")},
+			{In: NewStr("1.5e3"), Want: newDecimal("1.5e3")},
+			{In: newDecimal("2.25"), Want: newDecimal("2.25")},
+			{
+				In:      NewStr("1.5d"),
+				WantErr: errors.WrapValueError("1.5d", DecimalType, stderrors.New("invalid syntax")),
+			},
+			{
+				In:      NewFloat(math.NaN()),
+				WantErr: errors.ConversionOverflowError("NaN", DecimalType),
+			},
+			{
+				In:      NewBytes([]byte{0x01}),
+				WantErr: errors.NewTypeError(BytesType, DecimalType),
+			},
+		},
+		WantString:    "decimal",
+		WantIsNumeric: true,
+	}
+	tc.Run(t)
+}
+
+func TestDecimal(t *testing.T) {
+	t.Run("CloneIfPrimitive", func(t *testing.T) {
+		in := newDecimal("1.5")
+		got := in.CloneIfPrimitive().(*Decimal)
+		if reflect.ValueOf(in).Pointer() == reflect.ValueOf(got).Pointer() {
+			t.Errorf("CloneIfPrimitive() did not create a clone")
+		}
+		if !in.Equals(got) {
+			t.Errorf("CloneIfPrimitive() = %v, want %v", got, in)
+		}
+	})
+
+	t.Run("CompareTo", func(t *testing.T) {
+		for _, tc := range []struct {
+			in     *Decimal
+			other  execute.Value
+			want   int
+			wantOk bool
+		}{
+			{in: newDecimal("1.0"), other: newDecimal("1"), want: 0, wantOk: true},
+			{in: newDecimal("0.3"), other: NewFloat(0.3), want: 0, wantOk: true},
+			{in: newDecimal("0.3"), other: NewFloat(0.30000000000000004), want: -1, wantOk: true},
+			{in: newDecimal("1e100"), other: NewFloat(math.Inf(1)), want: -1, wantOk: true},
+			{in: newDecimal("-1e100"), other: NewFloat(math.Inf(-1)), want: 1, wantOk: true},
+			{in: newDecimal("2.5"), other: NewInt(2), want: 1, wantOk: true},
+			{in: newDecimal("2.5"), other: NewUint(3), want: -1, wantOk: true},
+			{in: newDecimal("18446744073709551616.5"), other: NewBigInt(twoTo64), want: 1, wantOk: true},
+			{in: newDecimal("1"), other: NewBool(true), want: 0, wantOk: true},
+			{in: newDecimal("1"), other: NewStr("1"), want: 0, wantOk: false},
+		} {
+			got, ok := tc.in.CompareTo(tc.other)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("%v.CompareTo(%v) = (%d, %v), want (%d, %v)", tc.in, tc.other, got, ok, tc.want, tc.wantOk)
+			}
+		}
+	})
+
+	t.Run("CompareTo_other_types", func(t *testing.T) {
+		for _, other := range []execute.Value{NewBigInt(big.NewInt(1)), NewBool(true), NewFloat(1), NewInt(1), NewUint(1)} {
+			got, ok := other.CompareTo(newDecimal("1.5"))
+			if got != -1 || !ok {
+				t.Errorf("%v.CompareTo(1.5d) = (%d, %v), want (-1, true)", other, got, ok)
+			}
+		}
+	})
+
+	t.Run("Equals", func(t *testing.T) {
+		v := newDecimal("1.50")
+		if !v.Equals(newDecimal("1.5")) {
+			t.Errorf("Equals() returned false for an equal decimal")
+		}
+		if v.Equals(newDecimal("1.51")) {
+			t.Errorf("Equals() returned true for an unequal decimal")
+		}
+		if v.Equals(NewFloat(1.5)) {
+			t.Errorf("Equals() returned true for a float")
+		}
+	})
+
+	t.Run("HashBytes", func(t *testing.T) {
+		for _, tc := range []struct {
+			in   string
+			want string
+		}{
+			{in: "1.50", want: "15e-1"},
+			{in: "1.5", want: "15e-1"},
+			{in: "1200", want: "12e2"},
+			{in: "0.00", want: "0e0"},
+		} {
+			if got := string(must(newDecimal(tc.in).HashBytes())); got != tc.want {
+				t.Errorf("%sd.HashBytes() = %q, want %q", tc.in, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("round", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			args    []execute.Value
+			want    execute.Value
+			wantErr error
+		}{
+			{name: "default_mode", args: []execute.Value{NewInt(2)}, want: newDecimal("2.34")},
+			{name: "mode", args: []execute.Value{NewUint(2), NewStr("half_up")}, want: newDecimal("2.35")},
+			{name: "negative_places", args: []execute.Value{NewInt(-1)}, want: newDecimal("0")},
+			{name: "no_args", wantErr: errors.CallError("decimal.round", 0, 1)},
+			{
+				name:    "too_many_args",
+				args:    []execute.Value{NewInt(1), NewStr("up"), NewStr("up")},
+				wantErr: errors.CallError("decimal.round", 3, 2),
+			},
+			{
+				name:    "float_places",
+				args:    []execute.Value{NewFloat(2)},
+				wantErr: errors.NewTypeError(FloatType, IntType),
+			},
+			{
+				name:    "non_str_mode",
+				args:    []execute.Value{NewInt(2), NewInt(1)},
+				wantErr: errors.NewTypeError(IntType, StrType),
+			},
+			{
+				name:    "unknown_mode",
+				args:    []execute.Value{NewInt(2), NewStr("nearest")},
+				wantErr: errors.NewValueError(`unknown rounding mode "nearest"`),
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				f := must(newDecimal("2.345").GetAttribute("round"))
+				got, err := must(f.ToCallable()).Call(nil, tc.args...)
+				if diff := cmp.Diff(tc.wantErr, err, allowUnexported); diff != "" {
+					t.Errorf("round() returned incorrect error (-want +got):\n%s", diff)
+				}
+				if tc.want != nil && !tc.want.Equals(got) {
+					t.Errorf("round() = %v, want %v", got, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("HasAttribute", func(t *testing.T) {
+		if !newDecimal("1").HasAttribute("round") {
+			t.Errorf("HasAttribute(%q) returned false", "round")
+		}
+		if newDecimal("1").HasAttribute("foo") {
+			t.Errorf("HasAttribute(%q) returned true", "foo")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got, want := newDecimal("-0.050").String(), "-0.050d"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+		if got, want := must(newDecimal("1.5e2").ToStr()), "150"; got != want {
+			t.Errorf("ToStr() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("ToBool", func(t *testing.T) {
+		if newDecimal("0.00").ToBool() {
+			t.Errorf("ToBool() returned true for 0.00")
+		}
+		if !newDecimal("1e-400").ToBool() {
+			t.Errorf("ToBool() returned false for 1e-400")
+		}
+	})
+
+	t.Run("ToBytes", func(t *testing.T) {
+		_, err := newDecimal("1").ToBytes()
+		if diff := cmp.Diff(errors.NewTypeError(DecimalType, BytesType), err, allowUnexported); diff != "" {
+			t.Errorf("ToBytes() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ToFloat", func(t *testing.T) {
+		if got, want := must(newDecimal("0.1").ToFloat()), 0.1; got != want {
+			t.Errorf("ToFloat() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ToInt", func(t *testing.T) {
+		if got := must(newDecimal("-2.9").ToInt()); got != -2 {
+			t.Errorf("ToInt() = %d, want -2", got)
+		}
+		_, err := newDecimal("1e19").ToInt()
+		want := errors.ConversionOverflowError("10000000000000000000", IntType)
+		if diff := cmp.Diff(want, err, allowUnexported); diff != "" {
+			t.Errorf("ToInt() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ToUint", func(t *testing.T) {
+		if got := must(newDecimal("2.9").ToUint()); got != 2 {
+			t.Errorf("ToUint() = %d, want 2", got)
+		}
+		// Negative decimals wrap around like negative ints.
+		if got := must(newDecimal("-1.5").ToUint()); got != math.MaxUint64 {
+			t.Errorf("ToUint() = %d, want %d", got, uint64(math.MaxUint64))
+		}
+		_, err := newDecimal("-1e19").ToUint()
+		want := errors.ConversionOverflowError("-10000000000000000000", UintType)
+		if diff := cmp.Diff(want, err, allowUnexported); diff != "" {
+			t.Errorf("ToUint() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ToBigInt", func(t *testing.T) {
+		testhelpers.CheckDiff(t, "ToBigInt()", big.NewInt(-12), must(ToBigInt(newDecimal("-12.99"))), typestesting.EquateBigInts())
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		if got := newDecimal("1").Type(); got != DecimalType {
+			t.Errorf("Type() = %v, want %v", got, DecimalType)
+		}
+	})
+}
+
+func TestCommonNumericType_decimal(t *testing.T) {
+	for _, other := range []execute.Type{DecimalType, FloatType, BigIntType, IntType, UintType, BoolType} {
+		got, ok := CommonNumericType(DecimalType, other)
+		if got != DecimalType || !ok {
+			t.Errorf("CommonNumericType(decimal, %v) = (%v, %v), want (decimal, true)", other, got, ok)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package types
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+)
+
+// -------------------------------------------------------------------------------------------------
+// Type definition
+// -------------------------------------------------------------------------------------------------
+
+type durationType struct{}
+
+func (t *durationType) IsNumeric() bool {
+	return false
+}
+
+// New converts v to a duration. Strings are parsed as Go durations (e.g. "1h30m") and numbers are
+// treated as a number of seconds.
+func (t *durationType) New(v execute.Value) (execute.Value, error) {
+	switch v := v.(type) {
+	case *Duration:
+		return NewDuration(v.value), nil
+	case *Str:
+		d, err := time.ParseDuration(v.value)
+		if err != nil {
+			return nil, errors.WrapValueError(v.value, t, err)
+		}
+		return NewDuration(d), nil
+	case *Float:
+		if math.IsNaN(v.value) || math.IsInf(v.value, 0) {
+			return nil, errors.ConversionOverflowError(v.String(), t)
+		}
+	}
+	if _, numeric := typeHierarchy[v.Type()]; !numeric {
+		return nil, errors.NewTypeError(v.Type(), t)
+	}
+	secs := must(ToDecimal(v))
+	ns := secs.Mul(decimal.FromInt(big.NewInt(int64(time.Second)))).Round(0, decimal.HalfEven).Int()
+	if !ns.IsInt64() {
+		return nil, errors.ConversionOverflowError(v.String(), t)
+	}
+	return NewDuration(time.Duration(ns.Int64())), nil
+}
+
+func (t *durationType) String() string {
+	return "duration"
+}
+
+var DurationType = &durationType{}
+
+// -------------------------------------------------------------------------------------------------
+// Type implementation
+// -------------------------------------------------------------------------------------------------
+
+// Duration is an amount of time with nanosecond precision.
+type Duration struct {
+	value time.Duration
+}
+
+func NewDuration(v time.Duration) *Duration {
+	return &Duration{v}
+}
+
+// Value returns the amount of time that this Duration represents.
+func (v *Duration) Value() time.Duration {
+	return v.value
+}
+
+// durationUnit returns a method that returns the duration in the provided unit.
+func durationUnit(name string, unit func(time.Duration) execute.Value) func(*Duration) execute.Value {
+	return func(v *Duration) execute.Value {
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 0; got != want {
+				return nil, errors.CallError(name, got, want)
+			}
+			return unit(v.value), nil
+		})
+	}
+}
+
+var durationMethods = map[string]func(*Duration) execute.Value{
+	"hours": durationUnit("duration.hours", func(d time.Duration) execute.Value {
+		return NewFloat(d.Hours())
+	}),
+	"minutes": durationUnit("duration.minutes", func(d time.Duration) execute.Value {
+		return NewFloat(d.Minutes())
+	}),
+	"seconds": durationUnit("duration.seconds", func(d time.Duration) execute.Value {
+		return NewFloat(d.Seconds())
+	}),
+	"milliseconds": durationUnit("duration.milliseconds", func(d time.Duration) execute.Value {
+		return NewInt(d.Milliseconds())
+	}),
+	"microseconds": durationUnit("duration.microseconds", func(d time.Duration) execute.Value {
+		return NewInt(d.Microseconds())
+	}),
+	"nanoseconds": durationUnit("duration.nanoseconds", func(d time.Duration) execute.Value {
+		return NewInt(d.Nanoseconds())
+	}),
+	"truncate": func(v *Duration) execute.Value {
+		name := "duration.truncate"
+		return NewGoFunc(name, func(vs ...execute.Value) (execute.Value, error) {
+			if got, want := len(vs), 1; got != want {
+				return nil, errors.CallError(name, got, want)
+			}
+			m, ok := vs[0].(*Duration)
+			if !ok {
+				return nil, errors.NewTypeError(vs[0].Type(), DurationType)
+			}
+			return NewDuration(v.value.Truncate(m.value)), nil
+		})
+	},
+}
+
+func (v *Duration) CloneIfPrimitive() execute.Value {
+	return NewDuration(v.value)
+}
+
+func (v *Duration) CompareTo(o execute.Value) (int, bool) {
+	od, ok := o.(*Duration)
+	if !ok {
+		return 0, false
+	}
+	return compareNumbers(int64(v.value), int64(od.value)), true
+}
+
+func (v *Duration) Equals(o execute.Value) bool {
+	od, ok := o.(*Duration)
+	if !ok {
+		return false
+	}
+	return v.value == od.value
+}
+
+func (v *Duration) GetAttribute(a string) (execute.Value, error) {
+	if methodFactory, ok := durationMethods[a]; ok {
+		return methodFactory(v), nil
+	}
+	return nil, errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Duration) GetIndex(execute.Value) (execute.Value, error) {
+	return nil, errors.IndexingNotSupported(v.Type())
+}
+
+func (v *Duration) HasAttribute(a string) bool {
+	_, ok := durationMethods[a]
+	return ok
+}
+
+func (v *Duration) HashBytes() ([]byte, error) {
+	return numToBytes(int64(v.value)), nil
+}
+
+func (v *Duration) Length() (uint64, error) {
+	return 0, errors.NoLengthError(v.Type())
+}
+
+func (v *Duration) SetAttribute(a string, _ execute.Value) error {
+	return errors.NewAttributeError(v.Type(), a)
+}
+
+func (v *Duration) SetIndex(execute.Value, execute.Value) error {
+	return errors.IndexingNotSupported(v.Type())
+}
+
+// String returns the duration formatted like Go durations, e.g. 1h30m0s.
+func (v *Duration) String() string {
+	return v.value.String()
+}
+
+func (v *Duration) ToBool() bool {
+	return v.value != 0
+}
+
+func (v *Duration) ToBytes() ([]byte, error) {
+	return nil, errors.NewTypeError(v.Type(), BytesType)
+}
+
+func (v *Duration) ToCallable() (execute.Callable, error) {
+	return nil, errors.NewTypeError(v.Type(), FuncType)
+}
+
+// ToFloat returns the number of seconds in this duration.
+func (v *Duration) ToFloat() (float64, error) {
+	return v.value.Seconds(), nil
+}
+
+// ToInt returns the number of whole seconds in this duration.
+func (v *Duration) ToInt() (int64, error) {
+	return int64(v.value / time.Second), nil
+}
+
+func (v *Duration) ToIterator() (execute.Iterator, error) {
+	return nil, errors.NewTypeError(v.Type(), IteratorType)
+}
+
+func (v *Duration) ToStr() (string, error) {
+	return v.value.String(), nil
+}
+
+// ToUint returns the number of whole seconds in this duration.
+func (v *Duration) ToUint() (uint64, error) {
+	if v.value < 0 {
+		return 0, errors.ConversionOverflowError(v.String(), UintType)
+	}
+	return uint64(v.value / time.Second), nil
+}
+
+func (v *Duration) Type() execute.Type {
+	return DurationType
+}
@@ -0,0 +1,206 @@
+package types
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDurationType(t *testing.T) {
+	tc := typestesting.TypeTestCase{
+		Type: DurationType,
+		NewTestCases: []typestesting.NewTestCase{
+			{In: NewStr("1h30m"), Want: NewDuration(90 * time.Minute)},
+			{In: NewStr("-1.5us"), Want: NewDuration(-1500 * time.Nanosecond)},
+			{In: NewInt(90), Want: NewDuration(90 * time.Second)},
+			{In: NewUint(2), Want: NewDuration(2 * time.Second)},
+			{In: NewFloat(0.25), Want: NewDuration(250 * time.Millisecond)},
+			{In: NewFloat(1e-10), Want: NewDuration(0)},
+			{In: newDecimal("0.0000000015"), Want: NewDuration(2 * time.Nanosecond)},
+			{In: NewBool(true), Want: NewDuration(time.Second)},
+			{In: NewDuration(time.Hour), Want: NewDuration(time.Hour)},
+			{
+				In:      NewStr("1 hour"),
+				WantErr: errors.WrapValueError("1 hour", DurationType, stringError(`time: unknown unit " hour" in duration "1 hour"`)),
+			},
+			{
+				In:      NewInt(math.MaxInt64),
+				WantErr: errors.ConversionOverflowError("9223372036854775807", DurationType),
+			},
+			{
+				In:      NewFloat(math.Inf(1)),
+				WantErr: errors.ConversionOverflowError("+Inf", DurationType),
+			},
+			{
+				In:      NewBytes([]byte{0x01}),
+				WantErr: errors.NewTypeError(BytesType, DurationType),
+			},
+			{
+				In:      NewDatetime(time.Unix(0, 0)),
+				WantErr: errors.NewTypeError(DatetimeType, DurationType),
+			},
+		},
+		WantString:    "duration",
+		WantIsNumeric: false,
+	}
+	tc.Run(t)
+}
+
+// stringError is an error with a fixed message.
+type stringError string
+
+func (e stringError) Error() string {
+	return string(e)
+}
+
+func TestDuration(t *testing.T) {
+	t.Run("CloneIfPrimitive", func(t *testing.T) {
+		in := NewDuration(time.Second)
+		got := in.CloneIfPrimitive().(*Duration)
+		if reflect.ValueOf(in).Pointer() == reflect.ValueOf(got).Pointer() {
+			t.Errorf("CloneIfPrimitive() did not create a clone")
+		}
+		if !in.Equals(got) {
+			t.Errorf("CloneIfPrimitive() = %v, want %v", got, in)
+		}
+	})
+
+	t.Run("CompareTo", func(t *testing.T) {
+		for _, tc := range []struct {
+			in     *Duration
+			other  execute.Value
+			want   int
+			wantOk bool
+		}{
+			{in: NewDuration(time.Second), other: NewDuration(time.Second), want: 0, wantOk: true},
+			{in: NewDuration(time.Second), other: NewDuration(time.Minute), want: -1, wantOk: true},
+			{in: NewDuration(time.Second), other: NewDuration(-time.Minute), want: 1, wantOk: true},
+			{in: NewDuration(time.Second), other: NewInt(1), want: 0, wantOk: false},
+		} {
+			got, ok := tc.in.CompareTo(tc.other)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("%v.CompareTo(%v) = (%d, %v), want (%d, %v)", tc.in, tc.other, got, ok, tc.want, tc.wantOk)
+			}
+		}
+	})
+
+	t.Run("Equals", func(t *testing.T) {
+		v := NewDuration(time.Minute)
+		if !v.Equals(NewDuration(60 * time.Second)) {
+			t.Errorf("Equals() returned false for an equal duration")
+		}
+		if v.Equals(NewDuration(time.Second)) {
+			t.Errorf("Equals() returned true for an unequal duration")
+		}
+		if v.Equals(NewInt(60)) {
+			t.Errorf("Equals() returned true for an int")
+		}
+	})
+
+	t.Run("HashBytes", func(t *testing.T) {
+		if got, want := must(NewDuration(time.Minute).HashBytes()), must(NewDuration(60*time.Second).HashBytes()); !reflect.DeepEqual(got, want) {
+			t.Errorf("HashBytes() returned different bytes for equal durations: %v and %v", got, want)
+		}
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		d := NewDuration(90*time.Minute + 1500*time.Microsecond)
+		for _, tc := range []struct {
+			name    string
+			args    []execute.Value
+			want    execute.Value
+			wantErr error
+		}{
+			{name: "hours", want: NewFloat(d.value.Hours())},
+			{name: "minutes", want: NewFloat(d.value.Minutes())},
+			{name: "seconds", want: NewFloat(5400.0015)},
+			{name: "milliseconds", want: NewInt(5400001)},
+			{name: "microseconds", want: NewInt(5400001500)},
+			{name: "nanoseconds", want: NewInt(5400001500000)},
+			{name: "truncate", args: []execute.Value{NewDuration(time.Hour)}, want: NewDuration(time.Hour)},
+			{name: "hours", args: []execute.Value{NewInt(1)}, wantErr: errors.CallError("duration.hours", 1, 0)},
+			{name: "truncate", wantErr: errors.CallError("duration.truncate", 0, 1)},
+			{name: "truncate", args: []execute.Value{NewInt(1)}, wantErr: errors.NewTypeError(IntType, DurationType)},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				f := must(d.GetAttribute(tc.name))
+				got, err := must(f.ToCallable()).Call(nil, tc.args...)
+				if diff := cmp.Diff(tc.wantErr, err, allowUnexported); diff != "" {
+					t.Errorf("%s() returned incorrect error (-want +got):\n%s", tc.name, diff)
+				}
+				if diff := cmp.Diff(tc.want, got, allowUnexported); diff != "" {
+					t.Errorf("%s() returned incorrect value (-want +got):\n%s", tc.name, diff)
+				}
+			})
+		}
+	})
+
+	t.Run("HasAttribute", func(t *testing.T) {
+		if !NewDuration(0).HasAttribute("seconds") {
+			t.Errorf("HasAttribute(%q) returned false", "seconds")
+		}
+		if NewDuration(0).HasAttribute("foo") {
+			t.Errorf("HasAttribute(%q) returned true", "foo")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got, want := NewDuration(-90*time.Minute).String(), "-1h30m0s"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+		if got, want := must(NewDuration(1500*time.Millisecond).ToStr()), "1.5s"; got != want {
+			t.Errorf("ToStr() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("ToBool", func(t *testing.T) {
+		if NewDuration(0).ToBool() {
+			t.Errorf("ToBool() returned true for 0s")
+		}
+		if !NewDuration(-1).ToBool() {
+			t.Errorf("ToBool() returned false for -1ns")
+		}
+	})
+
+	t.Run("ToFloat", func(t *testing.T) {
+		if got, want := must(NewDuration(1500*time.Millisecond).ToFloat()), 1.5; got != want {
+			t.Errorf("ToFloat() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ToInt", func(t *testing.T) {
+		if got := must(NewDuration(-2900 * time.Millisecond).ToInt()); got != -2 {
+			t.Errorf("ToInt() = %d, want -2", got)
+		}
+	})
+
+	t.Run("ToUint", func(t *testing.T) {
+		if got := must(NewDuration(2900 * time.Millisecond).ToUint()); got != 2 {
+			t.Errorf("ToUint() = %d, want 2", got)
+		}
+		_, err := NewDuration(-time.Second).ToUint()
+		want := errors.ConversionOverflowError("-1s", UintType)
+		if diff := cmp.Diff(want, err, allowUnexported); diff != "" {
+			t.Errorf("ToUint() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ToBigInt", func(t *testing.T) {
+		_, err := ToBigInt(NewDuration(time.Second))
+		if diff := cmp.Diff(errors.NewTypeError(DurationType, BigIntType), err, allowUnexported); diff != "" {
+			t.Errorf("ToBigInt() returned incorrect error (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		if got := NewDuration(0).Type(); got != DurationType {
+			t.Errorf("Type() = %v, want %v", got, DurationType)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
@@ -50,6 +51,11 @@ func (v *Float) CloneIfPrimitive() execute.Value {
 
 func (v *Float) CompareTo(o execute.Value) (int, bool) {
 	switch o.Type() {
+	case BigIntType:
+		fallthrough
+	case DecimalType:
+		c, _ := o.CompareTo(v)
+		return -c, true
 	case BoolType:
 		fallthrough
 	case FloatType:
@@ -106,7 +112,9 @@ func (v *Float) SetAttribute(a string, _ execute.Value) error {
 
 func (v *Float) String() string {
 	out := fmt.Sprint(v.value)
-	if v.value == math.Trunc(v.value) {
+	// Whole numbers are given a decimal point so that they aren't mistaken for ints, unless they are
+	// already written with an exponent or are infinite.
+	if v.value == math.Trunc(v.value) && !strings.ContainsAny(out, "eI") {
 		out += ".0"
 	}
 	return out
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math"
 	"testing"
 
 	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
@@ -58,7 +59,22 @@ func TestFloat(t *testing.T) {
 	})
 
 	t.Run("String", func(t *testing.T) {
-		// TODO
+		tests := []struct {
+			val  float64
+			want string
+		}{
+			{val: 1.5, want: "1.5"},
+			{val: 2, want: "2.0"},
+			{val: -3, want: "-3.0"},
+			{val: 2e21, want: "2e+21"},
+			{val: 1e-9, want: "1e-09"},
+			{val: math.Inf(1), want: "+Inf"},
+		}
+		for _, tc := range tests {
+			if got := NewFloat(tc.val).String(); got != tc.want {
+				t.Errorf("NewFloat(%v).String() = %q, want %q", tc.val, got, tc.want)
+			}
+		}
 	})
 
 	t.Run("ToBool", func(t *testing.T) {
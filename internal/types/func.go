@@ -47,16 +47,32 @@ func (*DeferError) Error() string { return "" }
 // FuncImpl is a function whose logic is implemented in Go, for builtins.
 type FuncImpl func(...execute.Value) (execute.Value, error)
 
-type Func struct {
-	name string
-	args []string
-	body execute.Block
-	impl FuncImpl
-}
+// EnvFuncImpl is a function whose logic is implemented in Go and that needs the environment it is
+// called from, e.g. to call a user-defined function that is passed to it.
+type EnvFuncImpl func(*execute.Environment, ...execute.Value) (execute.Value, error)
 
-// NewFunc creates a new types.Func for a user-defined function.
-func NewFunc(name string, args []string, body execute.Block) *Func {
-	return &Func{name: name, args: args, body: body}
+type Func struct {
+	name    string
+	args    []string
+	body    execute.Block
+	impl    FuncImpl
+	envImpl EnvFuncImpl
+	// scope is the environment that a user-defined function was declared in.
+	scope *execute.Environment
+	// file is the path to the file that a user-defined function was declared in, or an empty string
+	// if it wasn't declared in a file (e.g. in the REPL).
+	file string
+}
+
+// NewFunc creates a new types.Func for a user-defined function that was declared in the environment
+// scope of the file at the provided path. The function's body is executed in a new frame of scope,
+// so it can use the variables that were visible where it was declared (e.g. the private globals of
+// the module that declared it) but not those of its caller. If scope is nil, the body is executed in
+// the frame that the function is called with. While the function is executing, its file is the
+// current file of the runtime, so that relative imports in its body are resolved against the
+// directory containing it.
+func NewFunc(name string, args []string, body execute.Block, scope *execute.Environment, file string) *Func {
+	return &Func{name: name, args: args, body: body, scope: scope, file: file}
 }
 
 // NewGoFunc creates a new types.Func for a builtin funtion, whose logic is implemented in Go.
@@ -64,13 +80,43 @@ func NewGoFunc(name string, impl FuncImpl) *Func {
 	return &Func{name: name, impl: impl}
 }
 
+// NewEnvGoFunc creates a new types.Func for a builtin function whose logic is implemented in Go and
+// is passed the environment that it is called from.
+func NewEnvGoFunc(name string, impl EnvFuncImpl) *Func {
+	return &Func{name: name, envImpl: impl}
+}
+
 func (v *Func) Call(env *execute.Environment, args ...execute.Value) (execute.Value, error) {
 	if v.impl != nil {
 		return v.impl(args...)
 	}
+	if v.envImpl != nil {
+		return v.envImpl(env, args...)
+	}
 	if got, want := len(args), len(v.args); got != want {
 		return nil, errors.CallError(v.name, got, want)
 	}
+	if v.scope != nil {
+		env = v.scope.NewFrame()
+	}
+	rt := env.Runtime()
+	if err := rt.PushCall(v.name); err != nil {
+		return nil, err
+	}
+	defer rt.PopCall()
+	if v.file != "" {
+		rt.PushFile(v.file)
+		defer rt.PopFile()
+	}
+	val, err := v.callBody(env, args)
+	if err != nil {
+		return nil, errors.AddFrame(err, v.name)
+	}
+	return val, nil
+}
+
+// callBody executes the body of a user-defined function with the provided arguments.
+func (v *Func) callBody(env *execute.Environment, args []execute.Value) (execute.Value, error) {
 	var deferrals []execute.Expression
 	var retValue execute.Value
 	for i := range v.args {
@@ -92,14 +138,14 @@ func (v *Func) Call(env *execute.Environment, args ...execute.Value) (execute.Va
 				deferrals = append(deferrals, de.Expr)
 				continue
 			}
+			// Deferred calls also run when the function throws an error (e.g. so that files are
+			// closed), but the function's error takes precedence over theirs.
+			runDeferrals(env, deferrals)
 			return nil, err
 		}
 	}
-	for _, expr := range deferrals {
-		_, err := expr.Execute(env)
-		if err != nil {
-			return nil, err
-		}
+	if err := runDeferrals(env, deferrals); err != nil {
+		return nil, err
 	}
 	if retValue != nil {
 		return retValue, nil
@@ -108,6 +154,18 @@ func (v *Func) Call(env *execute.Environment, args ...execute.Value) (execute.Va
 	return Null, nil
 }
 
+// runDeferrals executes each deferred expression, even if an earlier one throws an error, and
+// returns the first error that is thrown.
+func runDeferrals(env *execute.Environment, deferrals []execute.Expression) error {
+	var firstErr error
+	for _, expr := range deferrals {
+		if _, err := expr.Execute(env); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 func (v *Func) CloneIfPrimitive() execute.Value {
 	return v
 }
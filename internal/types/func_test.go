@@ -1,9 +1,13 @@
 package types
 
 import (
+	stderrors "errors"
 	"testing"
 
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
 	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestFuncType(t *testing.T) {
@@ -101,3 +105,162 @@ func TestFunc(t *testing.T) {
 		// TODO
 	})
 }
+
+// recordingExpr is an expression that records its name when it is executed and returns err.
+type recordingExpr struct {
+	name string
+	log  *[]string
+	err  error
+}
+
+func (e *recordingExpr) Execute(*execute.Environment) (execute.Value, error) {
+	*e.log = append(*e.log, e.name)
+	return Null, e.err
+}
+
+// deferExpr is an expression that defers expr, like a defer statement.
+type deferExpr struct {
+	expr execute.Expression
+}
+
+func (e *deferExpr) Execute(*execute.Environment) (execute.Value, error) {
+	return nil, &DeferError{Expr: e.expr}
+}
+
+func TestFunc_Call_defer(t *testing.T) {
+	bodyErr, deferErr := errors.NewValueError("body"), errors.NewValueError("deferred")
+	tests := []struct {
+		name    string
+		body    func(log *[]string) execute.Block
+		wantLog []string
+		wantErr error
+	}{
+		{
+			name: "success",
+			body: func(log *[]string) execute.Block {
+				return execute.Block{
+					&deferExpr{&recordingExpr{name: "a", log: log}},
+					&recordingExpr{name: "b", log: log},
+					&deferExpr{&recordingExpr{name: "c", log: log}},
+				}
+			},
+			wantLog: []string{"b", "a", "c"},
+		},
+		{
+			name: "body_error",
+			body: func(log *[]string) execute.Block {
+				return execute.Block{
+					&deferExpr{&recordingExpr{name: "a", log: log, err: deferErr}},
+					&recordingExpr{name: "b", log: log, err: bodyErr},
+					&deferExpr{&recordingExpr{name: "c", log: log}},
+				}
+			},
+			wantLog: []string{"b", "a"},
+			wantErr: bodyErr,
+		},
+		{
+			name: "deferral_error",
+			body: func(log *[]string) execute.Block {
+				return execute.Block{
+					&deferExpr{&recordingExpr{name: "a", log: log, err: deferErr}},
+					&deferExpr{&recordingExpr{name: "b", log: log}},
+				}
+			},
+			wantLog: []string{"a", "b"},
+			wantErr: deferErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var log []string
+			f := NewFunc("f", nil, tc.body(&log), nil, "")
+			_, err := f.Call(execute.NewEnvironment())
+			if !stderrors.Is(err, tc.wantErr) {
+				t.Errorf("Call() returned incorrect error: got %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantLog, log); diff != "" {
+				t.Errorf("Call() executed expressions incorrectly (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFunc_Call_envGoFunc(t *testing.T) {
+	env := execute.NewEnvironment()
+	var gotEnv *execute.Environment
+	f := NewEnvGoFunc("f", func(e *execute.Environment, vs ...execute.Value) (execute.Value, error) {
+		gotEnv = e
+		return NewInt(int64(len(vs))), nil
+	})
+	got, err := f.Call(env, Null, Null)
+	if err != nil {
+		t.Fatalf("Call() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(NewInt(2), got, allowUnexported); diff != "" {
+		t.Errorf("Call() returned incorrect value (-want +got):\n%s", diff)
+	}
+	if gotEnv != env {
+		t.Errorf("Call() did not pass the calling environment to the function")
+	}
+}
+
+// returnVarExpr is an expression that returns the value of a variable, like a return statement.
+type returnVarExpr struct {
+	name string
+}
+
+func (e *returnVarExpr) Execute(env *execute.Environment) (execute.Value, error) {
+	v, err := env.Get(e.name)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &ReturnError{Value: v}
+}
+
+func TestFunc_Call_scope(t *testing.T) {
+	scope := execute.NewEnvironment()
+	scope.Declare("x")
+	scope.Set("x", NewInt(1))
+	caller := execute.NewEnvironment()
+	for name, v := range map[string]execute.Value{"x": NewInt(2), "y": NewInt(3)} {
+		caller.Declare(name)
+		caller.Set(name, v)
+	}
+	tests := []struct {
+		name    string
+		scope   *execute.Environment
+		varName string
+		want    execute.Value
+		wantErr error
+	}{
+		{
+			name:    "declaring_scope",
+			scope:   scope,
+			varName: "x",
+			want:    NewInt(1),
+		},
+		{
+			name:    "caller_not_visible",
+			scope:   scope,
+			varName: "y",
+			wantErr: errors.AddFrame(errors.NewNameError("y"), "f"),
+		},
+		{
+			name:    "no_scope",
+			varName: "x",
+			want:    NewInt(2),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := NewFunc("f", nil, execute.Block{&returnVarExpr{tc.varName}}, tc.scope, "")
+			got, err := f.Call(caller.NewFrame())
+			if diff := cmp.Diff(tc.wantErr, err, allowUnexported); diff != "" {
+				t.Errorf("Call() returned incorrect error (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got, allowUnexported); diff != "" {
+				t.Errorf("Call() returned incorrect value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -49,6 +49,11 @@ func (v *Int) CloneIfPrimitive() execute.Value {
 
 func (v *Int) CompareTo(o execute.Value) (int, bool) {
 	switch o.Type() {
+	case BigIntType:
+		fallthrough
+	case DecimalType:
+		c, _ := o.CompareTo(v)
+		return -c, true
 	case FloatType:
 		return compareNumbers(float64(v.value), must(o.ToFloat())), true
 	case BoolType:
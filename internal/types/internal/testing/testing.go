@@ -2,6 +2,7 @@ package testing
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -9,6 +10,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 )
 
+// EquateBigInts returns an option that compares big.Ints by value.
+func EquateBigInts() cmp.Option {
+	return cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 })
+}
+
 type NewTestCase struct {
 	In      execute.Value
 	Want    execute.Value
@@ -46,7 +52,7 @@ func (tc *TypeTestCase) Run(t *testing.T) {
 						types = append(types, rv.Elem().Interface())
 					}
 				}
-				if diff := cmp.Diff(ntc.Want, got, cmp.AllowUnexported(types...)); diff != "" {
+				if diff := cmp.Diff(ntc.Want, got, cmp.AllowUnexported(types...), EquateBigInts()); diff != "" {
 					t.Errorf("New returned incorrect value (-want +got):\n%s", diff)
 				}
 			})
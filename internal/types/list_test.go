@@ -1,8 +1,14 @@
 package types
 
 import (
+	"fmt"
+	"math"
+	"math/big"
 	"testing"
 
+	"github.com/chrispyles/slow/internal/errors"
+	"github.com/chrispyles/slow/internal/execute"
+	testhelpers "github.com/chrispyles/slow/internal/testing/helpers"
 	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
 )
 
@@ -34,7 +40,87 @@ func TestList(t *testing.T) {
 	})
 
 	t.Run("GetIndex", func(t *testing.T) {
-		// TODO
+		v := NewList([]execute.Value{NewInt(1), NewInt(2), NewInt(3)})
+		mustRange := func(start, stop, step execute.Value) execute.Value {
+			rg, err := NewRangeGenerator(start, stop, step)
+			if err != nil {
+				t.Fatalf("NewRangeGenerator() returned unexpected error: %v", err)
+			}
+			return rg
+		}
+		for _, tc := range []struct {
+			name    string
+			idx     execute.Value
+			want    execute.Value
+			wantErr error
+		}{
+			{
+				name: "int",
+				idx:  NewInt(-1),
+				want: NewInt(3),
+			},
+			{
+				name: "bigint",
+				idx:  NewBigInt(big.NewInt(1)),
+				want: NewInt(2),
+			},
+			{
+				name:    "bigint_out_of_range",
+				idx:     NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64)),
+				wantErr: errors.NewIndexError(fmt.Sprintf("%d", math.MaxInt)),
+			},
+			{
+				name: "slice",
+				idx:  mustRange(NewInt(0), NewInt(2), nil),
+				want: NewList([]execute.Value{NewInt(1), NewInt(2)}),
+			},
+			{
+				name: "bigint_slice",
+				idx:  mustRange(NewBigInt(big.NewInt(0)), NewBigInt(big.NewInt(2)), nil),
+				want: NewList([]execute.Value{NewInt(1), NewInt(2)}),
+			},
+			{
+				name: "bigint_slice_to_end",
+				idx:  mustRange(NewBigInt(big.NewInt(1)), nil, nil),
+				want: NewList([]execute.Value{NewInt(2), NewInt(3)}),
+			},
+			{
+				name: "bigint_slice_reversed",
+				idx:  mustRange(nil, NewBigInt(big.NewInt(0)), NewBigInt(big.NewInt(-1))),
+				want: NewList([]execute.Value{NewInt(3), NewInt(2)}),
+			},
+			{
+				name: "decimal",
+				idx:  newDecimal("-2.0"),
+				want: NewInt(2),
+			},
+			{
+				name:    "decimal_with_fraction",
+				idx:     newDecimal("1.5"),
+				wantErr: errors.NewIndexError("1.5d"),
+			},
+			{
+				name: "decimal_slice",
+				idx:  mustRange(newDecimal("1"), nil, nil),
+				want: NewList([]execute.Value{NewInt(2), NewInt(3)}),
+			},
+			{
+				name: "decimal_slice_reversed",
+				idx:  mustRange(nil, newDecimal("0"), newDecimal("-1")),
+				want: NewList([]execute.Value{NewInt(3), NewInt(2)}),
+			},
+			{
+				name:    "float",
+				idx:     NewFloat(1),
+				wantErr: errors.NonNumericIndexError(FloatType, ListType),
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := v.GetIndex(tc.idx)
+				testhelpers.CheckDiff(t, "GetIndex() error", tc.wantErr, err, allowUnexported)
+				testhelpers.CheckDiff(t, "GetIndex()", tc.want, got, allowUnexported)
+			})
+		}
 	})
 
 	t.Run("HasAttribute", func(t *testing.T) {
@@ -69,7 +69,7 @@ var mapMethods = map[string]func(*Map) execute.Value{
 			if got, want := len(vs), 0; got != want {
 				return nil, errors.CallError(name, got, want)
 			}
-			return &Map{v.seed, v.cloneEntries(), v.size, true}, nil
+			return v.clone(true), nil
 		})
 	},
 	"to_mutable": func(v *Map) execute.Value {
@@ -78,16 +78,19 @@ var mapMethods = map[string]func(*Map) execute.Value{
 			if got, want := len(vs), 0; got != want {
 				return nil, errors.CallError(name, got, want)
 			}
-			return &Map{v.seed, v.cloneEntries(), v.size, false}, nil
+			return v.clone(false), nil
 		})
 	},
 }
 
 type mapEntries map[uint64][]*mapEntry
 
+// Map is a hash map whose keys are iterated over in the order they were first set.
 type Map struct {
-	seed      maphash.Seed
-	entries   mapEntries
+	seed    maphash.Seed
+	entries mapEntries
+	// order contains the entries of the map in the order their keys were first set.
+	order     []*mapEntry
 	size      uint64
 	immutable bool
 }
@@ -101,15 +104,16 @@ func NewMap() *Map {
 	return &Map{seed: maphash.MakeSeed(), entries: make(map[uint64][]*mapEntry)}
 }
 
-func (v *Map) cloneEntries() mapEntries {
-	entries := make(map[uint64][]*mapEntry)
-	for k, vs := range v.entries {
-		for _, v := range vs {
-			vCopy := *v
-			entries[k] = append(entries[k], &vCopy)
-		}
+func (v *Map) clone(immutable bool) *Map {
+	c := &Map{seed: v.seed, entries: make(map[uint64][]*mapEntry), size: v.size, immutable: immutable}
+	for _, e := range v.order {
+		// The key was hashed when it was set, so hashing it again can't fail.
+		h := must(c.hash(e.key))
+		eCopy := *e
+		c.entries[h] = append(c.entries[h], &eCopy)
+		c.order = append(c.order, &eCopy)
 	}
-	return entries
+	return c
 }
 
 func (v *Map) hash(val execute.Value) (uint64, error) {
@@ -172,7 +176,9 @@ func (v *Map) Set(key execute.Value, value execute.Value) (execute.Value, error)
 		}
 	}
 	if !found {
-		v.entries[h] = append(v.entries[h], &mapEntry{key, value})
+		e := &mapEntry{key, value}
+		v.entries[h] = append(v.entries[h], e)
+		v.order = append(v.order, e)
 		v.size++
 	}
 	return NewBool(found), nil
@@ -238,10 +244,8 @@ func (v *Map) SetIndex(i execute.Value, val execute.Value) error {
 
 func (v *Map) String() string {
 	var items []string
-	for _, es := range v.entries {
-		for _, e := range es {
-			items = append(items, fmt.Sprintf("%s: %s", e.key.String(), e.value.String()))
-		}
+	for _, e := range v.order {
+		items = append(items, fmt.Sprintf("%s: %s", e.key.String(), e.value.String()))
 	}
 	return fmt.Sprintf("{%s}", strings.Join(items, ", "))
 }
@@ -290,10 +294,8 @@ type mapIterator struct {
 
 func newMapIterator(m *Map) *mapIterator {
 	var keys []execute.Value
-	for _, es := range m.entries {
-		for _, e := range es {
-			keys = append(keys, e.key)
-		}
+	for _, e := range m.order {
+		keys = append(keys, e.key)
 	}
 	return &mapIterator{m, keys, 0}
 }
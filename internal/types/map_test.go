@@ -3,6 +3,8 @@ package types
 import (
 	"testing"
 
+	"github.com/chrispyles/slow/internal/execute"
+	testhelpers "github.com/chrispyles/slow/internal/testing/helpers"
 	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
 )
 
@@ -58,7 +60,13 @@ func TestMap(t *testing.T) {
 	})
 
 	t.Run("String", func(t *testing.T) {
-		// TODO
+		m := NewMap()
+		m.Set(NewStr("b"), NewInt(1))
+		m.Set(NewInt(2), NewStr("a"))
+		m.Set(NewStr("b"), NewInt(3))
+		if got, want := m.String(), `{"b": 3, 2: "a"}`; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
 	})
 
 	t.Run("ToBool", func(t *testing.T) {
@@ -82,7 +90,22 @@ func TestMap(t *testing.T) {
 	})
 
 	t.Run("ToIterator", func(t *testing.T) {
-		// TODO
+		m := NewMap()
+		var want []execute.Value
+		for _, k := range []string{"c", "a", "d", "b", "a"} {
+			if found, _ := m.Set(NewStr(k), Null); !found.ToBool() {
+				want = append(want, NewStr(k))
+			}
+		}
+		// Copies of the map keep the order of its keys.
+		for name, v := range map[string]*Map{"map": m, "immutable_copy": m.clone(true)} {
+			var got []execute.Value
+			it := must(v.ToIterator())
+			for it.HasNext() {
+				got = append(got, must(it.Next()))
+			}
+			testhelpers.CheckDiff(t, name+" keys", want, got, allowUnexported)
+		}
 	})
 
 	t.Run("ToStr", func(t *testing.T) {
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
@@ -32,12 +33,28 @@ var ModuleType = &moduleType{}
 // -------------------------------------------------------------------------------------------------
 
 type Module struct {
-	name string
-	env  *execute.Environment
+	name    string
+	env     *execute.Environment
+	exports []string
 }
 
+// NewModule returns a module that exports every variable declared in the provided environment.
 func NewModule(name string, env *execute.Environment) *Module {
-	return &Module{name, env}
+	return NewModuleWithExports(name, env, env.Names())
+}
+
+// NewModuleWithExports returns a module that only exports the variables with the provided names.
+func NewModuleWithExports(name string, env *execute.Environment, exports []string) *Module {
+	return &Module{name, env, exports}
+}
+
+// Exports returns the names of the variables exported by this module.
+func (v *Module) Exports() []string {
+	return v.exports
+}
+
+func (v *Module) isExported(a string) bool {
+	return slices.Contains(v.exports, a)
 }
 
 func (v *Module) CloneIfPrimitive() execute.Value {
@@ -57,6 +74,9 @@ func (v *Module) Equals(o execute.Value) bool {
 }
 
 func (v *Module) GetAttribute(a string) (execute.Value, error) {
+	if !v.isExported(a) {
+		return nil, errors.NewAttributeError(v.Type(), a)
+	}
 	return v.env.Get(a)
 }
 
@@ -65,7 +85,7 @@ func (v *Module) GetIndex(execute.Value) (execute.Value, error) {
 }
 
 func (v *Module) HasAttribute(a string) bool {
-	return v.env.Has(a)
+	return v.isExported(a)
 }
 
 func (v *Module) HashBytes() ([]byte, error) {
@@ -2,7 +2,9 @@ package types
 
 import (
 	"fmt"
+	"math/big"
 
+	"github.com/chrispyles/slow/internal/decimal"
 	"github.com/chrispyles/slow/internal/errors"
 	"github.com/chrispyles/slow/internal/execute"
 )
@@ -26,6 +28,16 @@ type RangeIterator struct {
 	startU uint64
 	stopU  uint64
 	stepU  uint64
+
+	nextB  *big.Int
+	startB *big.Int
+	stopB  *big.Int
+	stepB  *big.Int
+
+	nextD  decimal.Decimal
+	startD decimal.Decimal
+	stopD  decimal.Decimal
+	stepD  decimal.Decimal
 }
 
 func NewRangeGenerator(start, stop, step execute.Value) (execute.Value, error) {
@@ -75,6 +87,28 @@ func makeRange(start, stop, step execute.Value) (*RangeIterator, error) {
 		stop = NewUint(0)
 	}
 	switch commonType {
+	case DecimalType:
+		startC, err := ToDecimal(start)
+		if err != nil {
+			return nil, err
+		}
+		stopC, err := ToDecimal(stop)
+		if err != nil {
+			return nil, err
+		}
+		stepC, err := ToDecimal(step)
+		if err != nil {
+			return nil, err
+		}
+		return &RangeIterator{
+			valueType:        DecimalType,
+			incr:             incr,
+			truncToContainer: truncToContainer,
+			nextD:            startC,
+			startD:           startC,
+			stopD:            stopC,
+			stepD:            stepC,
+		}, nil
 	case FloatType:
 		startC, err := start.ToFloat()
 		if err != nil {
@@ -97,6 +131,28 @@ func makeRange(start, stop, step execute.Value) (*RangeIterator, error) {
 			stopF:            stopC,
 			stepF:            stepC,
 		}, nil
+	case BigIntType:
+		startC, err := ToBigInt(start)
+		if err != nil {
+			return nil, err
+		}
+		stopC, err := ToBigInt(stop)
+		if err != nil {
+			return nil, err
+		}
+		stepC, err := ToBigInt(step)
+		if err != nil {
+			return nil, err
+		}
+		return &RangeIterator{
+			valueType:        BigIntType,
+			incr:             incr,
+			truncToContainer: truncToContainer,
+			nextB:            startC,
+			startB:           startC,
+			stopB:            stopC,
+			stepB:            stepC,
+		}, nil
 	case IntType:
 		startC, err := start.ToInt()
 		if err != nil {
@@ -150,12 +206,24 @@ func makeRange(start, stop, step execute.Value) (*RangeIterator, error) {
 func (g *RangeIterator) HasNext() bool {
 	// TODO: panic if truncToContainer is true????
 	switch g.valueType {
+	case DecimalType:
+		if g.incr {
+			return g.nextD.Cmp(g.stopD) < 0
+		} else {
+			return g.nextD.Cmp(g.stopD) > 0
+		}
 	case FloatType:
 		if g.incr {
 			return g.nextF < g.stopF
 		} else {
 			return g.nextF > g.stopF
 		}
+	case BigIntType:
+		if g.incr {
+			return g.nextB.Cmp(g.stopB) < 0
+		} else {
+			return g.nextB.Cmp(g.stopB) > 0
+		}
 	case IntType:
 		if g.incr {
 			return g.nextI < g.stopI
@@ -177,10 +245,18 @@ func (g *RangeIterator) Next() (execute.Value, error) {
 		return nil, errors.NewValueError("a range without endpoints may only be used for indexing")
 	}
 	switch g.valueType {
+	case DecimalType:
+		var curr decimal.Decimal
+		curr, g.nextD = g.nextD, g.nextD.Add(g.stepD)
+		return NewDecimal(curr), nil
 	case FloatType:
 		var curr float64
 		curr, g.nextF = g.nextF, g.nextF+g.stepF
 		return NewFloat(curr), nil
+	case BigIntType:
+		var curr *big.Int
+		curr, g.nextB = g.nextB, new(big.Int).Add(g.nextB, g.stepB)
+		return NewBigInt(curr), nil
 	case IntType:
 		var curr int64
 		curr, g.nextI = g.nextI, g.nextI+g.stepI
@@ -201,6 +277,16 @@ func (g *RangeIterator) WithContainerLen(l uint64) *Generator {
 	}
 	if copy.truncToContainer {
 		switch copy.valueType {
+		case DecimalType:
+			if !copy.startD.Equal(copy.nextD) {
+				panic("WithContainerLen called after iteration started")
+			}
+			if copy.incr {
+				copy.stopD = decimal.FromInt(new(big.Int).SetUint64(l))
+			} else {
+				copy.startD = decimal.FromInt(new(big.Int).SetUint64(l))
+				copy.nextD = copy.startD
+			}
 		case FloatType:
 			if copy.startF != copy.nextF {
 				panic("WithContainerLen called after iteration started")
@@ -211,6 +297,16 @@ func (g *RangeIterator) WithContainerLen(l uint64) *Generator {
 				copy.startF = float64(l)
 				copy.nextF = float64(l)
 			}
+		case BigIntType:
+			if copy.startB.Cmp(copy.nextB) != 0 {
+				panic("WithContainerLen called after iteration started")
+			}
+			if copy.incr {
+				copy.stopB = new(big.Int).SetUint64(l)
+			} else {
+				copy.startB = new(big.Int).SetUint64(l)
+				copy.nextB = copy.startB
+			}
 		case IntType:
 			if copy.startI != copy.nextI {
 				panic("WithContainerLen called after iteration started")
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/chrispyles/slow/internal/errors"
@@ -57,6 +58,43 @@ func TestRange(t *testing.T) {
 				NewUint(0),
 			},
 		},
+		{
+			name: "common_bigint_type",
+			args: []execute.Value{NewInt(0), NewBigInt(big.NewInt(5)), NewUint(2)},
+			want: []execute.Value{
+				NewBigInt(big.NewInt(0)),
+				NewBigInt(big.NewInt(2)),
+				NewBigInt(big.NewInt(4)),
+			},
+		},
+		{
+			name: "bigint_decrementing",
+			args: []execute.Value{NewBigInt(big.NewInt(3)), NewBigInt(big.NewInt(0)), NewBigInt(big.NewInt(-1))},
+			want: []execute.Value{
+				NewBigInt(big.NewInt(3)),
+				NewBigInt(big.NewInt(2)),
+				NewBigInt(big.NewInt(1)),
+			},
+		},
+		{
+			name: "common_decimal_type",
+			args: []execute.Value{NewInt(0), newDecimal("2.5"), NewUint(1)},
+			want: []execute.Value{
+				newDecimal("0"),
+				newDecimal("1"),
+				newDecimal("2"),
+			},
+		},
+		{
+			name: "decimal_step",
+			args: []execute.Value{newDecimal("1"), newDecimal("0"), newDecimal("-0.25")},
+			want: []execute.Value{
+				newDecimal("1"),
+				newDecimal("0.75"),
+				newDecimal("0.50"),
+				newDecimal("0.25"),
+			},
+		},
 		{
 			name:       "non_numeric_arg",
 			args:       []execute.Value{NewStr("10"), NewInt(20), NewInt(2)},
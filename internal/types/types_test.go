@@ -2,13 +2,18 @@ package types
 
 import (
 	"github.com/chrispyles/slow/internal/errors"
+	typestesting "github.com/chrispyles/slow/internal/types/internal/testing"
 	"github.com/google/go-cmp/cmp"
 )
 
-var allowUnexported = cmp.AllowUnexported(
+var allowUnexported = cmp.Options{cmp.AllowUnexported(
 	errors.SlowError{},
+	BigInt{},
 	Bool{},
 	Bytes{},
+	Datetime{},
+	Decimal{},
+	Duration{},
 	Float{},
 	Func{},
 	Generator{},
@@ -19,4 +24,4 @@ var allowUnexported = cmp.AllowUnexported(
 	RangeIterator{},
 	Str{},
 	Uint{},
-)
+), typestesting.EquateBigInts()}
@@ -9,8 +9,12 @@ import (
 )
 
 var AllTypes = []execute.Type{
+	BigIntType,
 	BoolType,
 	BytesType,
+	DatetimeType,
+	DecimalType,
+	DurationType,
 	FloatType,
 	FuncType,
 	GeneratorType,
@@ -34,10 +38,12 @@ func GetType(typeName string) (execute.Type, bool) {
 }
 
 var typeHierarchy = map[execute.Type]int{
-	FloatType: 0,
-	IntType:   1,
-	UintType:  2,
-	BoolType:  3,
+	DecimalType: 0,
+	FloatType:   1,
+	BigIntType:  2,
+	IntType:     3,
+	UintType:    4,
+	BoolType:    5,
 }
 
 func CommonNumericType(t1 execute.Type, t2 execute.Type) (execute.Type, bool) {
@@ -88,6 +94,21 @@ func numericIndex(v execute.Value, t errors.Type) (int, error) {
 		ret = int(vu.value)
 	} else if vi, ok := v.(*Int); ok {
 		ret = int(vi.value)
+	} else if vb, ok := v.(*BigInt); ok {
+		// Indices that don't fit in an int are out of range for every container, so they are clamped.
+		switch {
+		case vb.value.IsInt64():
+			ret = int(vb.value.Int64())
+		case vb.value.Sign() < 0:
+			ret = math.MinInt
+		default:
+			ret = math.MaxInt
+		}
+	} else if vd, ok := v.(*Decimal); ok {
+		if !vd.value.IsInteger() {
+			return 0, errors.NewIndexError(vd.String())
+		}
+		return numericIndex(NewBigInt(vd.value.Int()), t)
 	} else if vb, ok := v.(*Bool); ok {
 		ret = int(must(vb.ToInt()))
 	} else {
@@ -0,0 +1,254 @@
+// Package slow provides an embeddable interpreter for the Slow programming language.
+//
+// Each Interpreter is fully isolated from every other: it has its own global environment, standard
+// streams, module registry, and options, so a program may create as many interpreters as it needs.
+// An Interpreter is not safe for concurrent use, but separate interpreters may be used from separate
+// goroutines.
+package slow
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/chrispyles/slow/internal/builtins"
+	"github.com/chrispyles/slow/internal/builtins/modules"
+	"github.com/chrispyles/slow/internal/decimal"
+	"github.com/chrispyles/slow/internal/eval"
+	"github.com/chrispyles/slow/internal/execute"
+	"github.com/chrispyles/slow/internal/marshal"
+)
+
+// Value is a value in the Slow language.
+type Value = execute.Value
+
+// ExitError is the error returned when a Slow program calls the exit builtin.
+type ExitError = builtins.ExitError
+
+// RoundingMode determines how the results of decimal arithmetic are rounded. See
+// WithDecimalContext.
+type RoundingMode = decimal.RoundingMode
+
+// DefaultDecimalPrecision is the default number of significant digits in inexact decimal results.
+const DefaultDecimalPrecision = decimal.DefaultPrecision
+
+const (
+	RoundHalfEven = decimal.HalfEven
+	RoundHalfUp   = decimal.HalfUp
+	RoundHalfDown = decimal.HalfDown
+	RoundUp       = decimal.Up
+	RoundDown     = decimal.Down
+	RoundCeiling  = decimal.Ceiling
+	RoundFloor    = decimal.Floor
+)
+
+// Module is a module that Slow code can import by name. See Interpreter.RegisterModule.
+type Module = modules.Module
+
+// Environment is a set of variable bindings, such as the members of a module.
+type Environment = execute.Environment
+
+// NewEnvironment returns a frozen environment containing the provided variables. It can be used to
+// implement Module.Import.
+func NewEnvironment(vars map[string]Value) *Environment {
+	return execute.FromMap(vars)
+}
+
+// NewModule returns a Module with the provided name whose members are created by calling init the
+// first time that the module is imported by an interpreter. Each member is converted using ToValue.
+func NewModule(name string, init func() (map[string]any, error)) Module {
+	return &hostModule{name: name, init: init}
+}
+
+type hostModule struct {
+	name string
+	init func() (map[string]any, error)
+}
+
+func (m *hostModule) Name() string {
+	return m.name
+}
+
+func (m *hostModule) Import() (*Environment, error) {
+	members, err := m.init()
+	if err != nil {
+		return nil, err
+	}
+	vars := make(map[string]Value, len(members))
+	for n, v := range members {
+		val, err := namedValue(m.name+"."+n, v)
+		if err != nil {
+			return nil, err
+		}
+		vars[n] = val
+	}
+	return NewEnvironment(vars), nil
+}
+
+// ToValue converts a Go value into a Slow value. Maps, slices, and structs are copied into Slow
+// maps and lists; pointers to structs are wrapped so that Slow code can read and set their fields
+// and call their methods; and functions are wrapped so that their arguments and results are
+// converted automatically. Struct fields are named by lowercasing the first word of the field name
+// and can be renamed with a `slow:"name"` tag, omitted when empty with `slow:",omitempty"`, or
+// hidden with `slow:"-"`.
+func ToValue(v any) (Value, error) {
+	return marshal.ToValue(v)
+}
+
+// FromValue stores the Go representation of a Slow value in the value pointed to by dst. It is the
+// inverse of ToValue and uses the same struct tags. Values that can't be converted to the type of
+// dst result in a TypeError.
+func FromValue(v Value, dst any) error {
+	return marshal.FromValue(v, dst)
+}
+
+// Interpreter is a single, isolated instance of the Slow interpreter.
+type Interpreter struct {
+	runtime *execute.Runtime
+	modules *modules.Registry
+	root    *execute.Environment
+	env     *execute.Environment
+}
+
+// Option configures an Interpreter.
+type Option func(*Interpreter)
+
+// WithStdout sets the writer that the interpreter prints to. Defaults to os.Stdout.
+func WithStdout(w io.Writer) Option {
+	return func(i *Interpreter) { i.runtime.Stdout = w }
+}
+
+// WithStderr sets the writer that the interpreter writes errors to. Defaults to os.Stderr.
+func WithStderr(w io.Writer) Option {
+	return func(i *Interpreter) { i.runtime.Stderr = w }
+}
+
+// WithStdin sets the reader that the interpreter reads input from. Defaults to os.Stdin.
+func WithStdin(r io.Reader) Option {
+	return func(i *Interpreter) { i.runtime.Stdin = r }
+}
+
+// WithDebug sets whether the interpreter prints ASTs and values as they are evaluated.
+func WithDebug(debug bool) Option {
+	return func(i *Interpreter) { i.runtime.Debug = debug }
+}
+
+// WithCheckedArithmetic sets whether int and uint arithmetic and casts raise an OverflowError when a
+// result doesn't fit in its type, instead of wrapping around.
+func WithCheckedArithmetic(checked bool) Option {
+	return func(i *Interpreter) { i.runtime.CheckedArithmetic = checked }
+}
+
+// WithDecimalContext sets the number of significant digits that decimal results which can't be
+// represented exactly (like 1d / 3) are rounded to, and how they are rounded. Defaults to 28 digits
+// rounded half to even.
+func WithDecimalContext(precision int, rounding RoundingMode) Option {
+	return func(i *Interpreter) { i.runtime.Decimal = decimal.Context{Precision: precision, Rounding: rounding} }
+}
+
+// WithScript sets the path to the file containing the main program. Files that the program imports
+// are resolved relative to the directory containing it. If no script is set, imports are resolved
+// relative to the working directory.
+func WithScript(path string) Option {
+	return func(i *Interpreter) { i.runtime.Script = path }
+}
+
+// WithArgs sets the arguments passed to the main program, which it can read from sys.argv.
+func WithArgs(args ...string) Option {
+	return func(i *Interpreter) { i.runtime.Args = args }
+}
+
+// WithSearchPath adds directories to the list that imported files are searched for in when they
+// can't be found relative to the importing file.
+func WithSearchPath(dirs ...string) Option {
+	return func(i *Interpreter) { i.runtime.SearchPath = append(i.runtime.SearchPath, dirs...) }
+}
+
+// New creates a new Interpreter configured with the provided options.
+func New(opts ...Option) *Interpreter {
+	rt := execute.NewRuntime()
+	i := &Interpreter{
+		runtime: rt,
+		modules: modules.NewRegistry(rt),
+	}
+	for _, o := range opts {
+		o(i)
+	}
+	i.root = builtins.NewRootEnvironment(i.runtime, i.modules)
+	i.env = i.root.NewFrame()
+	return i
+}
+
+// Eval executes the provided code in the interpreter's global environment and returns the value of
+// the last statement.
+func (i *Interpreter) Eval(code string) (Value, error) {
+	return eval.Eval(code, i.env)
+}
+
+// Get returns the value of the global variable with the provided name.
+func (i *Interpreter) Get(name string) (Value, error) {
+	return i.env.Get(name)
+}
+
+// Set sets the value of the global variable with the provided name, declaring it if it has not
+// already been declared.
+func (i *Interpreter) Set(name string, v Value) error {
+	if !i.env.Has(name) {
+		if err := i.env.Declare(name); err != nil {
+			return err
+		}
+	}
+	_, err := i.env.Set(name, v)
+	return err
+}
+
+// Register converts a Go value with ToValue and binds it to the global variable with the provided
+// name. This is the usual way to expose Go functions and structs to Slow code:
+//
+//	interp.Register("greet", func(name string) string { return "Hello, " + name })
+//	interp.Register("config", &cfg)
+func (i *Interpreter) Register(name string, v any) error {
+	val, err := namedValue(name, v)
+	if err != nil {
+		return err
+	}
+	return i.Set(name, val)
+}
+
+// namedValue converts v with ToValue, except that functions are given the provided name.
+func namedValue(name string, v any) (Value, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Func && !rv.IsNil() {
+		return marshal.NewFunc(name, rv)
+	}
+	return ToValue(v)
+}
+
+// RegisterModule makes a module available to the interpreter's import builtin. Module names may be
+// namespaced with slashes, e.g. "acme/db". The module is not imported until Slow code imports it,
+// and it is imported at most once per interpreter. An error is returned if the name is invalid or
+// is already used by another module.
+func (i *Interpreter) RegisterModule(m Module) error {
+	return i.modules.Register(m)
+}
+
+// Call calls the function bound to the global variable with the provided name.
+func (i *Interpreter) Call(name string, args ...Value) (Value, error) {
+	f, err := i.env.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	c, err := f.ToCallable()
+	if err != nil {
+		return nil, err
+	}
+	return c.Call(i.env.NewFrame(), args...)
+}
+
+// Stdout returns the writer that the interpreter prints to.
+func (i *Interpreter) Stdout() io.Writer {
+	return i.runtime.Stdout
+}
+
+// Stderr returns the writer that the interpreter writes errors to.
+func (i *Interpreter) Stderr() io.Writer {
+	return i.runtime.Stderr
+}
@@ -0,0 +1,285 @@
+package slow_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chrispyles/slow"
+	"github.com/chrispyles/slow/internal/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInterpreter_Eval(t *testing.T) {
+	var stdout strings.Builder
+	interp := slow.New(slow.WithStdout(&stdout))
+	got, err := interp.Eval("print(\"hi\")\n1 + 2")
+	if err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	if !got.Equals(types.NewInt(3)) {
+		t.Errorf("Eval() returned %v, want 3", got)
+	}
+	if diff := cmp.Diff("hi\n", stdout.String()); diff != "" {
+		t.Errorf("Eval() printed incorrectly (-want +got):\n%s", diff)
+	}
+}
+
+func TestInterpreter_EvalError(t *testing.T) {
+	interp := slow.New()
+	if _, err := interp.Eval("foo"); err == nil {
+		t.Errorf("Eval() did not return an error")
+	}
+}
+
+func TestInterpreter_Exit(t *testing.T) {
+	var stdout strings.Builder
+	interp := slow.New(slow.WithStdout(&stdout))
+	_, err := interp.Eval("exit(3)")
+	ee, ok := err.(*slow.ExitError)
+	if !ok {
+		t.Fatalf("Eval() returned incorrect error: %v", err)
+	}
+	if ee.Code != 3 {
+		t.Errorf("ExitError.Code = %d, want 3", ee.Code)
+	}
+}
+
+func TestInterpreter_Args(t *testing.T) {
+	var stdout, stderr strings.Builder
+	interp := slow.New(
+		slow.WithStdout(&stdout),
+		slow.WithStderr(&stderr),
+		slow.WithStdin(strings.NewReader("input\n")),
+		slow.WithScript("main.slo"),
+		slow.WithArgs("a", "--b"),
+	)
+	code := "import \"sys\" as sys\nprint(sys.argv)\nsys.stderr.write(sys.stdin.readline())"
+	if _, err := interp.Eval(code); err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("[\"main.slo\", \"a\", \"--b\"]\n", stdout.String()); diff != "" {
+		t.Errorf("Eval() printed incorrectly to stdout (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("input", stderr.String()); diff != "" {
+		t.Errorf("Eval() printed incorrectly to stderr (-want +got):\n%s", diff)
+	}
+}
+
+func TestInterpreter_CheckedArithmetic(t *testing.T) {
+	for _, code := range []string{"9223372036854775807 + 1", "-1 as uint", "var u = 0u\n--u"} {
+		if _, err := slow.New().Eval(code); err != nil {
+			t.Errorf("Eval(%q) returned an unexpected error: %v", code, err)
+		}
+		_, err := slow.New(slow.WithCheckedArithmetic(true)).Eval(code)
+		if err == nil || !strings.HasPrefix(err.Error(), "OverflowError: ") {
+			t.Errorf("Eval(%q) with checked arithmetic returned %v, want an OverflowError", code, err)
+		}
+	}
+}
+
+func TestInterpreter_DecimalContext(t *testing.T) {
+	for _, tc := range []struct {
+		opts []slow.Option
+		want string
+	}{
+		{want: "0.6666666666666666666666666667d"},
+		{opts: []slow.Option{slow.WithDecimalContext(4, slow.RoundHalfEven)}, want: "0.6667d"},
+		{opts: []slow.Option{slow.WithDecimalContext(4, slow.RoundDown)}, want: "0.6666d"},
+	} {
+		got, err := slow.New(tc.opts...).Eval("2d / 3")
+		if err != nil {
+			t.Fatalf("Eval() returned an unexpected error: %v", err)
+		}
+		if got.String() != tc.want {
+			t.Errorf("Eval() = %v, want %s", got, tc.want)
+		}
+	}
+}
+
+func TestInterpreter_GetSet(t *testing.T) {
+	interp := slow.New()
+	if err := interp.Set("x", types.NewInt(1)); err != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", err)
+	}
+	if _, err := interp.Eval("x += 1"); err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	got, err := interp.Get("x")
+	if err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if !got.Equals(types.NewInt(2)) {
+		t.Errorf("Get() returned %v, want 2", got)
+	}
+	// Setting a declared variable should reassign it.
+	if err := interp.Set("x", types.NewInt(5)); err != nil {
+		t.Fatalf("Set() returned an unexpected error: %v", err)
+	}
+	if got, _ := interp.Get("x"); !got.Equals(types.NewInt(5)) {
+		t.Errorf("Get() returned %v, want 5", got)
+	}
+}
+
+func TestInterpreter_Call(t *testing.T) {
+	interp := slow.New()
+	if _, err := interp.Eval("func add(a, b) {\n  return a + b\n}"); err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	got, err := interp.Call("add", types.NewInt(1), types.NewInt(2))
+	if err != nil {
+		t.Fatalf("Call() returned an unexpected error: %v", err)
+	}
+	if !got.Equals(types.NewInt(3)) {
+		t.Errorf("Call() returned %v, want 3", got)
+	}
+	if _, err := interp.Call("nope"); err == nil {
+		t.Errorf("Call() did not return an error for an undeclared function")
+	}
+	interp.Set("notAFunc", types.NewInt(1))
+	if _, err := interp.Call("notAFunc"); err == nil {
+		t.Errorf("Call() did not return an error for a non-callable value")
+	}
+}
+
+func TestInterpreter_Isolation(t *testing.T) {
+	var wg sync.WaitGroup
+	outs := make([]strings.Builder, 8)
+	for i := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			interp := slow.New(slow.WithStdout(&outs[i]))
+			code := fmt.Sprintf("var x = %d\nfor i in range(100) {\n  x += 1\n}\nprint(x)", i)
+			if _, err := interp.Eval(code); err != nil {
+				t.Errorf("Eval() returned an unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	for i := range outs {
+		if got, want := outs[i].String(), fmt.Sprintf("%d\n", i+100); got != want {
+			t.Errorf("interpreter %d printed %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestInterpreter_Register(t *testing.T) {
+	type config struct {
+		Name    string
+		Retries int `slow:"maxRetries"`
+	}
+	var stdout strings.Builder
+	interp := slow.New(slow.WithStdout(&stdout))
+	cfg := &config{Name: "svc", Retries: 1}
+	if err := interp.Register("config", cfg); err != nil {
+		t.Fatalf("Register() returned an unexpected error: %v", err)
+	}
+	if err := interp.Register("greet", func(name string) string { return "Hello, " + name }); err != nil {
+		t.Fatalf("Register() returned an unexpected error: %v", err)
+	}
+	if _, err := interp.Eval("config.maxRetries += 2\nprint(greet(config.name))"); err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("Hello, svc\n", stdout.String()); diff != "" {
+		t.Errorf("Eval() printed incorrectly (-want +got):\n%s", diff)
+	}
+	if cfg.Retries != 3 {
+		t.Errorf("config.Retries = %d, want 3", cfg.Retries)
+	}
+	if _, err := interp.Eval("greet(1)"); err == nil || !strings.Contains(err.Error(), "TypeError") {
+		t.Errorf("Eval() returned incorrect error for a bad argument: %v", err)
+	}
+	if err := interp.Register("ch", make(chan int)); err == nil {
+		t.Errorf("Register() did not return an error for an unsupported type")
+	}
+}
+
+func TestToValueFromValue(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	in := map[string][]point{"line": {{1, 2}, {3, 4}}}
+	v, err := slow.ToValue(in)
+	if err != nil {
+		t.Fatalf("ToValue() returned an unexpected error: %v", err)
+	}
+	var out map[string][]point
+	if err := slow.FromValue(v, &out); err != nil {
+		t.Fatalf("FromValue() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(in, out); diff != "" {
+		t.Errorf("FromValue(ToValue()) returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestInterpreter_RegisterModule(t *testing.T) {
+	var stdout strings.Builder
+	interp := slow.New(slow.WithStdout(&stdout))
+	inits := 0
+	db := slow.NewModule("acme/db", func() (map[string]any, error) {
+		inits++
+		return map[string]any{
+			"query":   func(q string) []string { return []string{q, q} },
+			"version": 2,
+		}, nil
+	})
+	if err := interp.RegisterModule(db); err != nil {
+		t.Fatalf("RegisterModule() returned an unexpected error: %v", err)
+	}
+	if inits != 0 {
+		t.Errorf("RegisterModule() initialized the module")
+	}
+	code := `const db = import("acme/db")
+const db2 = import("acme/db")
+print(db.query("x"), db.version, db == db2)
+print(modules())`
+	if _, err := interp.Eval(code); err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("[\"x\", \"x\"]2true\n[\"acme/db\", \"csv\", \"fs\", \"json\", \"math\", \"os\", \"path\", \"random\", \"re\", \"sys\", \"time\"]\n", stdout.String()); diff != "" {
+		t.Errorf("Eval() printed incorrectly (-want +got):\n%s", diff)
+	}
+	if inits != 1 {
+		t.Errorf("module was initialized %d times, want 1", inits)
+	}
+	if err := interp.RegisterModule(db); err == nil {
+		t.Errorf("RegisterModule() did not return an error for a duplicate module")
+	}
+
+	// Modules are registered per interpreter.
+	if _, err := slow.New().Eval(`import("acme/db")`); err == nil {
+		t.Errorf("Eval() did not return an error for a module registered with another interpreter")
+	}
+}
+
+func TestInterpreter_imports(t *testing.T) {
+	dir := t.TempDir()
+	for name, code := range map[string]string{
+		"main.slo":              `const util = import("util.slo")`,
+		"util.slo":              `var answer = import("lib").answer`,
+		"include/lib/index.slo": `var answer = 42`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	interp := slow.New(
+		slow.WithScript(filepath.Join(dir, "main.slo")),
+		slow.WithSearchPath(filepath.Join(dir, "include")),
+	)
+	got, err := interp.Eval(`import("util.slo").answer`)
+	if err != nil {
+		t.Fatalf("Eval() returned an unexpected error: %v", err)
+	}
+	if !got.Equals(types.NewInt(42)) {
+		t.Errorf("Eval() returned %v, want 42", got)
+	}
+}